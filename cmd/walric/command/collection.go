@@ -0,0 +1,198 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/virtualtam/walric/pkg/collection"
+)
+
+// NewCollectionCommand initializes a CLI command to manage collections of
+// submissions.
+func NewCollectionCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "collection",
+		Short: "Manage curated collections of submissions",
+	}
+
+	cmd.AddCommand(
+		newCollectionAddCommand(),
+		newCollectionCreateCommand(),
+		newCollectionRemoveCommand(),
+		newCollectionRotateCommand(),
+		newCollectionShowCommand(),
+	)
+
+	return cmd
+}
+
+func newCollectionAddCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add NAME POST_ID...",
+		Short: "Append submissions to a collection",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			c, err := collectionService.ByName(ctx, args[0])
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			for _, postID := range args[1:] {
+				sub, err := submissionService.ByPostID(ctx, postID)
+				if err != nil {
+					cobra.CheckErr(err)
+				}
+
+				if err := collectionService.Add(ctx, c, sub); err != nil {
+					cobra.CheckErr(err)
+				}
+			}
+		},
+	}
+
+	return cmd
+}
+
+func newCollectionCreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create NAME",
+		Short: "Create a collection",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			if err := collectionService.Create(ctx, collection.NewCollection(args[0])); err != nil {
+				cobra.CheckErr(err)
+			}
+		},
+	}
+
+	return cmd
+}
+
+func newCollectionRemoveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove NAME POST_ID...",
+		Short: "Remove submissions from a collection",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			c, err := collectionService.ByName(ctx, args[0])
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			for _, postID := range args[1:] {
+				sub, err := submissionService.ByPostID(ctx, postID)
+				if err != nil {
+					cobra.CheckErr(err)
+				}
+
+				if err := collectionService.Remove(ctx, c, sub); err != nil {
+					cobra.CheckErr(err)
+				}
+			}
+		},
+	}
+
+	return cmd
+}
+
+func newCollectionRotateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate NAME",
+		Short: "Select the next submission from a collection",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			c, err := collectionService.ByName(ctx, args[0])
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			sub, err := collectionService.Next(ctx, c)
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			if err := saveSelection(ctx, sub); err != nil {
+				cobra.CheckErr(err)
+			}
+		},
+	}
+
+	return cmd
+}
+
+func newCollectionShowCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show [NAME]",
+		Short: "List the submissions in a collection, or all collections if no name is given",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+			defer writer.Flush()
+
+			if len(args) == 0 {
+				collections, err := collectionService.All(ctx)
+				if err != nil {
+					cobra.CheckErr(err)
+				}
+
+				fmt.Fprintln(writer, "Count\tCollection\t")
+				fmt.Fprintln(writer, "-----\t----------\t")
+				fmt.Fprintln(writer, "\t\t")
+
+				for _, c := range collections {
+					items, err := collectionService.Items(ctx, c)
+					if err != nil {
+						cobra.CheckErr(err)
+					}
+
+					fmt.Fprintf(writer, "%d\t%s\t\n", len(items), c.Name)
+				}
+
+				return
+			}
+
+			c, err := collectionService.ByName(ctx, args[0])
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			items, err := collectionService.Items(ctx, c)
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			for _, item := range items {
+				marker := ""
+				if item.Position == c.Cursor {
+					marker = "*"
+				}
+
+				fmt.Fprintf(
+					writer,
+					"%s\t%s\t%s\t%d x %d\t%s\n",
+					marker,
+					item.Submission.Subreddit.Name,
+					item.Submission.PostID,
+					item.Submission.ImageWidthPx,
+					item.Submission.ImageHeightPx,
+					item.Submission.Title,
+				)
+			}
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,40 @@
+package command
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/virtualtam/walric/pkg/monitor"
+)
+
+const (
+	maxCropPercentUnset int = -1
+)
+
+// addMaxCropFlag registers the flag setting the maximum crop percentage of a
+// command.
+func addMaxCropFlag(cmd *cobra.Command, maxCropPercent *int) {
+	cmd.Flags().IntVar(
+		maxCropPercent,
+		"max-crop",
+		maxCropPercentUnset,
+		"Maximum percentage of the image area that may be cropped to fit the monitor setup, from 0 to 99 (default: configured value, or no limit)",
+	)
+}
+
+// cropRange returns the range of aspect ratios of the images that fit a
+// Resolution, according to the --max-crop flag or the configured value, or
+// nil if neither is set.
+func cropRange(resolution *monitor.Resolution, flagValue int) (*monitor.AspectRatioRange, error) {
+	maxCropPercent := flagValue
+
+	if maxCropPercent == maxCropPercentUnset {
+		configured, ok := walricConfig.MaxCropPercent()
+		if !ok {
+			return nil, nil
+		}
+
+		maxCropPercent = configured
+	}
+
+	return resolution.CropRange(maxCropPercent)
+}
@@ -15,7 +15,9 @@ func NewCurrentCommand() *cobra.Command {
 		Use:   "current",
 		Short: "Display information about the currently selected entry",
 		Run: func(cmd *cobra.Command, args []string) {
-			entry, err := historyService.Current()
+			ctx := cmd.Context()
+
+			entry, err := historyService.Current(ctx)
 			if err != nil {
 				cobra.CheckErr(err)
 			}
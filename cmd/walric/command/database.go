@@ -0,0 +1,188 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/rs/zerolog/log"
+
+	"github.com/virtualtam/walric/cmd/walric/config"
+	"github.com/virtualtam/walric/internal/storage/memory"
+	"github.com/virtualtam/walric/internal/storage/postgres"
+	postgresmigrations "github.com/virtualtam/walric/internal/storage/postgres/migrations"
+	"github.com/virtualtam/walric/internal/storage/sqlite3"
+	sqlite3migrations "github.com/virtualtam/walric/internal/storage/sqlite3/migrations"
+	"github.com/virtualtam/walric/pkg/collection"
+	"github.com/virtualtam/walric/pkg/history"
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+// repository persists Collections, the History and Submissions.
+type repository interface {
+	collection.Repository
+	history.Repository
+	submission.Repository
+}
+
+// openRepository opens the configured database and returns the
+// corresponding repository.
+func openRepository(ctx context.Context, cfg *config.Config) (repository, error) {
+	driver, err := cfg.DatabaseDriver()
+	if err != nil {
+		return nil, err
+	}
+
+	switch driver {
+	case config.DatabaseDriverMemory:
+		if cfg.DatabaseDSN() == "" {
+			return memory.NewRepository(), nil
+		}
+
+		return memory.LoadJSONFile(cfg.DatabaseDSN())
+
+	case config.DatabaseDriverPostgres:
+		db, err := sqlx.Open("postgres", cfg.DatabaseDSN())
+		if err != nil {
+			return nil, err
+		}
+
+		return postgres.NewRepository(db), nil
+
+	default:
+		db, err := sqlx.Open("sqlite3", sqlite3.DataSourceName(cfg.DatabaseDSN()))
+		if err != nil {
+			return nil, err
+		}
+
+		return sqlite3.NewRepository(db), nil
+	}
+}
+
+// requireDatabaseFeatures returns an error if the configured database driver
+// lacks a feature required by the migrations and the repository, before the
+// database is backed up or migrated.
+//
+// The SQLite3 library must be compiled with the FTS5 extension, which is
+// checked on an in-memory database so that no database file is created.
+func requireDatabaseFeatures(ctx context.Context, cfg *config.Config) error {
+	driver, err := cfg.DatabaseDriver()
+	if err != nil {
+		return err
+	}
+
+	if driver != config.DatabaseDriverSQLite3 {
+		return nil
+	}
+
+	db, err := sqlx.Open("sqlite3", ":memory:")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return sqlite3.RequireFTS5(ctx, db)
+}
+
+// checkDatabaseSchema ensures the schema of the configured database matches the
+// embedded migrations, and applies pending migrations if auto_migrate is set.
+//
+// The in-memory database has no schema, and is not checked.
+func checkDatabaseSchema(ctx context.Context, cfg *config.Config) error {
+	driver, err := cfg.DatabaseDriver()
+	if err != nil {
+		return err
+	}
+
+	if driver == config.DatabaseDriverMemory {
+		return nil
+	}
+
+	if err := requireDatabaseFeatures(ctx, cfg); err != nil {
+		return err
+	}
+
+	// Do not create an SQLite3 database only to report it is not initialized
+	if driver == config.DatabaseDriverSQLite3 && !cfg.DatabaseAutoMigrate() && !fileExists(cfg.DatabaseDSN()) {
+		return errDatabaseNotInitialized(cfg)
+	}
+
+	m, err := newMigration(cfg)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	version, dirty, err := m.Version()
+	if err != nil {
+		return err
+	}
+
+	latestVersion := m.LatestVersion()
+
+	switch {
+	case dirty:
+		return fmt.Errorf("database schema version %d is dirty, as a migration failed: repair the database, then run 'walric migrate force VERSION'", version)
+	case version > latestVersion:
+		return fmt.Errorf("database schema version %d is newer than the latest supported version %d: upgrade walric", version, latestVersion)
+	case version == latestVersion:
+		return nil
+	case !cfg.DatabaseAutoMigrate() && version == 0:
+		return errDatabaseNotInitialized(cfg)
+	case !cfg.DatabaseAutoMigrate():
+		return fmt.Errorf("database schema version %d is outdated, the latest version is %d: run 'walric migrate', or set auto_migrate = true in the [database] configuration", version, latestVersion)
+	}
+
+	if version > 0 {
+		backupPath, err := backupDatabase(ctx, cfg)
+		if err != nil {
+			return err
+		}
+
+		if backupPath != "" {
+			log.Info().Str("path", backupPath).Msg("database backed up")
+		}
+	}
+
+	log.Info().Uint("from", version).Uint("to", latestVersion).Msg("migrating database schema")
+
+	// Keep the output of the command clean
+	m.migrater.Log = nil
+
+	if err := m.migrater.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to migrate database schema: %w", err)
+	}
+
+	return nil
+}
+
+// errDatabaseNotInitialized returns the error reported when no migration was
+// applied to the configured database.
+func errDatabaseNotInitialized(cfg *config.Config) error {
+	return fmt.Errorf("database %q is not initialized: run 'walric migrate', or set auto_migrate = true in the [database] configuration", cfg.DatabaseDSN())
+}
+
+// migrationsSource returns the migrations of the configured database, and the
+// URL of the database to migrate.
+func migrationsSource(cfg *config.Config) (fs.FS, string, error) {
+	driver, err := cfg.DatabaseDriver()
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch driver {
+	case config.DatabaseDriverMemory:
+		return nil, "", errors.New("the in-memory database has no migrations")
+
+	case config.DatabaseDriverPostgres:
+		return postgresmigrations.MigrationsFS, cfg.DatabaseDSN(), nil
+
+	default:
+		return sqlite3migrations.MigrationsFS, fmt.Sprintf("sqlite3://%s", cfg.DatabaseDSN()), nil
+	}
+}
@@ -0,0 +1,51 @@
+//go:build !sqlite_fts5
+
+package command
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/virtualtam/walric/internal/storage/sqlite3"
+)
+
+func TestCheckDatabaseSchemaFTS5Unavailable(t *testing.T) {
+	_, cfg := newTestConfig(t)
+
+	m, err := newMigration(cfg)
+	if err != nil {
+		t.Fatalf("failed to initialize migration: %q", err)
+	}
+	defer m.Close()
+
+	// The next migration creates the FTS5 table
+	if err := m.migrater.Migrate(9); err != nil {
+		t.Fatalf("failed to run migrations: %q", err)
+	}
+
+	err = checkDatabaseSchema(t.Context(), cfg)
+
+	if err == nil {
+		t.Error("expected an error but got none")
+	} else if !errors.Is(err, sqlite3.ErrFTS5Unavailable) {
+		t.Errorf("want error %q, got %q", sqlite3.ErrFTS5Unavailable, err)
+	}
+
+	version, dirty, err := m.Version()
+	if err != nil {
+		t.Fatalf("failed to retrieve schema version: %q", err)
+	}
+
+	if version != 9 || dirty {
+		t.Errorf("want schema version 9 (dirty: false), got %d (dirty: %t)", version, dirty)
+	}
+
+	backupPaths, err := filepath.Glob(cfg.DatabaseDSN() + ".*.bak")
+	if err != nil {
+		t.Fatalf("failed to list backup files: %q", err)
+	}
+	if len(backupPaths) > 0 {
+		t.Errorf("want no backup files, got %q", backupPaths)
+	}
+}
@@ -0,0 +1,30 @@
+package command
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/virtualtam/walric/cmd/walric/config"
+)
+
+// newTestConfig writes a configuration file using a SQLite3 database located
+// in a new data directory, and returns its path and the loaded Config.
+func newTestConfig(t *testing.T) (string, *config.Config) {
+	t.Helper()
+
+	dataDir := t.TempDir()
+	configPath := filepath.Join(t.TempDir(), "walric.toml")
+
+	configData := "[database]\nauto_migrate = true\n\n[walric]\ndata_dir = \"" + dataDir + "\"\n"
+	if err := os.WriteFile(configPath, []byte(configData), 0o644); err != nil {
+		t.Fatalf("failed to write configuration file: %q", err)
+	}
+
+	cfg, err := config.LoadTOML(configPath)
+	if err != nil {
+		t.Fatalf("failed to load configuration: %q", err)
+	}
+
+	return configPath, cfg
+}
@@ -0,0 +1,31 @@
+package command
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+// NewDislikeCommand initializes a CLI command to mark a submission as
+// disliked.
+func NewDislikeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dislike [POST_ID]",
+		Short: "Mark a submission, or the current entry if no post ID is given, as disliked",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			postID := ""
+			if len(args) == 1 {
+				postID = args[0]
+			}
+
+			if err := rateSubmission(ctx, postID, submission.RatingDisliked); err != nil {
+				cobra.CheckErr(err)
+			}
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,205 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/virtualtam/walric/pkg/integrity"
+)
+
+const (
+	fsckActionDelete     string = "delete"
+	fsckActionRedownload string = "redownload"
+	fsckActionReimport   string = "reimport"
+	fsckActionReport     string = "report"
+	fsckActionUpdate     string = "update"
+)
+
+var (
+	fsckMissingAction    string
+	fsckOrphanAction     string
+	fsckMismatchedAction string
+	fsckYes              bool
+)
+
+// NewFsckCommand initializes a CLI command to reconcile the database with the
+// image files in the data directory.
+func NewFsckCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fsck",
+		Short: "Reconcile the database with the image files in the data directory",
+		Long: `Reconcile the database with the image files in the data directory
+
+By default, inconsistencies are only reported. Without --yes, delete actions
+display what would be deleted, but nothing is deleted.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := requireFsckAction("missing", fsckMissingAction, fsckActionRedownload, fsckActionDelete); err != nil {
+				return err
+			}
+			if err := requireFsckAction("orphans", fsckOrphanAction, fsckActionReimport, fsckActionDelete); err != nil {
+				return err
+			}
+
+			return requireFsckAction("mismatched", fsckMismatchedAction, fsckActionUpdate, fsckActionDelete)
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			integrityService := integrity.NewService(log.Logger, submissionService, walricConfig.DataDir())
+
+			report, err := integrityService.Check(ctx)
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+
+			fmt.Fprintln(writer, "Missing image files")
+			for _, sub := range report.MissingFiles {
+				fmt.Fprintf(writer, "  %s\t%s\t%s\n", sub.Subreddit.Name, sub.PostID, sub.ImageFilename)
+			}
+			fmt.Fprintln(writer)
+
+			fmt.Fprintln(writer, "Orphan files")
+			for _, filePath := range report.OrphanFiles {
+				fmt.Fprintf(writer, "  %s\n", filePath)
+			}
+			fmt.Fprintln(writer)
+
+			fmt.Fprintln(writer, "Resolution mismatches")
+			for _, mismatch := range report.ResolutionMismatches {
+				fmt.Fprintf(
+					writer,
+					"  %s\t%s\t%d x %d\t%d x %d\t%s\n",
+					mismatch.Submission.Subreddit.Name,
+					mismatch.Submission.PostID,
+					mismatch.Submission.ImageWidthPx,
+					mismatch.Submission.ImageHeightPx,
+					mismatch.WidthPx,
+					mismatch.HeightPx,
+					mismatch.Submission.ImageFilename,
+				)
+			}
+
+			writer.Flush()
+
+			fmt.Println()
+			fmt.Println(len(report.MissingFiles), "missing image file(s) found")
+			fmt.Println(len(report.OrphanFiles), "orphan file(s) found")
+			fmt.Println(len(report.ResolutionMismatches), "resolution mismatch(es) found")
+
+			deleteActions := []string{fsckMissingAction, fsckOrphanAction, fsckMismatchedAction}
+
+			if slices.Contains(deleteActions, fsckActionDelete) && !fsckYes {
+				fmt.Println()
+				fmt.Println("Dry run: pass --yes to delete the reported submissions and files")
+			}
+
+			switch fsckMissingAction {
+			case fsckActionRedownload:
+				gatherService, err := newGatherService()
+				if err != nil {
+					cobra.CheckErr(err)
+				}
+
+				if err := gatherService.Redownload(ctx, report.MissingFiles); err != nil {
+					cobra.CheckErr(err)
+				}
+
+			case fsckActionDelete:
+				if !fsckYes {
+					break
+				}
+
+				for _, sub := range report.MissingFiles {
+					if err := integrityService.DeleteSubmission(ctx, sub); err != nil {
+						cobra.CheckErr(err)
+					}
+				}
+			}
+
+			switch fsckOrphanAction {
+			case fsckActionReimport:
+				gatherService, err := newGatherService()
+				if err != nil {
+					cobra.CheckErr(err)
+				}
+
+				if err := gatherService.Import(ctx, report.OrphanFiles); err != nil {
+					cobra.CheckErr(err)
+				}
+
+			case fsckActionDelete:
+				if !fsckYes {
+					break
+				}
+
+				for _, filePath := range report.OrphanFiles {
+					if err := integrityService.DeleteOrphanFile(filePath); err != nil {
+						cobra.CheckErr(err)
+					}
+				}
+			}
+
+			switch fsckMismatchedAction {
+			case fsckActionUpdate:
+				for _, mismatch := range report.ResolutionMismatches {
+					if err := integrityService.UpdateResolution(ctx, mismatch); err != nil {
+						cobra.CheckErr(err)
+					}
+				}
+
+			case fsckActionDelete:
+				if !fsckYes {
+					break
+				}
+
+				for _, mismatch := range report.ResolutionMismatches {
+					if err := integrityService.DeleteSubmission(ctx, mismatch.Submission); err != nil {
+						cobra.CheckErr(err)
+					}
+				}
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&fsckMissingAction,
+		"missing",
+		fsckActionReport,
+		"Action for submissions whose image file is missing (report, redownload, delete)",
+	)
+	cmd.Flags().StringVar(
+		&fsckOrphanAction,
+		"orphans",
+		fsckActionReport,
+		"Action for files that do not belong to any submission (report, reimport, delete)",
+	)
+	cmd.Flags().StringVar(
+		&fsckMismatchedAction,
+		"mismatched",
+		fsckActionReport,
+		"Action for submissions whose stored resolution does not match their image file (report, update, delete)",
+	)
+	cmd.Flags().BoolVar(
+		&fsckYes,
+		"yes",
+		false,
+		"Apply delete actions",
+	)
+
+	return cmd
+}
+
+func requireFsckAction(flagName string, action string, allowedActions ...string) error {
+	if action == fsckActionReport || slices.Contains(allowedActions, action) {
+		return nil
+	}
+
+	return fmt.Errorf("invalid action %q for --%s", action, flagName)
+}
@@ -0,0 +1,93 @@
+//go:build sqlite_fts5
+
+package command
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/virtualtam/walric/pkg/integrity"
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+// executeTestCommand runs the walric CLI with the given configuration file
+// and arguments.
+func executeTestCommand(t *testing.T, configPath string, args ...string) {
+	t.Helper()
+
+	rootCommand := NewRootCommand()
+	rootCommand.AddCommand(NewFsckCommand(), NewVerifyCommand())
+	rootCommand.SetArgs(append([]string{"--config", configPath}, args...))
+
+	if err := rootCommand.ExecuteContext(t.Context()); err != nil {
+		t.Fatalf("failed to run command %q: %q", args, err)
+	}
+}
+
+func TestFsckMissingDeleteQuarantined(t *testing.T) {
+	configPath, cfg := newTestConfig(t)
+
+	if err := checkDatabaseSchema(t.Context(), cfg); err != nil {
+		t.Fatalf("failed to initialize database: %q", err)
+	}
+
+	repository, err := openRepository(t.Context(), cfg)
+	if err != nil {
+		t.Fatalf("failed to open repository: %q", err)
+	}
+
+	var pngBuf bytes.Buffer
+	if err := png.Encode(&pngBuf, image.NewRGBA(image.Rect(0, 0, 64, 48))); err != nil {
+		t.Fatalf("failed to encode PNG image: %q", err)
+	}
+
+	imagePath := filepath.Join(cfg.DataDir(), "Dummy", "trunc-image.png")
+	if err := os.MkdirAll(filepath.Dir(imagePath), os.ModePerm); err != nil {
+		t.Fatalf("failed to create directory: %q", err)
+	}
+	if err := os.WriteFile(imagePath, pngBuf.Bytes()[:pngBuf.Len()/2], 0o644); err != nil {
+		t.Fatalf("failed to write image file: %q", err)
+	}
+
+	subreddit := &submission.Subreddit{Name: "Dummy"}
+	if err := repository.SubredditCreate(t.Context(), subreddit); err != nil {
+		t.Fatalf("failed to create subreddit: %q", err)
+	}
+
+	subreddit, err = repository.SubredditGetByName(t.Context(), "Dummy")
+	if err != nil {
+		t.Fatalf("failed to retrieve subreddit: %q", err)
+	}
+
+	if err := repository.SubmissionCreate(t.Context(), &submission.Submission{
+		PostID:        "trunc",
+		Subreddit:     subreddit,
+		Title:         "Truncated",
+		ImageFilename: imagePath,
+		ImageHeightPx: 48,
+		ImageWidthPx:  64,
+	}); err != nil {
+		t.Fatalf("failed to create submission: %q", err)
+	}
+
+	executeTestCommand(t, configPath, "verify", "--quarantine")
+	executeTestCommand(t, configPath, "fsck", "--missing", "delete", "--yes")
+
+	sub, err := repository.SubmissionGetByPostID(t.Context(), "trunc")
+	if err != nil {
+		t.Fatalf("want quarantined submission to be kept, got %q", err)
+	}
+
+	if !sub.ImageUnavailable {
+		t.Error("want quarantined submission image to be unavailable")
+	}
+
+	quarantinePath := filepath.Join(cfg.DataDir(), integrity.QuarantineDirName, "Dummy", "trunc-image.png")
+	if _, err := os.Stat(quarantinePath); err != nil {
+		t.Errorf("want quarantined image file %q to be kept, got %q", quarantinePath, err)
+	}
+}
@@ -7,6 +7,13 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/virtualtam/walric/cmd/walric/formatter"
+	"github.com/virtualtam/walric/pkg/history"
+)
+
+var (
+	historySort  string
+	historyLimit int
+	historyPage  int
 )
 
 // NewHistoryCommand initializes a CLI command to display the history of the
@@ -16,14 +23,30 @@ func NewHistoryCommand() *cobra.Command {
 		Use:   "history",
 		Short: "Display the history of selected entries",
 		Run: func(cmd *cobra.Command, args []string) {
-			history, err := historyService.All()
+			ctx := cmd.Context()
+
+			key, direction, err := history.ParseSort(historySort)
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			page, err := listPage(historyLimit, historyPage)
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			entries, err := historyService.All(ctx, &history.ListOptions{
+				Sort:      key,
+				Direction: direction,
+				Page:      page,
+			})
 			if err != nil {
 				cobra.CheckErr(err)
 			}
 
 			writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
 
-			for _, entry := range history {
+			for _, entry := range entries {
 				fmt.Fprintf(
 					writer,
 					"%s\t%s\t%d x %d\t%s\n",
@@ -39,5 +62,7 @@ func NewHistoryCommand() *cobra.Command {
 		},
 	}
 
+	addListFlags(cmd, &historySort, &historyLimit, &historyPage, string(history.SortDate))
+
 	return cmd
 }
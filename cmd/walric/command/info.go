@@ -15,7 +15,9 @@ func NewInfoCommand() *cobra.Command {
 		Short: "Display information about a given submission",
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			submission, err := submissionService.ByPostID(args[0])
+			ctx := cmd.Context()
+
+			submission, err := submissionService.ByPostID(ctx, args[0])
 			if err != nil {
 				cobra.CheckErr(err)
 			}
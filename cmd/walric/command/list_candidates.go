@@ -7,6 +7,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/virtualtam/walric/pkg/monitor"
+	"github.com/virtualtam/walric/pkg/submission"
 )
 
 const (
@@ -14,16 +15,34 @@ const (
 )
 
 var (
-	xRandRScreenNo int
+	xRandRScreenNo            int
+	listCandidatesTags        []string
+	listCandidatesExcludeTags []string
+	listCandidatesSort        string
+	listCandidatesLimit       int
+	listCandidatesPage        int
+	listCandidatesNSFW        string
+	listCandidatesMaxCrop     int
 )
 
 // NewListCandidates initializes a CLI command to list Submissions suitable for
 // the current monitor configuration.
 func NewListCandidatesCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "list-candidates",
+		Use:   "list-candidates [QUERY]",
 		Short: "List submissions suitable for the current monitor setup",
+		Long: `List submissions suitable for the current monitor setup
+
+` + queryHelp,
+		Args: cobra.ArbitraryArgs,
 		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			query, err := parseQueryArgs(args)
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
 			monitors, err := monitor.ConnectedMonitors(xRandRScreenNo)
 			if err != nil {
 				cobra.CheckErr(err)
@@ -31,22 +50,43 @@ func NewListCandidatesCommand() *cobra.Command {
 
 			wallpaperResolution := monitor.WallpaperResolution(monitors)
 
-			submissions, err := submissionService.ByMinResolution(wallpaperResolution)
+			filter := &submission.Filter{
+				Tags:        listCandidatesTags,
+				ExcludeTags: listCandidatesExcludeTags,
+				Query:       query,
+			}
+
+			filter.NSFW, err = nsfwPolicy(listCandidatesNSFW)
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			filter.AspectRatio, err = cropRange(wallpaperResolution, listCandidatesMaxCrop)
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			options, err := submissionListOptions(listCandidatesSort, listCandidatesLimit, listCandidatesPage)
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			submissions, err := submissionService.ByMinResolution(ctx, wallpaperResolution, filter, options)
 			if err != nil {
 				cobra.CheckErr(err)
 			}
 
 			writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
 
-			for _, submission := range submissions {
+			for _, sub := range submissions {
 				fmt.Fprintf(
 					writer,
 					"%s\t%s\t%d x %d\t%s\n",
-					submission.Subreddit.Name,
-					submission.PostID,
-					submission.ImageWidthPx,
-					submission.ImageHeightPx,
-					submission.Title,
+					sub.Subreddit.Name,
+					sub.PostID,
+					sub.ImageWidthPx,
+					sub.ImageHeightPx,
+					sub.Title,
 				)
 			}
 
@@ -61,5 +101,22 @@ func NewListCandidatesCommand() *cobra.Command {
 		"XRandR screen",
 	)
 
+	cmd.Flags().StringSliceVar(
+		&listCandidatesTags,
+		"tag",
+		[]string{},
+		"Only list submissions with all the given tag(s)",
+	)
+	cmd.Flags().StringSliceVar(
+		&listCandidatesExcludeTags,
+		"exclude-tag",
+		[]string{},
+		"Exclude submissions with any of the given tag(s)",
+	)
+
+	addNSFWFlag(cmd, &listCandidatesNSFW)
+	addMaxCropFlag(cmd, &listCandidatesMaxCrop)
+	addListFlags(cmd, &listCandidatesSort, &listCandidatesLimit, &listCandidatesPage, submissionSortKeys())
+
 	return cmd
 }
@@ -0,0 +1,86 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+// addListFlags registers the flags controlling the order and range of a
+// listing.
+func addListFlags(cmd *cobra.Command, sort *string, limit *int, page *int, sortKeys string) {
+	cmd.Flags().StringVar(
+		sort,
+		"sort",
+		"",
+		fmt.Sprintf("Sort by KEY[:asc|desc], where KEY is one of: %s", sortKeys),
+	)
+	cmd.Flags().IntVar(
+		limit,
+		"limit",
+		0,
+		"Maximum number of results to display (0: no limit)",
+	)
+	cmd.Flags().IntVar(
+		page,
+		"page",
+		1,
+		"Page of results to display, starting at 1 (requires --limit)",
+	)
+}
+
+// listPage returns the Page selected by the --limit and --page flags.
+func listPage(limit int, page int) (submission.Page, error) {
+	if limit < 0 {
+		return submission.Page{}, fmt.Errorf("invalid limit %d for --limit", limit)
+	}
+
+	if page < 1 {
+		return submission.Page{}, fmt.Errorf("invalid page %d for --page", page)
+	}
+
+	if page > 1 && limit == 0 {
+		return submission.Page{}, errors.New("--page requires --limit")
+	}
+
+	return submission.Page{
+		Limit:  limit,
+		Offset: (page - 1) * limit,
+	}, nil
+}
+
+// submissionListOptions returns the ListOptions selected by the --sort,
+// --limit and --page flags.
+func submissionListOptions(sort string, limit int, page int) (*submission.ListOptions, error) {
+	key, direction, err := submission.ParseSort(sort)
+	if err != nil {
+		return &submission.ListOptions{}, err
+	}
+
+	listPage, err := listPage(limit, page)
+	if err != nil {
+		return &submission.ListOptions{}, err
+	}
+
+	return &submission.ListOptions{
+		Sort:      key,
+		Direction: direction,
+		Page:      listPage,
+	}, nil
+}
+
+// submissionSortKeys returns the list of Submission sort keys, for usage
+// messages.
+func submissionSortKeys() string {
+	keys := make([]string, len(submission.SortKeys))
+
+	for index, key := range submission.SortKeys {
+		keys[index] = string(key)
+	}
+
+	return strings.Join(keys, ", ")
+}
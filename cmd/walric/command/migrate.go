@@ -1,17 +1,37 @@
 package command
 
 import (
+	"bufio"
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/database/sqlite3"
+	"github.com/golang-migrate/migrate/v4/source"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/jmoiron/sqlx"
 	"github.com/spf13/cobra"
 
-	"github.com/virtualtam/walric/internal/storage/sqlite3/migrations"
+	"github.com/virtualtam/walric/cmd/walric/config"
+	"github.com/virtualtam/walric/internal/storage/sqlite3"
+)
+
+const (
+	migrateBackupTimeLayout string = "20060102T150405"
+)
+
+var (
+	migrateYes bool
 )
 
 var _ migrate.Logger = &migrateLogger{}
@@ -28,43 +48,432 @@ func (l migrateLogger) Verbose() bool {
 	return l.verbose
 }
 
+// migration runs the embedded migrations of the configured database.
+type migration struct {
+	migrater *migrate.Migrate
+
+	// versions lists the embedded migrations, in ascending order.
+	versions []uint
+}
+
+// newMigration initializes a migration for the configured database.
+func newMigration(cfg *config.Config) (*migration, error) {
+	driver, err := cfg.DatabaseDriver()
+	if err != nil {
+		return nil, err
+	}
+
+	if driver == config.DatabaseDriverSQLite3 {
+		if err := os.MkdirAll(filepath.Dir(cfg.DatabaseDSN()), os.ModePerm); err != nil {
+			return nil, err
+		}
+	}
+
+	migrationsFS, databaseURL, err := migrationsSource(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceDriver, err := iofs.New(migrationsFS, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := sourceVersions(sourceDriver)
+	if err != nil {
+		return nil, err
+	}
+
+	migrater, err := migrate.NewWithSourceInstance("iofs", sourceDriver, databaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	migrater.Log = migrateLogger{
+		verbose: debugMode,
+	}
+
+	return &migration{
+		migrater: migrater,
+		versions: versions,
+	}, nil
+}
+
+// sourceVersions returns the versions of the migrations provided by a source,
+// in ascending order.
+func sourceVersions(sourceDriver source.Driver) ([]uint, error) {
+	versions := []uint{}
+
+	version, err := sourceDriver.First()
+	for err == nil {
+		versions = append(versions, version)
+		version, err = sourceDriver.Next(version)
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return []uint{}, err
+	}
+
+	return versions, nil
+}
+
+// Close releases the migration's database connection.
+func (m *migration) Close() {
+	_, _ = m.migrater.Close()
+}
+
+// Version returns the current schema version, which is 0 if no migration was
+// applied, and whether the last migration failed.
+func (m *migration) Version() (uint, bool, error) {
+	version, dirty, err := m.migrater.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+
+	return version, dirty, err
+}
+
+// LatestVersion returns the version of the last embedded migration.
+func (m *migration) LatestVersion() uint {
+	if len(m.versions) == 0 {
+		return 0
+	}
+
+	return m.versions[len(m.versions)-1]
+}
+
 // NewMigrateCommand initializes a CLI command to create database tables and run
 // SQL migrations.
 func NewMigrateCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "migrate",
 		Short: "Initialize database and run migrations",
+		Annotations: map[string]string{
+			annotationNoRepository: "true",
+		},
+		Long: `Initialize database and run migrations
+
+Without a subcommand, all pending migrations are applied.
+
+SQLite3 databases are backed up next to the database file before any change.
+Without --yes, confirmation is asked before a step that may lose data.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runMigrationStep(cmd, neverDestructive, func(m *migration) error {
+				return m.migrater.Up()
+			})
+		},
+	}
+
+	cmd.PersistentFlags().BoolVar(
+		&migrateYes,
+		"yes",
+		false,
+		"Do not ask for confirmation",
+	)
+
+	cmd.AddCommand(
+		newMigrateDownCommand(),
+		newMigrateForceCommand(),
+		newMigrateGotoCommand(),
+		newMigrateStatusCommand(),
+		newMigrateUpCommand(),
+	)
+
+	return cmd
+}
+
+func newMigrateDownCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "down [N]",
+		Short: "Roll back the last N migrations, or all migrations if N is not set",
+		Args:  cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			if err := os.MkdirAll(walricConfig.DataDir(), os.ModePerm); err != nil {
+			if len(args) == 0 {
+				runMigrationStep(cmd, alwaysDestructive, func(m *migration) error {
+					return m.migrater.Down()
+				})
+				return
+			}
+
+			steps, err := parseMigrationSteps(args[0])
+			if err != nil {
 				cobra.CheckErr(err)
 			}
 
-			migrationsSource, err := iofs.New(migrations.MigrationsFS, ".")
+			runMigrationStep(cmd, alwaysDestructive, func(m *migration) error {
+				return m.migrater.Steps(-steps)
+			})
+		},
+	}
+
+	return cmd
+}
+
+func newMigrateForceCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "force VERSION",
+		Short: "Set the schema version without running migrations, e.g. to clear a failed migration",
+		Long: `Set the schema version without running migrations, e.g. to clear a failed migration
+
+The database must first be repaired manually to match the given version.
+A version of -1 marks the database as having no migration applied.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			version, err := strconv.Atoi(args[0])
+			if err != nil || version < database.NilVersion {
+				cobra.CheckErr(fmt.Errorf("invalid version %q", args[0]))
+			}
+
+			runMigrationStep(cmd, alwaysDestructive, func(m *migration) error {
+				return m.migrater.Force(version)
+			})
+		},
+	}
+
+	return cmd
+}
+
+func newMigrateGotoCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "goto VERSION",
+		Short: "Apply or roll back migrations to reach a given version",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			version, err := strconv.ParseUint(args[0], 10, 0)
+			if err != nil {
+				cobra.CheckErr(fmt.Errorf("invalid version %q", args[0]))
+			}
+
+			rollsBack := func(current uint) bool { return uint(version) < current }
+
+			runMigrationStep(cmd, rollsBack, func(m *migration) error {
+				return m.migrater.Migrate(uint(version))
+			})
+		},
+	}
+
+	return cmd
+}
+
+func newMigrateStatusCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Display the schema version and pending migrations",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			m, err := newMigration(walricConfig)
 			if err != nil {
 				cobra.CheckErr(err)
 			}
+			defer m.Close()
 
-			migrater, err := migrate.NewWithSourceInstance(
-				"iofs",
-				migrationsSource,
-				fmt.Sprintf("sqlite3://%s", walricConfig.DatabasePath()),
-			)
+			version, dirty, err := m.Version()
 			if err != nil {
 				cobra.CheckErr(err)
 			}
 
-			migrater.Log = migrateLogger{
-				verbose: debugMode,
+			pending := []string{}
+			for _, v := range m.versions {
+				if v > version {
+					pending = append(pending, strconv.FormatUint(uint64(v), 10))
+				}
+			}
+
+			if len(pending) == 0 {
+				pending = append(pending, "none")
+			}
+
+			writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+
+			fmt.Fprintf(writer, "Current version\t%d\t\n", version)
+			fmt.Fprintf(writer, "Latest version\t%d\t\n", m.LatestVersion())
+			fmt.Fprintf(writer, "Dirty\t%t\t\n", dirty)
+			fmt.Fprintf(writer, "Pending\t%s\t\n", strings.Join(pending, ", "))
+
+			writer.Flush()
+
+			if dirty {
+				fmt.Println()
+				fmt.Printf("Migration %d failed: repair the database, then run 'walric migrate force VERSION'\n", version)
 			}
+		},
+	}
 
-			err = migrater.Up()
-			if errors.Is(err, migrate.ErrNoChange) {
-				fmt.Println("Database schema already up to date")
-			} else if err != nil {
+	return cmd
+}
+
+func newMigrateUpCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "up [N]",
+		Short: "Apply the next N migrations, or all pending migrations if N is not set",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 {
+				runMigrationStep(cmd, neverDestructive, func(m *migration) error {
+					return m.migrater.Up()
+				})
+				return
+			}
+
+			steps, err := parseMigrationSteps(args[0])
+			if err != nil {
 				cobra.CheckErr(err)
 			}
+
+			runMigrationStep(cmd, neverDestructive, func(m *migration) error {
+				return m.migrater.Steps(steps)
+			})
 		},
 	}
 
 	return cmd
 }
+
+// parseMigrationSteps parses a positive number of migrations to apply or roll
+// back.
+func parseMigrationSteps(arg string) (int, error) {
+	steps, err := strconv.Atoi(arg)
+	if err != nil || steps < 1 {
+		return 0, fmt.Errorf("invalid number of migrations %q", arg)
+	}
+
+	return steps, nil
+}
+
+// alwaysDestructive is used for migration steps that may always lose data.
+func alwaysDestructive(uint) bool { return true }
+
+// neverDestructive is used for migration steps that only apply migrations.
+func neverDestructive(uint) bool { return false }
+
+// runMigrationStep backs up the database and runs a migration step, after
+// asking for confirmation if the step is destructive for the current schema
+// version.
+func runMigrationStep(cmd *cobra.Command, destructive func(current uint) bool, step func(m *migration) error) {
+	if err := requireDatabaseFeatures(cmd.Context(), walricConfig); err != nil {
+		cobra.CheckErr(err)
+	}
+
+	m, err := newMigration(walricConfig)
+	if err != nil {
+		cobra.CheckErr(err)
+	}
+	defer m.Close()
+
+	current, _, err := m.Version()
+	if err != nil {
+		cobra.CheckErr(err)
+	}
+
+	isDestructive := destructive(current)
+
+	if isDestructive && !migrateYes {
+		confirmed, err := confirm(cmd.InOrStdin(), cmd.OutOrStdout(), "This may lose data. Continue?")
+		if err != nil {
+			cobra.CheckErr(err)
+		}
+
+		if !confirmed {
+			fmt.Println("Aborted")
+			return
+		}
+	}
+
+	// Nothing to back up until a migration has been applied, nor when the
+	// schema is up to date and no migration is to be rolled back
+	if current > 0 && (isDestructive || current < m.LatestVersion()) {
+		backupPath, err := backupDatabase(cmd.Context(), walricConfig)
+		if err != nil {
+			cobra.CheckErr(err)
+		}
+
+		if backupPath == "" {
+			fmt.Println("Skipping backup: only SQLite3 databases are backed up, use pg_dump for PostgreSQL")
+		} else {
+			fmt.Println("Database backed up to", backupPath)
+		}
+	}
+
+	err = step(m)
+
+	var shortLimitErr migrate.ErrShortLimit
+
+	switch {
+	case errors.Is(err, migrate.ErrNoChange):
+		fmt.Println("Database schema already up to date")
+	case errors.As(err, &shortLimitErr):
+		fmt.Printf("Reached the end of the migrations, %d step(s) short\n", shortLimitErr.Short)
+	case err != nil:
+		cobra.CheckErr(err)
+	}
+
+	version, dirty, err := m.Version()
+	if err != nil {
+		cobra.CheckErr(err)
+	}
+
+	fmt.Printf("Database schema version: %d (dirty: %t)\n", version, dirty)
+}
+
+// backupDatabase writes a copy of the SQLite3 database to the same directory,
+// suffixed with the current date and time, and returns the path to the copy.
+//
+// PostgreSQL databases are not backed up, as they may be large and are
+// usually backed up by other means; the returned path is then empty.
+func backupDatabase(ctx context.Context, cfg *config.Config) (string, error) {
+	driver, err := cfg.DatabaseDriver()
+	if err != nil {
+		return "", err
+	}
+
+	if driver != config.DatabaseDriverSQLite3 {
+		return "", nil
+	}
+
+	databasePath := cfg.DatabaseDSN()
+
+	db, err := sqlx.Open("sqlite3", sqlite3.DataSourceName(databasePath))
+	if err != nil {
+		return "", err
+	}
+	defer db.Close()
+
+	backupPrefix := fmt.Sprintf("%s.%s", databasePath, time.Now().UTC().Format(migrateBackupTimeLayout))
+	backupPath := backupPrefix + ".bak"
+
+	// Keep backups made within the same second
+	for n := 1; fileExists(backupPath); n++ {
+		backupPath = fmt.Sprintf("%s-%d.bak", backupPrefix, n)
+	}
+
+	if err := sqlite3.Backup(ctx, db, backupPath); err != nil {
+		return "", fmt.Errorf("failed to back up database to %q: %w", backupPath, err)
+	}
+
+	return backupPath, nil
+}
+
+// fileExists returns whether a file exists at a given path.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+
+	return err == nil
+}
+
+// confirm asks a yes/no question, and returns whether the answer is yes.
+func confirm(reader io.Reader, writer io.Writer, question string) (bool, error) {
+	fmt.Fprintf(writer, "%s [y/N] ", question)
+
+	answer, err := bufio.NewReader(reader).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
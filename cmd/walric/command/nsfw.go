@@ -0,0 +1,27 @@
+package command
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+// addNSFWFlag registers the flag setting the NSFW policy of a command.
+func addNSFWFlag(cmd *cobra.Command, policy *string) {
+	cmd.Flags().StringVar(
+		policy,
+		"nsfw",
+		"",
+		"Policy for submissions flagged as NSFW: exclude, include or only (default: configured policy, or exclude)",
+	)
+}
+
+// nsfwPolicy returns the NSFW policy set by the --nsfw flag, or the configured
+// policy if the flag is unset.
+func nsfwPolicy(flagValue string) (submission.NSFWPolicy, error) {
+	if flagValue == "" {
+		return walricConfig.NSFWPolicy()
+	}
+
+	return submission.ParseNSFWPolicy(flagValue)
+}
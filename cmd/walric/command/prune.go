@@ -0,0 +1,176 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/virtualtam/walric/cmd/walric/formatter"
+	"github.com/virtualtam/walric/pkg/monitor"
+	"github.com/virtualtam/walric/pkg/prune"
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+const (
+	pruneDateLayout string = "2006-01-02"
+)
+
+var (
+	pruneOlderThan       string
+	pruneBelowResolution string
+	pruneSubreddits      []string
+	pruneNSFW            bool
+	pruneNeverShown      bool
+	pruneDisliked        bool
+	pruneYes             bool
+)
+
+// NewPruneCommand initializes a CLI command to delete submissions matching
+// selection criteria.
+func NewPruneCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prune [QUERY]",
+		Short: "Delete submissions matching selection criteria",
+		Long: `Delete submissions matching selection criteria
+
+Criteria are combined: only submissions matching all of them are selected.
+Without --yes, the selection is displayed but nothing is deleted.
+
+` + queryHelp,
+		Args: cobra.ArbitraryArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			query, err := parseQueryArgs(args)
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			filter := &submission.Filter{
+				SubredditNames: pruneSubreddits,
+				NeverShown:     pruneNeverShown,
+				DislikedOnly:   pruneDisliked,
+				Query:          query,
+			}
+
+			if pruneNSFW {
+				filter.NSFW = submission.NSFWOnly
+			}
+
+			if pruneOlderThan != "" {
+				postedBefore, err := time.Parse(pruneDateLayout, pruneOlderThan)
+				if err != nil {
+					cobra.CheckErr(fmt.Errorf("invalid date %q for --older-than: %w", pruneOlderThan, err))
+				}
+
+				filter.PostedBefore = postedBefore
+			}
+
+			if pruneBelowResolution != "" {
+				belowResolution, err := monitor.ParseResolution(pruneBelowResolution)
+				if err != nil {
+					cobra.CheckErr(fmt.Errorf("invalid resolution %q for --below-resolution: %w", pruneBelowResolution, err))
+				}
+
+				filter.BelowResolution = belowResolution
+			}
+
+			pruneService := prune.NewService(log.Logger, submissionService, walricConfig.DataDir())
+
+			plan, err := pruneService.Plan(ctx, filter)
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			subredditCounts := map[string]int{}
+			subredditNames := []string{}
+
+			for _, sub := range plan.Submissions {
+				if _, ok := subredditCounts[sub.Subreddit.Name]; !ok {
+					subredditNames = append(subredditNames, sub.Subreddit.Name)
+				}
+
+				subredditCounts[sub.Subreddit.Name]++
+			}
+
+			writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+
+			fmt.Fprintln(writer, "Count\tSubreddit\t")
+			fmt.Fprintln(writer, "-----\t---------\t")
+			fmt.Fprintln(writer, "\t\t")
+
+			for _, name := range subredditNames {
+				fmt.Fprintf(writer, "%d\t%s\t\n", subredditCounts[name], name)
+			}
+
+			fmt.Fprintln(writer, "\t\t")
+			fmt.Fprintf(writer, "%d\t%s\t\n", len(plan.Submissions), "TOTAL")
+
+			writer.Flush()
+
+			fmt.Println()
+			fmt.Println(formatter.FormatBytes(plan.SizeBytes), "used by image files")
+
+			if !pruneYes {
+				fmt.Println()
+				fmt.Println("Dry run: pass --yes to delete these submissions")
+				return
+			}
+
+			if err := pruneService.Apply(ctx, plan); err != nil {
+				cobra.CheckErr(err)
+			}
+
+			fmt.Println(len(plan.Submissions), "submission(s) deleted")
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&pruneOlderThan,
+		"older-than",
+		"",
+		"Select submissions posted before this date (YYYY-MM-DD)",
+	)
+	cmd.Flags().StringVar(
+		&pruneBelowResolution,
+		"below-resolution",
+		"",
+		"Select submissions smaller than this resolution (WIDTHxHEIGHT)",
+	)
+	cmd.Flags().StringSliceVar(
+		&pruneSubreddits,
+		"subreddit",
+		[]string{},
+		"Select submissions from the given subreddit(s)",
+	)
+	cmd.Flags().BoolVar(
+		&pruneNSFW,
+		"nsfw",
+		false,
+		"Select submissions flagged as NSFW",
+	)
+	cmd.Flags().BoolVar(
+		&pruneNeverShown,
+		"never-shown",
+		false,
+		"Select submissions that were never selected as wallpaper",
+	)
+	cmd.Flags().BoolVar(
+		&pruneDisliked,
+		"disliked",
+		false,
+		"Select submissions that were shown and marked as disliked",
+	)
+	cmd.Flags().BoolVar(
+		&pruneYes,
+		"yes",
+		false,
+		"Delete the selected submissions",
+	)
+
+	return cmd
+}
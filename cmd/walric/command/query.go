@@ -0,0 +1,27 @@
+package command
+
+import (
+	"strings"
+
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+const queryHelp = `A query is a list of conditions that must all match:
+
+  subreddit:NAME, author:NAME, tag:NAME
+  score, width, height, rating, with one of : = != < <= > >=, e.g. score>=5000
+  nsfw, removed, unavailable, shown, with a boolean, e.g. nsfw:false
+  posted:DATE, where DATE is YYYY, YYYY-MM or YYYY-MM-DD, and posted:FROM..TO
+  for ranges, where either bound may be omitted, e.g. posted:2023..
+  any other word, "double-quoted phrase", or prefix* to match the title,
+  author, subreddit or tags
+
+A leading - negates a condition; use -- before a query starting with -, e.g.:
+
+  walric random -- -tag:winter 'subreddit:EarthPorn "mountain lake"'`
+
+// parseQueryArgs joins and parses command-line arguments as a
+// submission.Query.
+func parseQueryArgs(args []string) (*submission.Query, error) {
+	return submission.ParseQuery(strings.Join(args, " "))
+}
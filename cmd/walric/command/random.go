@@ -1,21 +1,81 @@
 package command
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/virtualtam/walric/cmd/walric/formatter"
 	"github.com/virtualtam/walric/pkg/history"
 	"github.com/virtualtam/walric/pkg/monitor"
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+var (
+	randomFavorites       bool
+	randomExcludeDisliked bool
+	randomTags            []string
+	randomExcludeTags     []string
+	randomCollection      string
+	randomInOrder         bool
+	randomNSFW            string
+	randomMaxCrop         int
+	randomStrategy        string
+	randomRepeat          string
 )
 
 // NewRandomCommand initializes a CLI command to select a random submission
 // suitable for the current monitor setup.
 func NewRandomCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "random",
+		Use:   "random [QUERY]",
 		Short: "Select a random submission suitable for the current monitor setup",
+		Long: `Select a random submission suitable for the current monitor setup
+
+With --collection, the submission is selected from a collection, regardless of
+the monitor setup and history.
+
+` + queryHelp,
+		Args: cobra.ArbitraryArgs,
 		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			query, err := parseQueryArgs(args)
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			if randomCollection != "" {
+				if !query.IsEmpty() {
+					cobra.CheckErr(errors.New("a query cannot be used with --collection"))
+				}
+
+				c, err := collectionService.ByName(ctx, randomCollection)
+				if err != nil {
+					cobra.CheckErr(err)
+				}
+
+				var sub *submission.Submission
+
+				if randomInOrder {
+					sub, err = collectionService.Next(ctx, c)
+				} else {
+					sub, err = collectionService.Random(ctx, c)
+				}
+				if err != nil {
+					cobra.CheckErr(err)
+				}
+
+				if err := saveSelection(ctx, sub); err != nil {
+					cobra.CheckErr(err)
+				}
+
+				return
+			}
+
 			monitors, err := monitor.ConnectedMonitors(xRandRScreenNo)
 			if err != nil {
 				cobra.CheckErr(err)
@@ -23,24 +83,144 @@ func NewRandomCommand() *cobra.Command {
 
 			wallpaperResolution := monitor.WallpaperResolution(monitors)
 
-			submission, err := submissionService.Random(wallpaperResolution)
+			filter := &submission.Filter{
+				ExcludeDisliked: randomExcludeDisliked,
+				Tags:            randomTags,
+				ExcludeTags:     randomExcludeTags,
+				Query:           query,
+			}
+
+			filter.NSFW, err = nsfwPolicy(randomNSFW)
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			filter.AspectRatio, err = cropRange(wallpaperResolution, randomMaxCrop)
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			if randomFavorites {
+				filter.MinRating = submission.RatingFavorite
+			}
+
+			strategyName := randomStrategy
+			if strategyName == "" {
+				strategyName = walricConfig.SelectionStrategy()
+			}
+
+			strategy, err := submission.NewSelectionStrategy(strategyName)
 			if err != nil {
 				cobra.CheckErr(err)
 			}
 
-			entry, err := history.NewEntry(submission)
+			repeat, err := walricConfig.RepeatPolicy()
 			if err != nil {
 				cobra.CheckErr(err)
 			}
 
-			if err := historyService.Save(entry); err != nil {
+			if randomRepeat != "" {
+				repeat, err = submission.ParseRepeatPolicy(randomRepeat)
+				if err != nil {
+					cobra.CheckErr(err)
+				}
+			}
+
+			sub, err := submissionService.Random(ctx, wallpaperResolution, filter, strategy, repeat)
+			if err != nil {
 				cobra.CheckErr(err)
 			}
 
-			writer := formatter.FormatSubmissionAsTab(os.Stdout, submission)
-			writer.Flush()
+			if err := saveSelection(ctx, sub); err != nil {
+				cobra.CheckErr(err)
+			}
 		},
 	}
 
+	cmd.Flags().BoolVar(
+		&randomFavorites,
+		"favorites",
+		false,
+		"Only select submissions marked as favorite",
+	)
+	cmd.Flags().BoolVar(
+		&randomExcludeDisliked,
+		"exclude-disliked",
+		false,
+		"Do not select submissions marked as disliked",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&randomTags,
+		"tag",
+		[]string{},
+		"Only select submissions with all the given tag(s)",
+	)
+	cmd.Flags().StringSliceVar(
+		&randomExcludeTags,
+		"exclude-tag",
+		[]string{},
+		"Exclude submissions with any of the given tag(s)",
+	)
+	addNSFWFlag(cmd, &randomNSFW)
+	addMaxCropFlag(cmd, &randomMaxCrop)
+	cmd.Flags().StringVar(
+		&randomStrategy,
+		"strategy",
+		"",
+		fmt.Sprintf(
+			"Random selection strategy, one of: %s (default: configured strategy, or %s)",
+			strings.Join(submission.SelectionStrategies, ", "),
+			submission.DefaultSelectionStrategy,
+		),
+	)
+	cmd.Flags().StringVar(
+		&randomRepeat,
+		"repeat",
+		"",
+		fmt.Sprintf(
+			"When previously selected submissions may be selected again: never, cycle, days:N or selections:N (default: configured policy, or %s)",
+			submission.DefaultRepeatPolicy,
+		),
+	)
+
+	cmd.Flags().StringVar(
+		&randomCollection,
+		"collection",
+		"",
+		"Select a submission from this collection",
+	)
+	cmd.Flags().BoolVar(
+		&randomInOrder,
+		"in-order",
+		false,
+		"Select the next submission from the collection, instead of a random one",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive("collection", "favorites")
+	cmd.MarkFlagsMutuallyExclusive("collection", "exclude-disliked")
+	cmd.MarkFlagsMutuallyExclusive("collection", "tag")
+	cmd.MarkFlagsMutuallyExclusive("collection", "exclude-tag")
+	cmd.MarkFlagsMutuallyExclusive("collection", "nsfw")
+	cmd.MarkFlagsMutuallyExclusive("collection", "max-crop")
+	cmd.MarkFlagsMutuallyExclusive("collection", "strategy")
+	cmd.MarkFlagsMutuallyExclusive("collection", "repeat")
+
 	return cmd
 }
+
+// saveSelection adds a Submission to the history, and displays its metadata.
+func saveSelection(ctx context.Context, sub *submission.Submission) error {
+	entry, err := history.NewEntry(sub)
+	if err != nil {
+		return err
+	}
+
+	if err := historyService.Save(ctx, entry); err != nil {
+		return err
+	}
+
+	writer := formatter.FormatSubmissionAsTab(os.Stdout, sub)
+
+	return writer.Flush()
+}
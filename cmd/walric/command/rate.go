@@ -0,0 +1,71 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/virtualtam/walric/cmd/walric/formatter"
+)
+
+// NewRateCommand initializes a CLI command to rate a submission.
+func NewRateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rate [POST_ID] RATING",
+		Short: "Rate a submission, or the current entry if no post ID is given",
+		Long: `Rate a submission, or the current entry if no post ID is given
+
+Ratings range from -1 (disliked) to 5 (favorite), and 0 clears the rating.
+Negative ratings must follow a double dash, e.g. "walric rate -- -1".`,
+		Args: cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			postID := ""
+			if len(args) == 2 {
+				postID = args[0]
+			}
+
+			ratingArg := args[len(args)-1]
+
+			rating, err := strconv.Atoi(ratingArg)
+			if err != nil {
+				cobra.CheckErr(fmt.Errorf("invalid rating %q: %w", ratingArg, err))
+			}
+
+			if err := rateSubmission(ctx, postID, rating); err != nil {
+				cobra.CheckErr(err)
+			}
+		},
+	}
+
+	return cmd
+}
+
+// rateSubmission sets the rating for the submission with the given post ID, or
+// for the current history entry if the post ID is empty.
+func rateSubmission(ctx context.Context, postID string, rating int) error {
+	if postID == "" {
+		entry, err := historyService.Current(ctx)
+		if err != nil {
+			return err
+		}
+
+		postID = entry.Submission.PostID
+	}
+
+	sub, err := submissionService.ByPostID(ctx, postID)
+	if err != nil {
+		return err
+	}
+
+	if err := submissionService.Rate(ctx, sub.ID, rating); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s\t%s\t%s\n", sub.PostID, formatter.FormatRating(rating), sub.Title)
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package command
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/sethjones/go-reddit/v2/reddit"
+
+	"github.com/virtualtam/walric/pkg/gather"
+)
+
+// newGatherService initializes a Reddit API client and the corresponding
+// gather.Service from the application's configuration.
+func newGatherService() (*gather.Service, error) {
+	redditClient, err := reddit.NewClient(
+		reddit.Credentials{
+			ID:     walricConfig.Reddit.ClientID,
+			Secret: walricConfig.Reddit.ClientSecret,
+		},
+		reddit.WithApplicationOnlyOAuth(true),
+		reddit.WithUserAgent(walricConfig.Reddit.UserAgent),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	listPostOptions := &reddit.ListPostOptions{
+		ListOptions: reddit.ListOptions{Limit: walricConfig.Walric.SubmissionLimit},
+		Time:        walricConfig.Walric.TimeFilter,
+	}
+
+	return gather.NewService(log.Logger, redditClient, submissionService, walricConfig.Walric.DataDir, listPostOptions), nil
+}
@@ -0,0 +1,51 @@
+package command
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var (
+	redownloadSubreddits []string
+)
+
+// NewRedownloadCommand initializes a CLI command to download missing or
+// corrupted image files again.
+func NewRedownloadCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "redownload [POST_ID...]",
+		Short: "Download missing or corrupted image files again",
+		Long: `Download missing or corrupted image files again
+
+By default, all submissions are checked. The selection can be restricted to
+given post IDs and/or subreddits.
+
+Submissions whose image can no longer be retrieved are marked as
+unavailable.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			submissions, err := selectSubmissions(ctx, args, redownloadSubreddits)
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			gatherService, err := newGatherService()
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			if err := gatherService.Redownload(ctx, submissions); err != nil {
+				cobra.CheckErr(err)
+			}
+		},
+	}
+
+	cmd.Flags().StringSliceVar(
+		&redownloadSubreddits,
+		"subreddit",
+		[]string{},
+		"Only check submissions from the given subreddit(s)",
+	)
+
+	return cmd
+}
@@ -0,0 +1,51 @@
+package command
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var (
+	refreshSubreddits []string
+)
+
+// NewRefreshCommand initializes a CLI command to retrieve up-to-date post
+// metadata from Reddit.
+func NewRefreshCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "refresh [POST_ID...]",
+		Short: "Refresh post scores, titles and status from Reddit",
+		Long: `Refresh post scores, titles and status from Reddit
+
+By default, all submissions are refreshed. The selection can be restricted to
+given post IDs and/or subreddits.
+
+Posts that were deleted or removed are flagged as removed, and posts that were
+marked as NSFW after being gathered are flagged as NSFW.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			submissions, err := selectSubmissions(ctx, args, refreshSubreddits)
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			gatherService, err := newGatherService()
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			if err := gatherService.Refresh(ctx, submissions); err != nil {
+				cobra.CheckErr(err)
+			}
+		},
+	}
+
+	cmd.Flags().StringSliceVar(
+		&refreshSubreddits,
+		"subreddit",
+		[]string{},
+		"Only refresh submissions from the given subreddit(s)",
+	)
+
+	return cmd
+}
@@ -0,0 +1,40 @@
+package command
+
+import (
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/virtualtam/walric/pkg/integrity"
+)
+
+// NewRemoveCommand initializes a CLI command to remove submissions and ban
+// them from being gathered again.
+func NewRemoveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove POST_ID...",
+		Short: "Remove submissions and prevent them from being gathered again",
+		Long: `Remove submissions and prevent them from being gathered again
+
+The image file, the submission and its history entries are deleted, and the
+post ID and image checksum are added to the ban list.`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			integrityService := integrity.NewService(log.Logger, submissionService, walricConfig.DataDir())
+
+			for _, postID := range args {
+				sub, err := submissionService.ByPostID(ctx, postID)
+				if err != nil {
+					cobra.CheckErr(err)
+				}
+
+				if err := integrityService.Remove(ctx, sub); err != nil {
+					cobra.CheckErr(err)
+				}
+			}
+		},
+	}
+
+	return cmd
+}
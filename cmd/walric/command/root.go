@@ -1,27 +1,31 @@
 package command
 
 import (
-	"github.com/jmoiron/sqlx"
-	_ "github.com/mattn/go-sqlite3"
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 
 	"github.com/virtualtam/walric/cmd/walric/config"
-	"github.com/virtualtam/walric/internal/storage/sqlite3"
+	"github.com/virtualtam/walric/pkg/collection"
 	"github.com/virtualtam/walric/pkg/history"
 	"github.com/virtualtam/walric/pkg/submission"
 )
 
 const (
 	defaultDebugMode bool = false
+
+	// annotationNoRepository marks commands that do not access the repository,
+	// and may run before the database is initialized.
+	annotationNoRepository string = "walric/no-repository"
 )
 
 var (
-	configPath string
-	debugMode  bool
+	configPath  string
+	databaseURL string
+	debugMode   bool
 
 	walricConfig *config.Config
 
+	collectionService *collection.Service
 	historyService    *history.Service
 	submissionService *submission.Service
 )
@@ -45,15 +49,28 @@ Walric helps you manage a collection of curated wallpapers, courtesy of the Redd
 			}
 			walricConfig = cfg
 
-			db, err := sqlx.Open("sqlite3", walricConfig.DatabasePath())
-			if err != nil {
+			if databaseURL != "" {
+				if err := walricConfig.SetDatabaseURL(databaseURL); err != nil {
+					return err
+				}
+			}
+
+			if !requiresRepository(cmd) {
+				return nil
+			}
+
+			if err := checkDatabaseSchema(cmd.Context(), walricConfig); err != nil {
 				return err
 			}
 
-			sqliteRepository := sqlite3.NewRepository(db)
+			repository, err := openRepository(cmd.Context(), walricConfig)
+			if err != nil {
+				return err
+			}
 
-			submissionService = submission.NewService(sqliteRepository)
-			historyService = history.NewService(sqliteRepository, submissionService)
+			submissionService = submission.NewService(repository)
+			historyService = history.NewService(repository)
+			collectionService = collection.NewService(repository, submissionService)
 
 			return nil
 		},
@@ -65,6 +82,12 @@ Walric helps you manage a collection of curated wallpapers, courtesy of the Redd
 		"",
 		"Configuration file",
 	)
+	cmd.PersistentFlags().StringVar(
+		&databaseURL,
+		"database",
+		"",
+		"Database URL, overriding the configuration file (e.g. memory://fixture.json)",
+	)
 	cmd.PersistentFlags().BoolVar(
 		&debugMode,
 		"debug",
@@ -74,3 +97,21 @@ Walric helps you manage a collection of curated wallpapers, courtesy of the Redd
 
 	return cmd
 }
+
+// requiresRepository returns whether a command accesses the repository, which
+// is not the case of commands annotated with annotationNoRepository, nor of
+// the help and shell completion commands.
+func requiresRepository(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if _, ok := c.Annotations[annotationNoRepository]; ok {
+			return false
+		}
+
+		switch c.Name() {
+		case "help", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+			return false
+		}
+	}
+
+	return true
+}
@@ -3,43 +3,139 @@ package command
 import (
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/spf13/cobra"
+
+	"github.com/virtualtam/walric/cmd/walric/formatter"
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+var (
+	searchFavorites       bool
+	searchMinRating       int
+	searchExcludeDisliked bool
+	searchDisliked        bool
+	searchTags            []string
+	searchExcludeTags     []string
+	searchSort            string
+	searchLimit           int
+	searchPage            int
+	searchNSFW            string
 )
 
 // NewSearchCommand initializes a CLI command to search Submissions.
 func NewSearchCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "search",
-		Short: "Search for submissions by title",
-		Args:  cobra.ExactArgs(1),
+		Use:   "search QUERY",
+		Short: "Search for submissions by title, author, subreddit or tag",
+		Long: `Search for submissions by title, author, subreddit or tag
+
+Results are sorted by relevance, unless --sort is set. Words are matched
+regardless of their inflection, double-quoted words are matched as a phrase,
+and a trailing asterisk matches words starting with a prefix, e.g.:
+
+  walric search '"mountain lake" sun*' score>=5000
+
+` + queryHelp,
+		Args: cobra.MinimumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			submissions, err := submissionService.Search(args[0])
+			ctx := cmd.Context()
+
+			filter := &submission.Filter{
+				MinRating:       searchMinRating,
+				ExcludeDisliked: searchExcludeDisliked,
+				DislikedOnly:    searchDisliked,
+				Tags:            searchTags,
+				ExcludeTags:     searchExcludeTags,
+			}
+
+			policy, err := nsfwPolicy(searchNSFW)
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			filter.NSFW = policy
+
+			if searchFavorites {
+				filter.MinRating = submission.RatingFavorite
+			}
+
+			options, err := submissionListOptions(searchSort, searchLimit, searchPage)
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			results, err := submissionService.Search(ctx, strings.Join(args, " "), filter, options)
 			if err != nil {
 				cobra.CheckErr(err)
 			}
 
 			writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
 
-			for _, submission := range submissions {
+			highlight := formatter.IsTerminal(os.Stdout)
+
+			for _, result := range results {
 				fmt.Fprintf(
 					writer,
-					"%s\t%s\t%d x %d\t%s\n",
-					submission.Subreddit.Name,
-					submission.PostID,
-					submission.ImageWidthPx,
-					submission.ImageHeightPx,
-					submission.Title,
+					"%s\t%s\t%d x %d\t%s\t%s\n",
+					result.Submission.Subreddit.Name,
+					result.Submission.PostID,
+					result.Submission.ImageWidthPx,
+					result.Submission.ImageHeightPx,
+					formatter.FormatRating(result.Submission.Rating),
+					formatter.FormatSnippet(result.Snippet, highlight),
 				)
 			}
 
 			writer.Flush()
 
 			fmt.Println()
-			fmt.Println(len(submissions), "submission(s) found")
+			fmt.Println(len(results), "submission(s) found")
 		},
 	}
 
+	cmd.Flags().BoolVar(
+		&searchFavorites,
+		"favorites",
+		false,
+		"Only show submissions marked as favorite",
+	)
+	cmd.Flags().IntVar(
+		&searchMinRating,
+		"min-rating",
+		submission.RatingNone,
+		"Only show submissions rated at least this value (1-5)",
+	)
+	cmd.Flags().BoolVar(
+		&searchExcludeDisliked,
+		"exclude-disliked",
+		false,
+		"Do not show submissions marked as disliked",
+	)
+	cmd.Flags().BoolVar(
+		&searchDisliked,
+		"disliked",
+		false,
+		"Only show submissions marked as disliked",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&searchTags,
+		"tag",
+		[]string{},
+		"Only show submissions with all the given tag(s)",
+	)
+	cmd.Flags().StringSliceVar(
+		&searchExcludeTags,
+		"exclude-tag",
+		[]string{},
+		"Exclude submissions with any of the given tag(s)",
+	)
+
+	addNSFWFlag(cmd, &searchNSFW)
+	addListFlags(cmd, &searchSort, &searchLimit, &searchPage, submissionSortKeys())
+
 	return cmd
 }
@@ -0,0 +1,52 @@
+package command
+
+import (
+	"context"
+
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+// selectSubmissions returns the Submissions matching the given post IDs and
+// subreddits, or all Submissions if none are specified.
+//
+// Each Submission is returned once, even if it is matched both by post ID and
+// subreddit, so that it is never processed concurrently.
+func selectSubmissions(ctx context.Context, postIDs []string, subredditNames []string) ([]*submission.Submission, error) {
+	if len(postIDs) == 0 && len(subredditNames) == 0 {
+		return submissionService.All(ctx)
+	}
+
+	submissions := []*submission.Submission{}
+	selectedIDs := map[int]bool{}
+
+	appendSubmission := func(sub *submission.Submission) {
+		if selectedIDs[sub.ID] {
+			return
+		}
+
+		selectedIDs[sub.ID] = true
+		submissions = append(submissions, sub)
+	}
+
+	for _, postID := range postIDs {
+		sub, err := submissionService.ByPostID(ctx, postID)
+		if err != nil {
+			return []*submission.Submission{}, err
+		}
+
+		appendSubmission(sub)
+	}
+
+	for _, subredditName := range subredditNames {
+		subredditSubmissions, err := submissionService.BySubredditName(ctx, subredditName)
+		if err != nil {
+			return []*submission.Submission{}, err
+		}
+
+		for _, sub := range subredditSubmissions {
+			appendSubmission(sub)
+		}
+	}
+
+	return submissions, nil
+}
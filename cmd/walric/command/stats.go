@@ -14,7 +14,9 @@ func NewStatsCommand() *cobra.Command {
 		Use:   "stats",
 		Short: "Display statistics about gathered submissions",
 		Run: func(cmd *cobra.Command, args []string) {
-			stats, err := submissionService.Stats()
+			ctx := cmd.Context()
+
+			stats, err := submissionService.Stats(ctx)
 			if err != nil {
 				cobra.CheckErr(err)
 			}
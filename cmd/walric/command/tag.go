@@ -0,0 +1,110 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// NewTagCommand initializes a CLI command to manage submission tags.
+func NewTagCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tag",
+		Short: "Manage submission tags",
+	}
+
+	cmd.AddCommand(
+		newTagAddCommand(),
+		newTagListCommand(),
+		newTagRemoveCommand(),
+	)
+
+	return cmd
+}
+
+func newTagAddCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add POST_ID TAG...",
+		Short: "Add tags to a submission",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			sub, err := submissionService.ByPostID(ctx, args[0])
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			if err := submissionService.AddTags(ctx, sub.ID, args[1:]); err != nil {
+				cobra.CheckErr(err)
+			}
+		},
+	}
+
+	return cmd
+}
+
+func newTagListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list [POST_ID]",
+		Short: "List the tags for a submission, or all tags if no post ID is given",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			if len(args) == 1 {
+				sub, err := submissionService.ByPostID(ctx, args[0])
+				if err != nil {
+					cobra.CheckErr(err)
+				}
+
+				fmt.Println(strings.Join(sub.Tags, "\n"))
+				return
+			}
+
+			stats, err := submissionService.TagStats(ctx)
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+
+			fmt.Fprintln(writer, "Count\tTag\t")
+			fmt.Fprintln(writer, "-----\t---\t")
+			fmt.Fprintln(writer, "\t\t")
+
+			for _, tagStats := range stats {
+				fmt.Fprintf(writer, "%d\t%s\t\n", tagStats.Submissions, tagStats.Name)
+			}
+
+			writer.Flush()
+		},
+	}
+
+	return cmd
+}
+
+func newTagRemoveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove POST_ID TAG...",
+		Short: "Remove tags from a submission",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			sub, err := submissionService.ByPostID(ctx, args[0])
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			if err := submissionService.RemoveTags(ctx, sub.ID, args[1:]); err != nil {
+				cobra.CheckErr(err)
+			}
+		},
+	}
+
+	return cmd
+}
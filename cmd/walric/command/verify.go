@@ -0,0 +1,77 @@
+package command
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/virtualtam/walric/pkg/integrity"
+)
+
+const (
+	defaultVerifyQuarantine bool = false
+)
+
+var (
+	verifyQuarantine bool
+)
+
+// NewVerifyCommand initializes a CLI command to check the integrity of the
+// local image files.
+func NewVerifyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Detect truncated or corrupted image files",
+		Run: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+
+			integrityService := integrity.NewService(log.Logger, submissionService, walricConfig.DataDir())
+
+			corruptedImages, err := integrityService.Verify(ctx)
+			if err != nil {
+				cobra.CheckErr(err)
+			}
+
+			writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+
+			for _, corruptedImage := range corruptedImages {
+				status := "corrupted"
+
+				if verifyQuarantine {
+					if _, err := integrityService.Quarantine(ctx, corruptedImage.Submission); err != nil {
+						cobra.CheckErr(err)
+					}
+
+					status = "quarantined"
+				}
+
+				fmt.Fprintf(
+					writer,
+					"%s\t%s\t%s\t%s\t%s\n",
+					corruptedImage.Submission.Subreddit.Name,
+					corruptedImage.Submission.PostID,
+					status,
+					corruptedImage.Submission.ImageFilename,
+					corruptedImage.Err,
+				)
+			}
+
+			writer.Flush()
+
+			fmt.Println()
+			fmt.Println(len(corruptedImages), "corrupted image(s) found")
+		},
+	}
+
+	cmd.Flags().BoolVar(
+		&verifyQuarantine,
+		"quarantine",
+		defaultVerifyQuarantine,
+		"Move corrupted image files to the quarantine directory, and mark them as unavailable",
+	)
+
+	return cmd
+}
@@ -4,18 +4,91 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/BurntSushi/toml"
+
+	"github.com/virtualtam/walric/pkg/submission"
 )
 
 const (
 	databaseFilename string = "walric.db"
+
+	// DatabaseDriverMemory selects the in-memory storage backend, optionally
+	// loaded from a JSON snapshot; changes are not persisted.
+	DatabaseDriverMemory string = "memory"
+
+	// DatabaseDriverPostgres selects the PostgreSQL storage backend.
+	DatabaseDriverPostgres string = "postgres"
+
+	// DatabaseDriverSQLite3 selects the SQLite3 storage backend.
+	DatabaseDriverSQLite3 string = "sqlite3"
 )
 
 // Config holds the application's configuration.
 type Config struct {
-	Reddit redditInfo
-	Walric walricInfo
+	Database databaseInfo
+	Reddit   redditInfo
+	Walric   walricInfo
+}
+
+// DatabaseDriver returns the name of the storage backend, or
+// DatabaseDriverSQLite3 if unset.
+func (c *Config) DatabaseDriver() (string, error) {
+	switch c.Database.Driver {
+	case "", DatabaseDriverSQLite3:
+		return DatabaseDriverSQLite3, nil
+	case DatabaseDriverMemory:
+		return DatabaseDriverMemory, nil
+	case DatabaseDriverPostgres:
+		return DatabaseDriverPostgres, nil
+	}
+
+	return "", fmt.Errorf("config: unknown database driver %q", c.Database.Driver)
+}
+
+// SetDatabaseURL sets the database driver and DSN from a URL, overriding the
+// configuration file:
+//
+//   - memory://fixture.json for the in-memory backend, loaded from a JSON
+//     snapshot, or memory:// for an empty database;
+//   - postgres://... or postgresql://... for PostgreSQL;
+//   - sqlite3://path/to/walric.db for SQLite3.
+func (c *Config) SetDatabaseURL(databaseURL string) error {
+	scheme, location, ok := strings.Cut(databaseURL, "://")
+	if !ok {
+		return fmt.Errorf("config: invalid database URL %q: missing scheme", databaseURL)
+	}
+
+	switch scheme {
+	case DatabaseDriverMemory:
+		c.Database.Driver, c.Database.DSN = DatabaseDriverMemory, location
+	case "postgres", "postgresql":
+		c.Database.Driver, c.Database.DSN = DatabaseDriverPostgres, databaseURL
+	case DatabaseDriverSQLite3:
+		c.Database.Driver, c.Database.DSN = DatabaseDriverSQLite3, location
+	default:
+		return fmt.Errorf("config: unknown database URL scheme %q", scheme)
+	}
+
+	return nil
+}
+
+// DatabaseDSN returns the data source name of the database: the configured
+// DSN if set, the path to the JSON snapshot for the in-memory backend, or the
+// path to the SQLite3 database in the data directory.
+func (c *Config) DatabaseDSN() string {
+	if c.Database.DSN != "" || c.Database.Driver == DatabaseDriverMemory {
+		return c.Database.DSN
+	}
+
+	return c.DatabasePath()
+}
+
+// DatabaseAutoMigrate returns whether pending migrations are applied to the
+// database schema on startup.
+func (c *Config) DatabaseAutoMigrate() bool {
+	return c.Database.AutoMigrate
 }
 
 // DataDir returns the path to the application's data directory.
@@ -29,6 +102,62 @@ func (c *Config) DatabasePath() string {
 	return filepath.Join(c.Walric.DataDir, databaseFilename)
 }
 
+// NSFWPolicy returns the policy applied to NSFW submissions when selecting
+// wallpapers, or submission.DefaultNSFWPolicy if unset.
+func (c *Config) NSFWPolicy() (submission.NSFWPolicy, error) {
+	if c.Walric.NSFW == "" {
+		return submission.DefaultNSFWPolicy, nil
+	}
+
+	policy, err := submission.ParseNSFWPolicy(c.Walric.NSFW)
+	if err != nil {
+		return "", fmt.Errorf("config: %w", err)
+	}
+
+	return policy, nil
+}
+
+// MaxCropPercent returns the maximum percentage of an image's area that may
+// be cropped to fit the monitor setup, and whether it is set.
+func (c *Config) MaxCropPercent() (int, bool) {
+	if c.Walric.MaxCropPercent == nil {
+		return 0, false
+	}
+
+	return *c.Walric.MaxCropPercent, true
+}
+
+// SelectionStrategy returns the name of the strategy used to select random
+// wallpapers, or submission.DefaultSelectionStrategy if unset.
+func (c *Config) SelectionStrategy() string {
+	if c.Walric.Strategy == "" {
+		return submission.DefaultSelectionStrategy
+	}
+
+	return c.Walric.Strategy
+}
+
+// RepeatPolicy returns the policy defining when a wallpaper may be selected
+// again, or submission.DefaultRepeatPolicy if unset.
+func (c *Config) RepeatPolicy() (submission.RepeatPolicy, error) {
+	if c.Walric.Repeat == "" {
+		return submission.DefaultRepeatPolicy, nil
+	}
+
+	policy, err := submission.ParseRepeatPolicy(c.Walric.Repeat)
+	if err != nil {
+		return submission.RepeatPolicy{}, fmt.Errorf("config: %w", err)
+	}
+
+	return policy, nil
+}
+
+type databaseInfo struct {
+	Driver      string `toml:"driver"`
+	DSN         string `toml:"dsn"`
+	AutoMigrate bool   `toml:"auto_migrate"`
+}
+
 type redditInfo struct {
 	ClientID     string `toml:"client_id"`
 	ClientSecret string `toml:"client_secret"`
@@ -40,6 +169,10 @@ type walricInfo struct {
 	SubmissionLimit int      `toml:"submission_limit"`
 	TimeFilter      string   `toml:"time_filter"`
 	Subreddits      []string `toml:"subreddits"`
+	NSFW            string   `toml:"nsfw"`
+	MaxCropPercent  *int     `toml:"max_crop_percent"`
+	Strategy        string   `toml:"selection_strategy"`
+	Repeat          string   `toml:"repeat"`
 }
 
 // LoadTOML loads the application's configuration from a TOML file and returns
@@ -0,0 +1,20 @@
+package formatter
+
+import (
+	"strings"
+
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+// FormatRating returns a human-readable representation of a Submission's
+// rating.
+func FormatRating(rating int) string {
+	switch {
+	case rating == submission.RatingDisliked:
+		return "disliked"
+	case rating <= submission.RatingNone:
+		return "not rated"
+	}
+
+	return strings.Repeat("★", rating) + strings.Repeat("☆", submission.RatingFavorite-rating)
+}
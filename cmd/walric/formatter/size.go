@@ -0,0 +1,21 @@
+package formatter
+
+import "fmt"
+
+// FormatBytes returns a human-readable representation of a size in bytes,
+// using binary prefixes.
+func FormatBytes(sizeBytes int64) string {
+	const unit = 1024
+
+	if sizeBytes < unit {
+		return fmt.Sprintf("%d B", sizeBytes)
+	}
+
+	div, exp := int64(unit), 0
+	for n := sizeBytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(sizeBytes)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,40 @@
+package formatter
+
+import (
+	"os"
+	"strings"
+
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+const (
+	ansiBold  = "\x1b[1m"
+	ansiReset = "\x1b[0m"
+)
+
+// FormatSnippet returns a search result snippet, where matching terms are
+// displayed in bold if highlight is set.
+func FormatSnippet(snippet string, highlight bool) string {
+	start, end := "", ""
+	if highlight {
+		start, end = ansiBold, ansiReset
+	}
+
+	replacer := strings.NewReplacer(
+		submission.HighlightStart, start,
+		submission.HighlightEnd, end,
+	)
+
+	return replacer.Replace(snippet)
+}
+
+// IsTerminal returns whether a file is a terminal, to only use ANSI escape
+// sequences when they can be displayed.
+func IsTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
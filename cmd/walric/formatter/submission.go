@@ -3,6 +3,7 @@ package formatter
 import (
 	"fmt"
 	"io"
+	"strings"
 	"text/tabwriter"
 
 	"github.com/virtualtam/walric/pkg/submission"
@@ -18,10 +19,20 @@ func FormatSubmissionAsTab(output io.Writer, submission *submission.Submission)
 	fmt.Fprintf(writer, "Subreddit\t%s\t\n", submission.Subreddit.Name)
 	fmt.Fprintf(writer, "Posted At\t%s\t\n", FormatDateAsUTC(submission.PostedAt))
 	fmt.Fprintf(writer, "Permalink\t%s\t\n", submission.PermalinkURL())
+	fmt.Fprintf(writer, "Score\t%d\t\n", submission.Score)
+	fmt.Fprintf(writer, "Removed\t%t\t\n", submission.Removed)
+	if !submission.LastRefreshedAt.IsZero() {
+		fmt.Fprintf(writer, "Refreshed At\t%s\t\n", FormatDateAsUTC(submission.LastRefreshedAt))
+	}
 	fmt.Fprintf(writer, "Image URL\t%s\t\n", submission.ImageURL)
+	fmt.Fprintf(writer, "Unavailable\t%t\t\n", submission.ImageUnavailable)
 	fmt.Fprintf(writer, "Image Size\t%d x %d\t\n", submission.ImageWidthPx, submission.ImageHeightPx)
 	fmt.Fprintf(writer, "Filename\t%s\t\n", submission.ImageFilename)
 	fmt.Fprintf(writer, "NSFW\t%t\t\n", submission.ImageNSFW)
+	fmt.Fprintf(writer, "Rating\t%s\t\n", FormatRating(submission.Rating))
+	if len(submission.Tags) > 0 {
+		fmt.Fprintf(writer, "Tags\t%s\t\n", strings.Join(submission.Tags, ", "))
+	}
 	fmt.Fprintf(writer, "Walric ID\t%d\t\n", submission.ID)
 
 	return writer
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"context"
 	"os"
+	"os/signal"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -18,18 +20,34 @@ func main() {
 	rootCommand := command.NewRootCommand()
 
 	commands := []*cobra.Command{
+		command.NewCollectionCommand(),
 		command.NewCurrentCommand(),
+		command.NewDislikeCommand(),
+		command.NewFavCommand(),
+		command.NewFsckCommand(),
 		command.NewGatherCommand(),
 		command.NewHistoryCommand(),
 		command.NewInfoCommand(),
 		command.NewListCandidatesCommand(),
 		command.NewMigrateCommand(),
+		command.NewPruneCommand(),
 		command.NewRandomCommand(),
+		command.NewRateCommand(),
+		command.NewRedownloadCommand(),
+		command.NewRefreshCommand(),
+		command.NewRemoveCommand(),
 		command.NewSearchCommand(),
 		command.NewStatsCommand(),
+		command.NewTagCommand(),
+		command.NewVerifyCommand(),
 	}
 
 	rootCommand.AddCommand(commands...)
 
-	cobra.CheckErr(rootCommand.Execute())
+	// Interrupting walric cancels the commands' context
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	err := rootCommand.ExecuteContext(ctx)
+	stop()
+
+	cobra.CheckErr(err)
 }
@@ -0,0 +1,10 @@
+package memory
+
+import "errors"
+
+var (
+	ErrSnapshotDuplicateID        error = errors.New("memory: duplicate ID in snapshot")
+	ErrSnapshotInvalidID          error = errors.New("memory: invalid ID in snapshot")
+	ErrSnapshotInvalidReference   error = errors.New("memory: invalid reference in snapshot")
+	ErrSnapshotUnsupportedVersion error = errors.New("memory: unsupported snapshot version")
+)
@@ -0,0 +1,701 @@
+// Package memory provides an in-memory storage backend, which may be loaded
+// from and saved to a JSON snapshot.
+package memory
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/virtualtam/walric/pkg/collection"
+	"github.com/virtualtam/walric/pkg/history"
+	"github.com/virtualtam/walric/pkg/monitor"
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+var (
+	_ collection.Repository = &Repository{}
+	_ history.Repository    = &Repository{}
+	_ submission.Repository = &Repository{}
+)
+
+// Repository provides an in-memory Repository for Collections, the History
+// and Submissions, safe for concurrent use.
+//
+// Values are copied when they are persisted and returned, so that callers
+// never share them with the Repository.
+type Repository struct {
+	mu *sync.RWMutex
+
+	// tx is set for the Repository passed to WithTx, whose operations run
+	// while the lock is already held.
+	tx bool
+
+	data *data
+}
+
+// data holds the state of a Repository.
+type data struct {
+	submissions *submission.RepositoryInMemory
+
+	entryCurrentID int
+	entries        []*history.Entry
+
+	collectionCurrentID int
+	collections         []*collection.Collection
+	items               map[int][]*collection.Item
+}
+
+// NewRepository initializes and returns an empty Repository.
+func NewRepository() *Repository {
+	return newRepository(&data{
+		submissions:         submission.NewRepositoryInMemory([]*submission.Submission{}, []*submission.Subreddit{}),
+		entryCurrentID:      1,
+		entries:             []*history.Entry{},
+		collectionCurrentID: 1,
+		collections:         []*collection.Collection{},
+		items:               map[int][]*collection.Item{},
+	})
+}
+
+func newRepository(d *data) *Repository {
+	return &Repository{
+		mu:   &sync.RWMutex{},
+		data: d,
+	}
+}
+
+// lock acquires the write lock, unless it is already held, and returns the
+// function releasing it.
+func (r *Repository) lock() func() {
+	if r.tx {
+		return func() {}
+	}
+
+	r.mu.Lock()
+
+	return r.mu.Unlock
+}
+
+// rlock acquires the read lock, unless the write lock is already held, and
+// returns the function releasing it.
+func (r *Repository) rlock() func() {
+	if r.tx {
+		return func() {}
+	}
+
+	r.mu.RLock()
+
+	return r.mu.RUnlock
+}
+
+// WithTx runs fn with a Repository holding the write lock, and restores the
+// previous state if fn returns an error.
+func (r *Repository) WithTx(ctx context.Context, fn func(r submission.Repository) error) error {
+	if r.tx {
+		return fn(r)
+	}
+
+	defer r.lock()()
+
+	tx := &Repository{mu: r.mu, tx: true, data: r.data}
+	snapshot := r.data.snapshot()
+
+	err := r.data.submissions.WithTx(ctx, func(_ submission.Repository) error {
+		return fn(tx)
+	})
+	if err != nil {
+		r.data.restore(snapshot)
+		return err
+	}
+
+	return nil
+}
+
+// snapshot returns a copy of the History and Collections, as Submissions are
+// restored by submission.RepositoryInMemory.WithTx.
+func (d *data) snapshot() *data {
+	snapshot := *d
+	snapshot.entries = slices.Clone(d.entries)
+
+	snapshot.collections = make([]*collection.Collection, len(d.collections))
+	for index, c := range d.collections {
+		collectionCopy := *c
+		snapshot.collections[index] = &collectionCopy
+	}
+
+	snapshot.items = make(map[int][]*collection.Item, len(d.items))
+	for collectionID, items := range d.items {
+		snapshot.items[collectionID] = slices.Clone(items)
+	}
+
+	return &snapshot
+}
+
+// restore restores the History and Collections from a snapshot.
+func (d *data) restore(snapshot *data) {
+	d.entryCurrentID = snapshot.entryCurrentID
+	d.entries = snapshot.entries
+	d.collectionCurrentID = snapshot.collectionCurrentID
+	d.collections = snapshot.collections
+	d.items = snapshot.items
+}
+
+func (r *Repository) BanIsPostIDRegistered(ctx context.Context, postID string) (bool, error) {
+	defer r.rlock()()
+
+	return r.data.submissions.BanIsPostIDRegistered(ctx, postID)
+}
+
+func (r *Repository) BanIsImageSHA256Registered(ctx context.Context, imageSHA256 string) (bool, error) {
+	defer r.rlock()()
+
+	return r.data.submissions.BanIsImageSHA256Registered(ctx, imageSHA256)
+}
+
+func (r *Repository) BanCreate(ctx context.Context, ban *submission.Ban) error {
+	defer r.lock()()
+
+	banCopy := *ban
+
+	if err := r.data.submissions.BanCreate(ctx, &banCopy); err != nil {
+		return err
+	}
+
+	ban.ID = banCopy.ID
+
+	return nil
+}
+
+func (r *Repository) CollectionGetAll(ctx context.Context) ([]*collection.Collection, error) {
+	if err := ctx.Err(); err != nil {
+		return []*collection.Collection{}, err
+	}
+
+	defer r.rlock()()
+
+	collections := make([]*collection.Collection, len(r.data.collections))
+	for index, c := range r.data.collections {
+		collectionCopy := *c
+		collections[index] = &collectionCopy
+	}
+
+	slices.SortStableFunc(collections, func(a, b *collection.Collection) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+
+	return collections, nil
+}
+
+func (r *Repository) CollectionGetByName(ctx context.Context, name string) (*collection.Collection, error) {
+	if err := ctx.Err(); err != nil {
+		return &collection.Collection{}, err
+	}
+
+	defer r.rlock()()
+
+	for _, c := range r.data.collections {
+		if c.Name == name {
+			collectionCopy := *c
+			return &collectionCopy, nil
+		}
+	}
+
+	return &collection.Collection{}, collection.ErrCollectionNotFound
+}
+
+func (r *Repository) CollectionIsNameRegistered(ctx context.Context, name string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	defer r.rlock()()
+
+	return slices.ContainsFunc(r.data.collections, func(c *collection.Collection) bool {
+		return c.Name == name
+	}), nil
+}
+
+func (r *Repository) CollectionCreate(ctx context.Context, c *collection.Collection) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	defer r.lock()()
+
+	if slices.ContainsFunc(r.data.collections, func(existing *collection.Collection) bool {
+		return existing.Name == c.Name
+	}) {
+		return collection.ErrCollectionNameAlreadyRegistered
+	}
+
+	c.ID = r.data.collectionCurrentID
+	r.data.collectionCurrentID++
+
+	collectionCopy := *c
+	r.data.collections = append(r.data.collections, &collectionCopy)
+
+	return nil
+}
+
+func (r *Repository) CollectionUpdateCursor(ctx context.Context, collectionID int, cursor int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	defer r.lock()()
+
+	c, err := r.data.collectionByID(collectionID)
+	if err != nil {
+		return err
+	}
+
+	c.Cursor = cursor
+
+	return nil
+}
+
+// collectionByID returns the Collection for a given ID.
+func (d *data) collectionByID(id int) (*collection.Collection, error) {
+	for _, c := range d.collections {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+
+	return &collection.Collection{}, collection.ErrCollectionNotFound
+}
+
+func (r *Repository) CollectionItemGetAll(ctx context.Context, collectionID int) ([]*collection.Item, error) {
+	if err := ctx.Err(); err != nil {
+		return []*collection.Item{}, err
+	}
+
+	defer r.rlock()()
+
+	items := make([]*collection.Item, len(r.data.items[collectionID]))
+	for index, item := range r.data.items[collectionID] {
+		items[index] = &collection.Item{
+			Position:   item.Position,
+			Submission: &submission.Submission{ID: item.Submission.ID},
+		}
+	}
+
+	return items, nil
+}
+
+func (r *Repository) CollectionItemIsSubmissionRegistered(ctx context.Context, collectionID int, submissionID int) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	defer r.rlock()()
+
+	return r.data.isItemRegistered(collectionID, submissionID), nil
+}
+
+// isItemRegistered returns whether a Submission belongs to a given Collection.
+func (d *data) isItemRegistered(collectionID int, submissionID int) bool {
+	return slices.ContainsFunc(d.items[collectionID], func(item *collection.Item) bool {
+		return item.Submission.ID == submissionID
+	})
+}
+
+func (r *Repository) CollectionItemAdd(ctx context.Context, collectionID int, submissionID int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	defer r.lock()()
+
+	if _, err := r.data.collectionByID(collectionID); err != nil {
+		return err
+	}
+
+	if _, err := r.data.submissions.SubmissionGetByID(ctx, submissionID); err != nil {
+		return err
+	}
+
+	if r.data.isItemRegistered(collectionID, submissionID) {
+		return collection.ErrItemAlreadyRegistered
+	}
+
+	position := 1
+	if items := r.data.items[collectionID]; len(items) > 0 {
+		position = items[len(items)-1].Position + 1
+	}
+
+	r.data.items[collectionID] = append(r.data.items[collectionID], &collection.Item{
+		Position:   position,
+		Submission: &submission.Submission{ID: submissionID},
+	})
+
+	return nil
+}
+
+func (r *Repository) CollectionItemRemove(ctx context.Context, collectionID int, submissionID int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	defer r.lock()()
+
+	if !r.data.isItemRegistered(collectionID, submissionID) {
+		return collection.ErrItemNotFound
+	}
+
+	r.data.items[collectionID] = slices.DeleteFunc(slices.Clone(r.data.items[collectionID]), func(item *collection.Item) bool {
+		return item.Submission.ID == submissionID
+	})
+
+	return nil
+}
+
+func (r *Repository) HistoryGetAll(ctx context.Context, options *history.ListOptions) ([]*history.Entry, error) {
+	defer r.rlock()()
+
+	entries, err := r.data.loadEntries(ctx)
+	if err != nil {
+		return []*history.Entry{}, err
+	}
+
+	if options.Descending() {
+		slices.Reverse(entries)
+	}
+
+	return submission.PageItems(entries, options.Page), nil
+}
+
+func (r *Repository) HistoryGetCurrent(ctx context.Context) (*history.Entry, error) {
+	defer r.rlock()()
+
+	entries, err := r.data.loadEntries(ctx)
+	if err != nil {
+		return &history.Entry{}, err
+	}
+
+	if len(entries) == 0 {
+		return &history.Entry{}, history.ErrNotFound
+	}
+
+	return entries[len(entries)-1], nil
+}
+
+func (r *Repository) HistoryCreate(ctx context.Context, entry *history.Entry) error {
+	defer r.lock()()
+
+	if _, err := r.data.submissions.SubmissionGetByID(ctx, entry.Submission.ID); err != nil {
+		return err
+	}
+
+	entry.ID = r.data.entryCurrentID
+	r.data.entryCurrentID++
+
+	r.data.addEntry(entry.ID, entry.Date, entry.Submission.ID)
+
+	return nil
+}
+
+// addEntry adds an Entry to the History, and records the selection of its
+// Submission.
+func (d *data) addEntry(id int, date time.Time, submissionID int) {
+	d.entries = append(d.entries, &history.Entry{
+		ID:         id,
+		Date:       date,
+		Submission: &submission.Submission{ID: submissionID},
+	})
+
+	d.submissions.RecordSelection(submissionID, date)
+}
+
+// loadEntries returns the History Entries with their Submission loaded, in
+// chronological order.
+func (d *data) loadEntries(ctx context.Context) ([]*history.Entry, error) {
+	entries := make([]*history.Entry, len(d.entries))
+
+	for index, entry := range d.entries {
+		s, err := d.submissions.SubmissionGetByID(ctx, entry.Submission.ID)
+		if err != nil {
+			return []*history.Entry{}, err
+		}
+
+		entries[index] = &history.Entry{
+			ID:         entry.ID,
+			Date:       entry.Date,
+			Submission: cloneSubmission(s),
+		}
+	}
+
+	slices.SortStableFunc(entries, func(a, b *history.Entry) int {
+		return cmp.Or(a.Date.Compare(b.Date), cmp.Compare(a.ID, b.ID))
+	})
+
+	return entries, nil
+}
+
+func (r *Repository) SubmissionGetAll(ctx context.Context) ([]*submission.Submission, error) {
+	defer r.rlock()()
+
+	return cloneSubmissions(r.data.submissions.SubmissionGetAll(ctx))
+}
+
+func (r *Repository) SubmissionGetByFilter(ctx context.Context, filter *submission.Filter, options *submission.ListOptions) ([]*submission.Submission, error) {
+	defer r.rlock()()
+
+	return cloneSubmissions(r.data.submissions.SubmissionGetByFilter(ctx, filter, options))
+}
+
+func (r *Repository) SubmissionGetByID(ctx context.Context, id int) (*submission.Submission, error) {
+	defer r.rlock()()
+
+	return cloneSubmissionResult(r.data.submissions.SubmissionGetByID(ctx, id))
+}
+
+func (r *Repository) SubmissionGetByMinResolution(ctx context.Context, minResolution *monitor.Resolution, filter *submission.Filter, options *submission.ListOptions) ([]*submission.Submission, error) {
+	defer r.rlock()()
+
+	return cloneSubmissions(r.data.submissions.SubmissionGetByMinResolution(ctx, minResolution, filter, options))
+}
+
+func (r *Repository) SubmissionGetByPostID(ctx context.Context, postID string) (*submission.Submission, error) {
+	defer r.rlock()()
+
+	return cloneSubmissionResult(r.data.submissions.SubmissionGetByPostID(ctx, postID))
+}
+
+func (r *Repository) SubmissionGetBySubredditID(ctx context.Context, subredditID int) ([]*submission.Submission, error) {
+	defer r.rlock()()
+
+	return cloneSubmissions(r.data.submissions.SubmissionGetBySubredditID(ctx, subredditID))
+}
+
+func (r *Repository) SubmissionIsPostIDRegistered(ctx context.Context, postID string) (bool, error) {
+	defer r.rlock()()
+
+	return r.data.submissions.SubmissionIsPostIDRegistered(ctx, postID)
+}
+
+func (r *Repository) SubmissionSearch(ctx context.Context, terms []submission.SearchTerm, filter *submission.Filter, options *submission.ListOptions) ([]*submission.SearchResult, error) {
+	defer r.rlock()()
+
+	results, err := r.data.submissions.SubmissionSearch(ctx, terms, filter, options)
+	if err != nil {
+		return []*submission.SearchResult{}, err
+	}
+
+	resultCopies := make([]*submission.SearchResult, len(results))
+	for index, result := range results {
+		resultCopies[index] = &submission.SearchResult{
+			Submission: cloneSubmission(result.Submission),
+			Snippet:    result.Snippet,
+		}
+	}
+
+	return resultCopies, nil
+}
+
+func (r *Repository) SubmissionGetRandom(ctx context.Context, minResolution *monitor.Resolution, filter *submission.Filter, repeat submission.RepeatPolicy) (*submission.Submission, error) {
+	defer r.rlock()()
+
+	return cloneSubmissionResult(r.data.submissions.SubmissionGetRandom(ctx, minResolution, filter, repeat))
+}
+
+func (r *Repository) SubmissionGetRandomCandidates(ctx context.Context, minResolution *monitor.Resolution, filter *submission.Filter, repeat submission.RepeatPolicy) ([]*submission.Submission, error) {
+	defer r.rlock()()
+
+	return cloneSubmissions(r.data.submissions.SubmissionGetRandomCandidates(ctx, minResolution, filter, repeat))
+}
+
+func (r *Repository) SubmissionCreate(ctx context.Context, s *submission.Submission) error {
+	defer r.lock()()
+
+	submissionCopy := cloneSubmission(s)
+
+	if err := r.data.submissions.SubmissionCreate(ctx, submissionCopy); err != nil {
+		return err
+	}
+
+	s.ID = submissionCopy.ID
+
+	return nil
+}
+
+func (r *Repository) SubmissionUpdate(ctx context.Context, s *submission.Submission) error {
+	defer r.lock()()
+
+	return r.data.submissions.SubmissionUpdate(ctx, cloneSubmission(s))
+}
+
+func (r *Repository) SubmissionRate(ctx context.Context, id int, rating int) error {
+	defer r.lock()()
+
+	return r.data.submissions.SubmissionRate(ctx, id, rating)
+}
+
+func (r *Repository) SubmissionAddTags(ctx context.Context, id int, tags []string) error {
+	defer r.lock()()
+
+	return r.data.submissions.SubmissionAddTags(ctx, id, tags)
+}
+
+func (r *Repository) SubmissionRemoveTags(ctx context.Context, id int, tags []string) error {
+	defer r.lock()()
+
+	return r.data.submissions.SubmissionRemoveTags(ctx, id, tags)
+}
+
+func (r *Repository) SubmissionDelete(ctx context.Context, id int) error {
+	defer r.lock()()
+
+	if err := r.data.submissions.SubmissionDelete(ctx, id); err != nil {
+		return err
+	}
+
+	r.data.deleteSubmissionReferences([]int{id})
+
+	return nil
+}
+
+func (r *Repository) SubmissionDeleteMany(ctx context.Context, ids []int) error {
+	defer r.lock()()
+
+	if err := r.data.submissions.SubmissionDeleteMany(ctx, ids); err != nil {
+		return err
+	}
+
+	r.data.deleteSubmissionReferences(ids)
+
+	return nil
+}
+
+// deleteSubmissionReferences deletes the History Entries and Collection Items
+// referencing the Submissions with the given IDs.
+func (d *data) deleteSubmissionReferences(submissionIDs []int) {
+	references := func(s *submission.Submission) bool {
+		return slices.Contains(submissionIDs, s.ID)
+	}
+
+	d.entries = slices.DeleteFunc(slices.Clone(d.entries), func(entry *history.Entry) bool {
+		return references(entry.Submission)
+	})
+
+	for collectionID, items := range d.items {
+		d.items[collectionID] = slices.DeleteFunc(slices.Clone(items), func(item *collection.Item) bool {
+			return references(item.Submission)
+		})
+	}
+}
+
+func (r *Repository) SubredditCreate(ctx context.Context, s *submission.Subreddit) error {
+	defer r.lock()()
+
+	subredditCopy := *s
+
+	if err := r.data.submissions.SubredditCreate(ctx, &subredditCopy); err != nil {
+		return err
+	}
+
+	s.ID = subredditCopy.ID
+
+	return nil
+}
+
+func (r *Repository) SubredditGetAll(ctx context.Context) ([]*submission.Subreddit, error) {
+	defer r.rlock()()
+
+	subreddits, err := r.data.submissions.SubredditGetAll(ctx)
+	if err != nil {
+		return []*submission.Subreddit{}, err
+	}
+
+	subredditCopies := make([]*submission.Subreddit, len(subreddits))
+	for index, subreddit := range subreddits {
+		subredditCopy := *subreddit
+		subredditCopies[index] = &subredditCopy
+	}
+
+	return subredditCopies, nil
+}
+
+func (r *Repository) SubredditGetStats(ctx context.Context) ([]submission.SubredditStats, error) {
+	defer r.rlock()()
+
+	return r.data.submissions.SubredditGetStats(ctx)
+}
+
+func (r *Repository) SubredditGetByID(ctx context.Context, id int) (*submission.Subreddit, error) {
+	defer r.rlock()()
+
+	return cloneSubredditResult(r.data.submissions.SubredditGetByID(ctx, id))
+}
+
+func (r *Repository) SubredditGetByName(ctx context.Context, name string) (*submission.Subreddit, error) {
+	defer r.rlock()()
+
+	return cloneSubredditResult(r.data.submissions.SubredditGetByName(ctx, name))
+}
+
+func (r *Repository) SubredditIsNameRegistered(ctx context.Context, name string) (bool, error) {
+	defer r.rlock()()
+
+	return r.data.submissions.SubredditIsNameRegistered(ctx, name)
+}
+
+func (r *Repository) TagGetStats(ctx context.Context) ([]submission.TagStats, error) {
+	defer r.rlock()()
+
+	return r.data.submissions.TagGetStats(ctx)
+}
+
+// cloneSubmission returns a deep copy of a Submission.
+func cloneSubmission(s *submission.Submission) *submission.Submission {
+	submissionCopy := *s
+	submissionCopy.Tags = slices.Clone(s.Tags)
+
+	if s.Subreddit != nil {
+		subredditCopy := *s.Subreddit
+		submissionCopy.Subreddit = &subredditCopy
+	}
+
+	return &submissionCopy
+}
+
+// cloneSubmissionResult returns a deep copy of a Submission returned by the
+// underlying submission.RepositoryInMemory.
+func cloneSubmissionResult(s *submission.Submission, err error) (*submission.Submission, error) {
+	if err != nil {
+		return &submission.Submission{}, err
+	}
+
+	return cloneSubmission(s), nil
+}
+
+// cloneSubmissions returns deep copies of the Submissions returned by the
+// underlying submission.RepositoryInMemory.
+func cloneSubmissions(submissions []*submission.Submission, err error) ([]*submission.Submission, error) {
+	if err != nil {
+		return []*submission.Submission{}, err
+	}
+
+	submissionCopies := make([]*submission.Submission, len(submissions))
+	for index, s := range submissions {
+		submissionCopies[index] = cloneSubmission(s)
+	}
+
+	return submissionCopies, nil
+}
+
+// cloneSubredditResult returns a copy of a Subreddit returned by the
+// underlying submission.RepositoryInMemory.
+func cloneSubredditResult(s *submission.Subreddit, err error) (*submission.Subreddit, error) {
+	if err != nil {
+		return &submission.Subreddit{}, err
+	}
+
+	subredditCopy := *s
+
+	return &subredditCopy, nil
+}
@@ -0,0 +1,391 @@
+package memory
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/virtualtam/walric/pkg/collection"
+	"github.com/virtualtam/walric/pkg/history"
+	"github.com/virtualtam/walric/pkg/monitor"
+	"github.com/virtualtam/walric/pkg/repositorytest"
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+func TestRepository(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repository {
+		return NewRepository()
+	})
+}
+
+// seedSnapshot creates a Subreddit, two Submissions, a Ban, a History Entry and
+// a Collection.
+func seedSnapshot(t *testing.T, r *Repository) {
+	t.Helper()
+
+	ctx := t.Context()
+
+	subreddit := &submission.Subreddit{Name: "EarthPorn"}
+	if err := r.SubredditCreate(ctx, subreddit); err != nil {
+		t.Fatalf("failed to create subreddit: %q", err)
+	}
+
+	for _, postID := range []string{"p1", "p2"} {
+		s := &submission.Submission{
+			Subreddit:     subreddit,
+			PostID:        postID,
+			PostedAt:      time.Date(2023, 1, 1, 8, 0, 0, 0, time.UTC),
+			Title:         "Submission " + postID,
+			ImageFilename: postID + ".jpg",
+			ImageHeightPx: 1080,
+			ImageWidthPx:  1920,
+			Tags:          []string{},
+		}
+
+		if err := r.SubmissionCreate(ctx, s); err != nil {
+			t.Fatalf("failed to create submission: %q", err)
+		}
+	}
+
+	if err := r.SubmissionRate(ctx, 1, submission.RatingFavorite); err != nil {
+		t.Fatalf("failed to rate submission: %q", err)
+	}
+
+	if err := r.SubmissionAddTags(ctx, 1, []string{"mountain"}); err != nil {
+		t.Fatalf("failed to tag submission: %q", err)
+	}
+
+	ban := &submission.Ban{Date: time.Date(2023, 2, 1, 8, 0, 0, 0, time.UTC), PostID: "p3", ImageSHA256: "abc123"}
+	if err := r.BanCreate(ctx, ban); err != nil {
+		t.Fatalf("failed to create ban: %q", err)
+	}
+
+	entry := &history.Entry{Date: time.Date(2023, 3, 1, 8, 0, 0, 0, time.UTC), Submission: &submission.Submission{ID: 2}}
+	if err := r.HistoryCreate(ctx, entry); err != nil {
+		t.Fatalf("failed to create history entry: %q", err)
+	}
+
+	c := &collection.Collection{Name: "favorites", CreatedAt: time.Date(2023, 4, 1, 8, 0, 0, 0, time.UTC)}
+	if err := r.CollectionCreate(ctx, c); err != nil {
+		t.Fatalf("failed to create collection: %q", err)
+	}
+
+	for _, submissionID := range []int{2, 1} {
+		if err := r.CollectionItemAdd(ctx, c.ID, submissionID); err != nil {
+			t.Fatalf("failed to add collection item: %q", err)
+		}
+	}
+
+	if err := r.CollectionUpdateCursor(ctx, c.ID, 1); err != nil {
+		t.Fatalf("failed to update collection cursor: %q", err)
+	}
+}
+
+func TestRepositoryCollection(t *testing.T) {
+	ctx := t.Context()
+	r := NewRepository()
+	seedSnapshot(t, r)
+
+	if err := r.CollectionCreate(ctx, &collection.Collection{Name: "empty"}); err != nil {
+		t.Fatalf("failed to create collection: %q", err)
+	}
+
+	testCases := []struct {
+		tname        string
+		collectionID int
+		submissionID int
+		wantErr      error
+	}{
+		// nominal cases
+		{
+			tname:        "new item",
+			collectionID: 2,
+			submissionID: 1,
+		},
+
+		// error cases
+		{
+			tname:        "duplicate item",
+			collectionID: 1,
+			submissionID: 1,
+			wantErr:      collection.ErrItemAlreadyRegistered,
+		},
+		{
+			tname:        "unknown collection",
+			collectionID: 9999,
+			submissionID: 1,
+			wantErr:      collection.ErrCollectionNotFound,
+		},
+		{
+			tname:        "unknown submission",
+			collectionID: 1,
+			submissionID: 9999,
+			wantErr:      submission.ErrSubmissionNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			err := r.CollectionItemAdd(ctx, tc.collectionID, tc.submissionID)
+			if !errors.Is(err, tc.wantErr) {
+				t.Errorf("want error %q, got %q", tc.wantErr, err)
+			}
+		})
+	}
+
+	t.Run("submission deletion removes items and entries", func(t *testing.T) {
+		if err := r.SubmissionDelete(ctx, 2); err != nil {
+			t.Fatalf("expected no error, got %q", err)
+		}
+
+		items, err := r.CollectionItemGetAll(ctx, 1)
+		if err != nil {
+			t.Fatalf("expected no error, got %q", err)
+		}
+
+		if len(items) != 1 || items[0].Submission.ID != 1 {
+			t.Errorf("want a single item for submission 1, got %d items", len(items))
+		}
+
+		if _, err := r.HistoryGetCurrent(ctx); !errors.Is(err, history.ErrNotFound) {
+			t.Errorf("want error %q, got %q", history.ErrNotFound, err)
+		}
+	})
+}
+
+func TestRepositoryWithTxRollback(t *testing.T) {
+	ctx := t.Context()
+	r := NewRepository()
+	seedSnapshot(t, r)
+
+	wantErr := errors.New("rollback")
+
+	err := r.WithTx(ctx, func(tx submission.Repository) error {
+		if err := tx.(*Repository).CollectionItemRemove(ctx, 1, 1); err != nil {
+			return err
+		}
+
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("want error %q, got %q", wantErr, err)
+	}
+
+	items, err := r.CollectionItemGetAll(ctx, 1)
+	if err != nil {
+		t.Fatalf("expected no error, got %q", err)
+	}
+
+	if len(items) != 2 {
+		t.Errorf("want 2 items, got %d", len(items))
+	}
+}
+
+func TestRepositoryJSONRoundTrip(t *testing.T) {
+	ctx := t.Context()
+	r := NewRepository()
+	seedSnapshot(t, r)
+
+	var snapshot bytes.Buffer
+	if err := r.WriteJSON(&snapshot); err != nil {
+		t.Fatalf("failed to write snapshot: %q", err)
+	}
+
+	loaded, err := LoadJSON(bytes.NewReader(snapshot.Bytes()))
+	if err != nil {
+		t.Fatalf("failed to load snapshot: %q", err)
+	}
+
+	var reloaded bytes.Buffer
+	if err := loaded.WriteJSON(&reloaded); err != nil {
+		t.Fatalf("failed to write snapshot: %q", err)
+	}
+
+	if snapshot.String() != reloaded.String() {
+		t.Errorf("want snapshot\n%s\ngot\n%s", snapshot.String(), reloaded.String())
+	}
+
+	s, err := loaded.SubmissionGetByPostID(ctx, "p1")
+	if err != nil {
+		t.Fatalf("expected no error, got %q", err)
+	}
+
+	if s.Subreddit.Name != "EarthPorn" || s.Rating != submission.RatingFavorite || len(s.Tags) != 1 {
+		t.Errorf("want submission p1 loaded with its subreddit, rating and tags, got %+v", s)
+	}
+
+	banned, err := loaded.BanIsImageSHA256Registered(ctx, "abc123")
+	if err != nil || !banned {
+		t.Errorf("want image checksum to be banned, got %t (%v)", banned, err)
+	}
+
+	entry, err := loaded.HistoryGetCurrent(ctx)
+	if err != nil || entry.Submission.PostID != "p2" {
+		t.Errorf("want current entry for p2, got %+v (%v)", entry, err)
+	}
+
+	c, err := loaded.CollectionGetByName(ctx, "favorites")
+	if err != nil || c.Cursor != 1 {
+		t.Errorf("want collection with cursor 1, got %+v (%v)", c, err)
+	}
+
+	// The selection of p2 is restored along with the History
+	candidates, err := loaded.SubmissionGetRandomCandidates(ctx, &monitor.Resolution{}, &submission.Filter{NeverShown: true}, submission.RepeatPolicy{})
+	if err != nil {
+		t.Fatalf("expected no error, got %q", err)
+	}
+
+	if len(candidates) != 1 || candidates[0].PostID != "p1" {
+		t.Errorf("want p1 as the only never shown candidate, got %d candidates", len(candidates))
+	}
+
+	// IDs keep increasing after loading
+	if err := loaded.SubredditCreate(ctx, &submission.Subreddit{Name: "wallpapers"}); err != nil {
+		t.Fatalf("expected no error, got %q", err)
+	}
+
+	subreddit, err := loaded.SubredditGetByName(ctx, "wallpapers")
+	if err != nil || subreddit.ID != 2 {
+		t.Errorf("want subreddit ID 2, got %+v (%v)", subreddit, err)
+	}
+}
+
+func TestLoadJSON(t *testing.T) {
+	testCases := []struct {
+		tname   string
+		input   string
+		wantErr error
+	}{
+		// nominal cases
+		{
+			tname: "empty snapshot",
+			input: `{"version": 1}`,
+		},
+
+		// error cases
+		{
+			tname:   "unsupported version",
+			input:   `{"version": 2}`,
+			wantErr: ErrSnapshotUnsupportedVersion,
+		},
+		{
+			tname:   "invalid subreddit ID",
+			input:   `{"version": 1, "subreddits": [{"id": 0, "name": "EarthPorn"}]}`,
+			wantErr: ErrSnapshotInvalidID,
+		},
+		{
+			tname:   "duplicate subreddit ID",
+			input:   `{"version": 1, "subreddits": [{"id": 1, "name": "EarthPorn"}, {"id": 1, "name": "wallpapers"}]}`,
+			wantErr: ErrSnapshotDuplicateID,
+		},
+		{
+			tname:   "duplicate subreddit name",
+			input:   `{"version": 1, "subreddits": [{"id": 1, "name": "EarthPorn"}, {"id": 2, "name": "EarthPorn"}]}`,
+			wantErr: submission.ErrSubredditNameAlreadyRegistered,
+		},
+		{
+			tname:   "unknown subreddit",
+			input:   `{"version": 1, "submissions": [{"id": 1, "subreddit_id": 1, "post_id": "p1"}]}`,
+			wantErr: ErrSnapshotInvalidReference,
+		},
+		{
+			tname:   "duplicate post ID",
+			input:   `{"version": 1, "subreddits": [{"id": 1, "name": "EarthPorn"}], "submissions": [{"id": 1, "subreddit_id": 1, "post_id": "p1"}, {"id": 2, "subreddit_id": 1, "post_id": "p1"}]}`,
+			wantErr: submission.ErrSubmissionPostIDAlreadyRegistered,
+		},
+		{
+			tname:   "duplicate ban",
+			input:   `{"version": 1, "bans": [{"post_id": "p1"}, {"post_id": "p1"}]}`,
+			wantErr: submission.ErrBanPostIDAlreadyRegistered,
+		},
+		{
+			tname:   "unknown history submission",
+			input:   `{"version": 1, "history": [{"id": 1, "submission_id": 1}]}`,
+			wantErr: ErrSnapshotInvalidReference,
+		},
+		{
+			tname:   "duplicate collection name",
+			input:   `{"version": 1, "collections": [{"id": 1, "name": "favorites"}, {"id": 2, "name": "favorites"}]}`,
+			wantErr: collection.ErrCollectionNameAlreadyRegistered,
+		},
+		{
+			tname:   "unknown collection item submission",
+			input:   `{"version": 1, "collections": [{"id": 1, "name": "favorites", "items": [{"position": 1, "submission_id": 1}]}]}`,
+			wantErr: ErrSnapshotInvalidReference,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			_, err := LoadJSON(strings.NewReader(tc.input))
+
+			if tc.wantErr != nil {
+				if err == nil {
+					t.Error("expected an error but got none")
+				} else if !errors.Is(err, tc.wantErr) {
+					t.Errorf("want error %q, got %q", tc.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+		})
+	}
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := LoadJSON(strings.NewReader(`{"version": 1, "unknown": true}`))
+		if err == nil {
+			t.Error("expected an error but got none")
+		}
+	})
+}
+
+func TestRepositoryConcurrentUse(t *testing.T) {
+	ctx := t.Context()
+	r := NewRepository()
+	seedSnapshot(t, r)
+
+	var wg sync.WaitGroup
+
+	for index := range 8 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			entry := &history.Entry{Date: time.Now().UTC(), Submission: &submission.Submission{ID: 1 + index%2}}
+			if err := r.HistoryCreate(ctx, entry); err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+
+			if err := r.SubmissionAddTags(ctx, 1, []string{"tag"}); err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+
+			if _, err := r.SubmissionGetByFilter(ctx, &submission.Filter{}, &submission.ListOptions{}); err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+
+			if err := r.WriteJSON(&bytes.Buffer{}); err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	entries, err := r.HistoryGetAll(ctx, &history.ListOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %q", err)
+	}
+
+	if len(entries) != 9 {
+		t.Errorf("want 9 entries, got %d", len(entries))
+	}
+}
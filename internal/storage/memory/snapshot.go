@@ -0,0 +1,424 @@
+package memory
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/virtualtam/walric/pkg/collection"
+	"github.com/virtualtam/walric/pkg/history"
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+// snapshotVersion is the version of the JSON snapshot format.
+const snapshotVersion = 1
+
+// jsonSnapshot represents the state of a Repository, as written to and read
+// from JSON.
+type jsonSnapshot struct {
+	Version int `json:"version"`
+
+	Subreddits  []jsonSubreddit  `json:"subreddits"`
+	Submissions []jsonSubmission `json:"submissions"`
+	Bans        []jsonBan        `json:"bans"`
+	History     []jsonEntry      `json:"history"`
+	Collections []jsonCollection `json:"collections"`
+}
+
+type jsonSubreddit struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type jsonSubmission struct {
+	ID int `json:"id"`
+
+	SubredditID int `json:"subreddit_id"`
+
+	// Reddit post metadata
+	Author    string    `json:"author"`
+	Permalink string    `json:"permalink"`
+	PostID    string    `json:"post_id"`
+	PostedAt  time.Time `json:"posted_at"`
+	Score     int       `json:"score"`
+	Title     string    `json:"title"`
+
+	// Reddit post status
+	Removed         bool      `json:"removed,omitempty"`
+	LastRefreshedAt time.Time `json:"last_refreshed_at,omitzero"`
+
+	// Attached image metadata
+	ImageDomain      string `json:"image_domain"`
+	ImageURL         string `json:"image_url"`
+	ImageNSFW        bool   `json:"image_nsfw,omitempty"`
+	ImageUnavailable bool   `json:"image_unavailable,omitempty"`
+
+	// Local image metadata
+	ImageFilename string `json:"image_filename"`
+	ImageHeightPx int    `json:"image_height_px"`
+	ImageWidthPx  int    `json:"image_width_px"`
+
+	Rating int      `json:"rating,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+}
+
+func newJSONSubmission(s *submission.Submission) jsonSubmission {
+	return jsonSubmission{
+		ID:               s.ID,
+		SubredditID:      s.Subreddit.ID,
+		Author:           s.Author,
+		Permalink:        s.Permalink,
+		PostID:           s.PostID,
+		PostedAt:         s.PostedAt,
+		Score:            s.Score,
+		Title:            s.Title,
+		Removed:          s.Removed,
+		LastRefreshedAt:  s.LastRefreshedAt,
+		ImageDomain:      s.ImageDomain,
+		ImageURL:         s.ImageURL,
+		ImageNSFW:        s.ImageNSFW,
+		ImageUnavailable: s.ImageUnavailable,
+		ImageFilename:    s.ImageFilename,
+		ImageHeightPx:    s.ImageHeightPx,
+		ImageWidthPx:     s.ImageWidthPx,
+		Rating:           s.Rating,
+		Tags:             s.Tags,
+	}
+}
+
+// AsSubmission returns a Submission referencing a Subreddit by ID.
+func (js *jsonSubmission) AsSubmission() *submission.Submission {
+	tags := slices.Clone(js.Tags)
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return &submission.Submission{
+		ID:               js.ID,
+		Subreddit:        &submission.Subreddit{ID: js.SubredditID},
+		Author:           js.Author,
+		Permalink:        js.Permalink,
+		PostID:           js.PostID,
+		PostedAt:         js.PostedAt,
+		Score:            js.Score,
+		Title:            js.Title,
+		Removed:          js.Removed,
+		LastRefreshedAt:  js.LastRefreshedAt,
+		ImageDomain:      js.ImageDomain,
+		ImageURL:         js.ImageURL,
+		ImageNSFW:        js.ImageNSFW,
+		ImageUnavailable: js.ImageUnavailable,
+		ImageFilename:    js.ImageFilename,
+		ImageHeightPx:    js.ImageHeightPx,
+		ImageWidthPx:     js.ImageWidthPx,
+		Rating:           js.Rating,
+		Tags:             tags,
+	}
+}
+
+// jsonBan represents a Ban; its ID is not preserved, as Bans are only ever
+// looked up by post ID or image checksum.
+type jsonBan struct {
+	Date        time.Time `json:"date"`
+	PostID      string    `json:"post_id"`
+	ImageSHA256 string    `json:"image_sha256,omitempty"`
+}
+
+type jsonEntry struct {
+	ID           int       `json:"id"`
+	Date         time.Time `json:"date"`
+	SubmissionID int       `json:"submission_id"`
+}
+
+type jsonCollection struct {
+	ID        int                  `json:"id"`
+	Name      string               `json:"name"`
+	CreatedAt time.Time            `json:"created_at"`
+	Cursor    int                  `json:"cursor,omitempty"`
+	Items     []jsonCollectionItem `json:"items"`
+}
+
+type jsonCollectionItem struct {
+	Position     int `json:"position"`
+	SubmissionID int `json:"submission_id"`
+}
+
+// LoadJSONFile initializes and returns a Repository from a JSON snapshot file.
+func LoadJSONFile(path string) (*Repository, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	r, err := LoadJSON(file)
+	if err != nil {
+		return nil, fmt.Errorf("%w (file: %q)", err, path)
+	}
+
+	return r, nil
+}
+
+// LoadJSON initializes and returns a Repository from a JSON snapshot, as
+// written by WriteJSON.
+func LoadJSON(reader io.Reader) (*Repository, error) {
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+
+	var snapshot jsonSnapshot
+
+	if err := decoder.Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("memory: failed to decode snapshot: %w", err)
+	}
+
+	if snapshot.Version != snapshotVersion {
+		return nil, fmt.Errorf("%w: %d", ErrSnapshotUnsupportedVersion, snapshot.Version)
+	}
+
+	d := &data{
+		entryCurrentID:      1,
+		entries:             []*history.Entry{},
+		collectionCurrentID: 1,
+		collections:         []*collection.Collection{},
+		items:               map[int][]*collection.Item{},
+	}
+
+	subreddits, err := loadSubreddits(snapshot.Subreddits)
+	if err != nil {
+		return nil, err
+	}
+
+	submissions, err := loadSubmissions(snapshot.Submissions, subreddits)
+	if err != nil {
+		return nil, err
+	}
+
+	d.submissions = submission.NewRepositoryInMemory(submissions, subreddits)
+
+	for _, jb := range snapshot.Bans {
+		ban := &submission.Ban{
+			Date:        jb.Date,
+			PostID:      jb.PostID,
+			ImageSHA256: jb.ImageSHA256,
+		}
+
+		if err := d.submissions.BanCreate(context.Background(), ban); err != nil {
+			return nil, fmt.Errorf("%w (ban: %q)", err, jb.PostID)
+		}
+	}
+
+	if err := d.loadHistory(snapshot.History, submissions); err != nil {
+		return nil, err
+	}
+
+	if err := d.loadCollections(snapshot.Collections, submissions); err != nil {
+		return nil, err
+	}
+
+	return newRepository(d), nil
+}
+
+// loadSubreddits returns the Subreddits from a snapshot, after checking their
+// IDs and names are unique.
+func loadSubreddits(jsonSubreddits []jsonSubreddit) ([]*submission.Subreddit, error) {
+	subreddits := make([]*submission.Subreddit, len(jsonSubreddits))
+
+	for index, js := range jsonSubreddits {
+		if err := checkID(js.ID, "subreddit", subreddits[:index], func(s *submission.Subreddit) int { return s.ID }); err != nil {
+			return nil, err
+		}
+
+		if slices.ContainsFunc(subreddits[:index], func(s *submission.Subreddit) bool { return s.Name == js.Name }) {
+			return nil, fmt.Errorf("%w (subreddit: %q)", submission.ErrSubredditNameAlreadyRegistered, js.Name)
+		}
+
+		subreddits[index] = &submission.Subreddit{ID: js.ID, Name: js.Name}
+	}
+
+	return subreddits, nil
+}
+
+// loadSubmissions returns the Submissions from a snapshot, after checking their
+// IDs and post IDs are unique, and that they reference existing Subreddits.
+func loadSubmissions(jsonSubmissions []jsonSubmission, subreddits []*submission.Subreddit) ([]*submission.Submission, error) {
+	submissions := make([]*submission.Submission, len(jsonSubmissions))
+
+	for index, js := range jsonSubmissions {
+		if err := checkID(js.ID, "submission", submissions[:index], func(s *submission.Submission) int { return s.ID }); err != nil {
+			return nil, err
+		}
+
+		if slices.ContainsFunc(submissions[:index], func(s *submission.Submission) bool { return s.PostID == js.PostID }) {
+			return nil, fmt.Errorf("%w (submission: %q)", submission.ErrSubmissionPostIDAlreadyRegistered, js.PostID)
+		}
+
+		if !slices.ContainsFunc(subreddits, func(s *submission.Subreddit) bool { return s.ID == js.SubredditID }) {
+			return nil, fmt.Errorf("%w: unknown subreddit %d (submission: %q)", ErrSnapshotInvalidReference, js.SubredditID, js.PostID)
+		}
+
+		submissions[index] = js.AsSubmission()
+	}
+
+	return submissions, nil
+}
+
+// loadHistory loads the History from a snapshot, after checking its Entries
+// have unique IDs and reference existing Submissions.
+func (d *data) loadHistory(jsonEntries []jsonEntry, submissions []*submission.Submission) error {
+	for _, je := range jsonEntries {
+		if err := checkID(je.ID, "history entry", d.entries, func(e *history.Entry) int { return e.ID }); err != nil {
+			return err
+		}
+
+		if !isSubmissionID(submissions, je.SubmissionID) {
+			return fmt.Errorf("%w: unknown submission %d (history entry: %d)", ErrSnapshotInvalidReference, je.SubmissionID, je.ID)
+		}
+
+		d.addEntry(je.ID, je.Date, je.SubmissionID)
+		d.entryCurrentID = max(d.entryCurrentID, je.ID+1)
+	}
+
+	return nil
+}
+
+// loadCollections loads the Collections from a snapshot, after checking they
+// have unique IDs and names, and that their Items reference existing
+// Submissions.
+func (d *data) loadCollections(jsonCollections []jsonCollection, submissions []*submission.Submission) error {
+	for _, jc := range jsonCollections {
+		if err := checkID(jc.ID, "collection", d.collections, func(c *collection.Collection) int { return c.ID }); err != nil {
+			return err
+		}
+
+		if slices.ContainsFunc(d.collections, func(c *collection.Collection) bool { return c.Name == jc.Name }) {
+			return fmt.Errorf("%w (collection: %q)", collection.ErrCollectionNameAlreadyRegistered, jc.Name)
+		}
+
+		items := make([]*collection.Item, len(jc.Items))
+
+		for index, ji := range jc.Items {
+			if !isSubmissionID(submissions, ji.SubmissionID) {
+				return fmt.Errorf("%w: unknown submission %d (collection: %q)", ErrSnapshotInvalidReference, ji.SubmissionID, jc.Name)
+			}
+
+			if slices.ContainsFunc(items[:index], func(item *collection.Item) bool { return item.Submission.ID == ji.SubmissionID }) {
+				return fmt.Errorf("%w (collection: %q, submission: %d)", collection.ErrItemAlreadyRegistered, jc.Name, ji.SubmissionID)
+			}
+
+			items[index] = &collection.Item{
+				Position:   ji.Position,
+				Submission: &submission.Submission{ID: ji.SubmissionID},
+			}
+		}
+
+		slices.SortStableFunc(items, func(a, b *collection.Item) int {
+			return cmp.Compare(a.Position, b.Position)
+		})
+
+		d.collections = append(d.collections, &collection.Collection{
+			ID:        jc.ID,
+			Name:      jc.Name,
+			CreatedAt: jc.CreatedAt,
+			Cursor:    jc.Cursor,
+		})
+		d.items[jc.ID] = items
+		d.collectionCurrentID = max(d.collectionCurrentID, jc.ID+1)
+	}
+
+	return nil
+}
+
+// checkID ensures an ID is positive, and unique among previously loaded
+// values.
+func checkID[T any](id int, kind string, loaded []T, idFn func(T) int) error {
+	if id < 1 {
+		return fmt.Errorf("%w: %d (%s)", ErrSnapshotInvalidID, id, kind)
+	}
+
+	if slices.ContainsFunc(loaded, func(value T) bool { return idFn(value) == id }) {
+		return fmt.Errorf("%w: %d (%s)", ErrSnapshotDuplicateID, id, kind)
+	}
+
+	return nil
+}
+
+// isSubmissionID returns whether a Submission exists for a given ID.
+func isSubmissionID(submissions []*submission.Submission, id int) bool {
+	return slices.ContainsFunc(submissions, func(s *submission.Submission) bool {
+		return s.ID == id
+	})
+}
+
+// WriteJSON writes a JSON snapshot of the Repository, which can be loaded with
+// LoadJSON.
+func (r *Repository) WriteJSON(writer io.Writer) error {
+	ctx := context.Background()
+
+	defer r.rlock()()
+
+	snapshot := jsonSnapshot{
+		Version:     snapshotVersion,
+		Subreddits:  []jsonSubreddit{},
+		Submissions: []jsonSubmission{},
+		Bans:        []jsonBan{},
+		History:     []jsonEntry{},
+		Collections: []jsonCollection{},
+	}
+
+	subreddits, err := r.data.submissions.SubredditGetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, subreddit := range subreddits {
+		snapshot.Subreddits = append(snapshot.Subreddits, jsonSubreddit{ID: subreddit.ID, Name: subreddit.Name})
+	}
+
+	submissions, err := r.data.submissions.SubmissionGetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range submissions {
+		snapshot.Submissions = append(snapshot.Submissions, newJSONSubmission(s))
+	}
+
+	bans, err := r.data.submissions.BanGetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, ban := range bans {
+		snapshot.Bans = append(snapshot.Bans, jsonBan{Date: ban.Date, PostID: ban.PostID, ImageSHA256: ban.ImageSHA256})
+	}
+
+	for _, entry := range r.data.entries {
+		snapshot.History = append(snapshot.History, jsonEntry{ID: entry.ID, Date: entry.Date, SubmissionID: entry.Submission.ID})
+	}
+
+	for _, c := range r.data.collections {
+		jc := jsonCollection{
+			ID:        c.ID,
+			Name:      c.Name,
+			CreatedAt: c.CreatedAt,
+			Cursor:    c.Cursor,
+			Items:     []jsonCollectionItem{},
+		}
+
+		for _, item := range r.data.items[c.ID] {
+			jc.Items = append(jc.Items, jsonCollectionItem{Position: item.Position, SubmissionID: item.Submission.ID})
+		}
+
+		snapshot.Collections = append(snapshot.Collections, jc)
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(snapshot)
+}
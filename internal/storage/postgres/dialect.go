@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/virtualtam/walric/internal/storage/sqlstore"
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+var _ sqlstore.Dialect = dialect{}
+
+// dialect provides the PostgreSQL specific parts of queries, using the
+// search_vector column of the submissions table for full-text search.
+type dialect struct{}
+
+// Rebind converts question mark placeholders to PostgreSQL's numbered
+// placeholders.
+func (dialect) Rebind(query string) string {
+	return sqlx.Rebind(sqlx.DOLLAR, query)
+}
+
+// NoLimit returns nil, as a NULL limit selects all the rows following the
+// offset.
+func (dialect) NoLimit() any {
+	return nil
+}
+
+// SingleWriter returns false, as PostgreSQL supports concurrent writers.
+func (dialect) SingleWriter() bool {
+	return false
+}
+
+func (dialect) ConstraintViolation(err error) sqlstore.Constraint {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return sqlstore.ConstraintNone
+	}
+
+	switch pqErr.Code {
+	case uniqueViolation:
+		return sqlstore.ConstraintUnique
+	case foreignKeyViolation:
+		return sqlstore.ConstraintForeignKey
+	}
+
+	return sqlstore.ConstraintNone
+}
+
+func (dialect) TextCondition(terms []submission.SearchTerm) (string, []any) {
+	query, params := tsQueryExpression(terms)
+
+	return "sm.search_vector @@ " + query, params
+}
+
+func (dialect) TextSearch(terms []submission.SearchTerm) *sqlstore.TextSearch {
+	tsQuery, tsQueryParams := tsQueryExpression(terms)
+
+	return &sqlstore.TextSearch{
+		Snippet: `ts_headline(
+    ` + textSearchConfig + `,
+    CONCAT_WS(' ', sm.title, sr.name, sm.author),
+    q.query,
+    ?
+  )`,
+		SnippetParams: []any{tsHeadlineOptions()},
+		From: `FROM submissions sm
+CROSS JOIN (SELECT ` + tsQuery + ` AS query) q`,
+		FromParams: tsQueryParams,
+		Condition:  "sm.search_vector @@ q.query",
+		Rank:       "ts_rank(" + searchRankWeights + ", sm.search_vector, q.query) DESC",
+	}
+}
@@ -0,0 +1,10 @@
+package postgres
+
+import (
+	"github.com/lib/pq"
+)
+
+const (
+	foreignKeyViolation pq.ErrorCode = "23503"
+	uniqueViolation     pq.ErrorCode = "23505"
+)
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS collection_items;
+DROP TABLE IF EXISTS collections;
+DROP TABLE IF EXISTS submission_tags;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS ratings;
+DROP TABLE IF EXISTS bans;
+DROP TABLE IF EXISTS history;
+DROP TABLE IF EXISTS submissions;
+DROP TABLE IF EXISTS subreddits;
@@ -0,0 +1,112 @@
+CREATE TABLE IF NOT EXISTS subreddits (
+    id   SERIAL  NOT NULL,
+    name VARCHAR NOT NULL,
+
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_subreddits_name ON subreddits (name);
+
+CREATE TABLE IF NOT EXISTS submissions (
+    id                SERIAL           NOT NULL,
+    subreddit_id      INTEGER          NOT NULL,
+    post_id           VARCHAR          NOT NULL,
+    author            VARCHAR          NOT NULL DEFAULT '',
+    created_utc       TIMESTAMPTZ      NOT NULL,
+    domain            VARCHAR          NOT NULL DEFAULT '',
+    over_18           BOOLEAN          NOT NULL DEFAULT FALSE,
+    permalink         VARCHAR          NOT NULL DEFAULT '',
+    score             INTEGER          NOT NULL DEFAULT 0,
+    title             VARCHAR          NOT NULL DEFAULT '',
+    url               VARCHAR          NOT NULL DEFAULT '',
+    image_filename    VARCHAR          NOT NULL DEFAULT '',
+    image_height_px   INTEGER          NOT NULL DEFAULT 0,
+    image_width_px    INTEGER          NOT NULL DEFAULT 0,
+    aspect_ratio      DOUBLE PRECISION NOT NULL DEFAULT 0,
+    unavailable       BOOLEAN          NOT NULL DEFAULT FALSE,
+    removed           BOOLEAN          NOT NULL DEFAULT FALSE,
+    last_refreshed_at TIMESTAMPTZ,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY(subreddit_id) REFERENCES subreddits (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_submissions_post_id ON submissions (post_id);
+CREATE INDEX IF NOT EXISTS idx_submissions_subreddit_id ON submissions (subreddit_id);
+CREATE INDEX IF NOT EXISTS idx_submissions_aspect_ratio ON submissions (aspect_ratio);
+
+CREATE TABLE IF NOT EXISTS history (
+    id            SERIAL      NOT NULL,
+    submission_id INTEGER     NOT NULL,
+    date          TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (id),
+    FOREIGN KEY(submission_id) REFERENCES submissions (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_history_submission_id ON history (submission_id);
+CREATE INDEX IF NOT EXISTS idx_history_date ON history (date);
+
+CREATE TABLE IF NOT EXISTS bans (
+    id            SERIAL      NOT NULL,
+    post_id       VARCHAR     NOT NULL,
+    image_sha256  VARCHAR     NOT NULL DEFAULT '',
+    date          TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bans_post_id ON bans (post_id);
+CREATE INDEX IF NOT EXISTS idx_bans_image_sha256 ON bans (image_sha256);
+
+CREATE TABLE IF NOT EXISTS ratings (
+    submission_id INTEGER     NOT NULL,
+    rating        INTEGER     NOT NULL CHECK (rating BETWEEN -1 AND 5),
+    date          TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (submission_id),
+    FOREIGN KEY(submission_id) REFERENCES submissions (id)
+);
+
+CREATE TABLE IF NOT EXISTS tags (
+    id   SERIAL  NOT NULL,
+    name VARCHAR NOT NULL,
+
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_name ON tags (name);
+
+CREATE TABLE IF NOT EXISTS submission_tags (
+    submission_id INTEGER NOT NULL,
+    tag_id        INTEGER NOT NULL,
+
+    PRIMARY KEY (submission_id, tag_id),
+    FOREIGN KEY(submission_id) REFERENCES submissions (id),
+    FOREIGN KEY(tag_id) REFERENCES tags (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_submission_tags_tag_id ON submission_tags (tag_id);
+
+CREATE TABLE IF NOT EXISTS collections (
+    id         SERIAL      NOT NULL,
+    name       VARCHAR     NOT NULL,
+    cursor     INTEGER     NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_collections_name ON collections (name);
+
+CREATE TABLE IF NOT EXISTS collection_items (
+    collection_id INTEGER NOT NULL,
+    submission_id INTEGER NOT NULL,
+    position      INTEGER NOT NULL,
+
+    PRIMARY KEY (collection_id, submission_id),
+    FOREIGN KEY(collection_id) REFERENCES collections (id),
+    FOREIGN KEY(submission_id) REFERENCES submissions (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_collection_items_submission_id ON collection_items (submission_id);
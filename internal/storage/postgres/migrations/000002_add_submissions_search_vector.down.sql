@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS submission_tags_search_vector_after_write ON submission_tags;
+DROP TRIGGER IF EXISTS subreddits_search_vector_after_update ON subreddits;
+DROP TRIGGER IF EXISTS submissions_search_vector_before_write ON submissions;
+DROP FUNCTION IF EXISTS submission_tags_search_vector_after_write();
+DROP FUNCTION IF EXISTS subreddits_search_vector_after_update();
+DROP FUNCTION IF EXISTS submissions_search_vector_before_write();
+DROP FUNCTION IF EXISTS submission_search_vector(submissions);
+DROP INDEX IF EXISTS idx_submissions_search_vector;
+ALTER TABLE submissions DROP COLUMN IF EXISTS search_vector;
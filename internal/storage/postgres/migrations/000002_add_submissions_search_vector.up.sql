@@ -0,0 +1,82 @@
+ALTER TABLE submissions ADD COLUMN IF NOT EXISTS search_vector TSVECTOR NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_submissions_search_vector ON submissions USING GIN (search_vector);
+
+-- Title, tags, subreddit and author are respectively weighted A, B, C and D,
+-- to rank search results.
+CREATE OR REPLACE FUNCTION submission_search_vector(sm submissions) RETURNS TSVECTOR
+LANGUAGE sql STABLE
+AS $$
+SELECT
+    setweight(to_tsvector('english', sm.title), 'A') ||
+    setweight(
+        to_tsvector(
+            'english',
+            COALESCE(
+                (
+                    SELECT STRING_AGG(t.name, ' ')
+                    FROM submission_tags st
+                    JOIN tags t ON st.tag_id=t.id
+                    WHERE st.submission_id=sm.id
+                ),
+                ''
+            )
+        ),
+        'B'
+    ) ||
+    setweight(to_tsvector('english', COALESCE((SELECT name FROM subreddits WHERE id=sm.subreddit_id), '')), 'C') ||
+    setweight(to_tsvector('english', sm.author), 'D')
+$$;
+
+CREATE OR REPLACE FUNCTION submissions_search_vector_before_write() RETURNS TRIGGER
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    NEW.search_vector := submission_search_vector(NEW);
+    RETURN NEW;
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION subreddits_search_vector_after_update() RETURNS TRIGGER
+LANGUAGE plpgsql
+AS $$
+BEGIN
+    UPDATE submissions sm
+    SET search_vector=submission_search_vector(sm)
+    WHERE sm.subreddit_id=NEW.id;
+    RETURN NULL;
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION submission_tags_search_vector_after_write() RETURNS TRIGGER
+LANGUAGE plpgsql
+AS $$
+DECLARE
+    target_id INTEGER;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        target_id := OLD.submission_id;
+    ELSE
+        target_id := NEW.submission_id;
+    END IF;
+
+    UPDATE submissions sm
+    SET search_vector=submission_search_vector(sm)
+    WHERE sm.id=target_id;
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER submissions_search_vector_before_write
+BEFORE INSERT OR UPDATE OF title, author, subreddit_id ON submissions
+FOR EACH ROW EXECUTE FUNCTION submissions_search_vector_before_write();
+
+CREATE TRIGGER subreddits_search_vector_after_update
+AFTER UPDATE OF name ON subreddits
+FOR EACH ROW EXECUTE FUNCTION subreddits_search_vector_after_update();
+
+CREATE TRIGGER submission_tags_search_vector_after_write
+AFTER INSERT OR DELETE ON submission_tags
+FOR EACH ROW EXECUTE FUNCTION submission_tags_search_vector_after_write();
+
+UPDATE submissions sm SET search_vector=submission_search_vector(sm);
@@ -0,0 +1,17 @@
+package postgres
+
+import (
+	"github.com/jmoiron/sqlx"
+
+	"github.com/virtualtam/walric/internal/storage/sqlstore"
+)
+
+// Repository provides a PostgreSQL database persistence layer for
+// Submissions, Subreddits, Collections and the History.
+type Repository = sqlstore.Repository
+
+// NewRepository initializes and returns a PostgreSQL repository to persist
+// and manage Submissions.
+func NewRepository(db *sqlx.DB) *Repository {
+	return sqlstore.NewRepository(db, dialect{})
+}
@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+const (
+	// textSearchConfig is the PostgreSQL text search configuration used to
+	// index and query Submissions.
+	textSearchConfig = "'english'"
+
+	// searchRankWeights holds the relevance of the author (D), subreddit (C),
+	// tags (B) and title (A) weights of the search_vector column, used to
+	// rank search results.
+	searchRankWeights = "'{0.1, 0.2, 0.5, 1.0}'"
+
+	// searchSnippetMaxWords is the maximum number of words in a search result
+	// Snippet.
+	searchSnippetMaxWords = 16
+)
+
+// tsQueryExpression returns the SQL expression and query parameters of the
+// text search query matching all the given submission.SearchTerm.
+//
+// Phrases are parsed by phraseto_tsquery, so that operators and special
+// characters are matched literally; the last word of a prefix term is quoted
+// as a tsquery lexeme, stripped of punctuation.
+func tsQueryExpression(terms []submission.SearchTerm) (string, []any) {
+	expressions := make([]string, len(terms))
+	params := []any{}
+
+	for index, term := range terms {
+		if !term.Prefix {
+			expressions[index] = "phraseto_tsquery(" + textSearchConfig + ", ?)"
+			params = append(params, term.Text)
+			continue
+		}
+
+		words := strings.FieldsFunc(term.Text, isSeparator)
+		if len(words) == 0 {
+			expressions[index] = "phraseto_tsquery(" + textSearchConfig + ", '')"
+			continue
+		}
+
+		prefixExpression := "to_tsquery(" + textSearchConfig + ", ?)"
+		prefixParam := tsQueryPrefix(words[len(words)-1])
+
+		if len(words) == 1 {
+			expressions[index] = prefixExpression
+			params = append(params, prefixParam)
+			continue
+		}
+
+		expressions[index] = "tsquery_phrase(phraseto_tsquery(" + textSearchConfig + ", ?), " + prefixExpression + ")"
+		params = append(params, strings.Join(words[:len(words)-1], " "), prefixParam)
+	}
+
+	return "(" + strings.Join(expressions, " && ") + ")", params
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// tsQueryPrefix returns a tsquery matching lexemes starting with word.
+func tsQueryPrefix(word string) string {
+	quoted := strings.NewReplacer(`\`, `\\`, `'`, `''`).Replace(word)
+
+	return "'" + quoted + "':*"
+}
+
+// tsHeadlineOptions returns the ts_headline options enclosing matching terms
+// between submission.HighlightStart and submission.HighlightEnd.
+func tsHeadlineOptions() string {
+	return `StartSel="` + submission.HighlightStart +
+		`", StopSel="` + submission.HighlightEnd +
+		`", MaxWords=` + strconv.Itoa(searchSnippetMaxWords) +
+		`, MinWords=` + strconv.Itoa(searchSnippetMaxWords/2) +
+		`, ShortWord=0`
+}
@@ -0,0 +1,35 @@
+package sqlite3
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// DataSourceName returns the data source name to open the SQLite3 database
+// located at path, with the connection settings required for concurrent use:
+//
+//   - write-ahead logging (WAL), so that readers do not block the writer;
+//   - a busy timeout, so that statements wait for locks to be released
+//     instead of failing with "database is locked";
+//   - immediate transactions, acquiring the write lock when they begin rather
+//     than failing to upgrade a read lock;
+//   - foreign key constraints enforcement.
+func DataSourceName(path string) string {
+	params := url.Values{}
+	params.Set("_busy_timeout", "5000")
+	params.Set("_foreign_keys", "on")
+	params.Set("_journal_mode", "WAL")
+	params.Set("_txlock", "immediate")
+
+	return "file:" + path + "?" + params.Encode()
+}
+
+// Backup writes a consistent copy of the database to a new file located at
+// path, while other connections may still be reading from or writing to it.
+func Backup(ctx context.Context, db *sqlx.DB, path string) error {
+	_, err := db.ExecContext(ctx, "VACUUM INTO ?", path)
+
+	return err
+}
@@ -0,0 +1,118 @@
+package sqlite3
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	gosqlite3 "github.com/mattn/go-sqlite3"
+)
+
+func TestBackup(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+
+	db, err := sqlx.Open("sqlite3", DataSourceName(filepath.Join(dir, "walric.db")))
+	if err != nil {
+		t.Fatalf("failed to open database: %q", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	if _, err := db.ExecContext(ctx, "CREATE TABLE subreddits(id INTEGER PRIMARY KEY, name TEXT)"); err != nil {
+		t.Fatalf("failed to create table: %q", err)
+	}
+
+	if _, err := db.ExecContext(ctx, "INSERT INTO subreddits(name) VALUES('EarthPorn')"); err != nil {
+		t.Fatalf("failed to insert row: %q", err)
+	}
+
+	backupPath := filepath.Join(dir, "walric.db.bak")
+
+	if err := Backup(ctx, db, backupPath); err != nil {
+		t.Fatalf("expected no error, got %q", err)
+	}
+
+	t.Run("existing file", func(t *testing.T) {
+		if err := Backup(ctx, db, backupPath); err == nil {
+			t.Error("expected an error but got none")
+		}
+	})
+
+	t.Run("backup contents", func(t *testing.T) {
+		backup, err := sqlx.Open("sqlite3", DataSourceName(backupPath))
+		if err != nil {
+			t.Fatalf("failed to open backup: %q", err)
+		}
+		t.Cleanup(func() { _ = backup.Close() })
+
+		var name string
+		if err := backup.GetContext(ctx, &name, "SELECT name FROM subreddits"); err != nil {
+			t.Fatalf("failed to query backup: %q", err)
+		}
+
+		if name != "EarthPorn" {
+			t.Errorf("want subreddit %q, got %q", "EarthPorn", name)
+		}
+	})
+}
+
+func TestDataSourceName(t *testing.T) {
+	ctx := t.Context()
+	dbPath := filepath.Join(t.TempDir(), "walric.db")
+
+	db, err := sqlx.Open("sqlite3", DataSourceName(dbPath))
+	if err != nil {
+		t.Fatalf("failed to open database: %q", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	testCases := []struct {
+		pragma string
+		want   string
+	}{
+		{pragma: "journal_mode", want: "wal"},
+		{pragma: "foreign_keys", want: "1"},
+		{pragma: "busy_timeout", want: "5000"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.pragma, func(t *testing.T) {
+			var got string
+			if err := db.GetContext(ctx, &got, "PRAGMA "+tc.pragma); err != nil {
+				t.Fatalf("failed to query pragma: %q", err)
+			}
+
+			if got != tc.want {
+				t.Errorf("want %s=%q, got %q", tc.pragma, tc.want, got)
+			}
+		})
+	}
+
+	t.Run("immediate transactions", func(t *testing.T) {
+		if _, err := db.ExecContext(ctx, "CREATE TABLE subreddits(id INTEGER PRIMARY KEY, name TEXT)"); err != nil {
+			t.Fatalf("failed to create table: %q", err)
+		}
+
+		tx, err := db.BeginTxx(ctx, nil)
+		if err != nil {
+			t.Fatalf("failed to begin transaction: %q", err)
+		}
+		t.Cleanup(func() { _ = tx.Rollback() })
+
+		// with WAL, a deferred transaction only acquires the write lock on its
+		// first write: writing from another connection would then succeed
+		other, err := sqlx.Open("sqlite3", "file:"+dbPath+"?_busy_timeout=10")
+		if err != nil {
+			t.Fatalf("failed to open database: %q", err)
+		}
+		t.Cleanup(func() { _ = other.Close() })
+
+		_, err = other.ExecContext(ctx, "INSERT INTO subreddits(name) VALUES('EarthPorn')")
+
+		var sqliteErr gosqlite3.Error
+		if !errors.As(err, &sqliteErr) || sqliteErr.Code != gosqlite3.ErrBusy {
+			t.Errorf("want the database to be locked by the transaction, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package sqlite3
+
+import (
+	"errors"
+
+	gosqlite3 "github.com/mattn/go-sqlite3"
+
+	"github.com/virtualtam/walric/internal/storage/sqlstore"
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+var _ sqlstore.Dialect = dialect{}
+
+// dialect provides the SQLite3 specific parts of queries, using the
+// submissions_fts FTS5 table for full-text search.
+type dialect struct{}
+
+// Rebind returns the query unchanged, as SQLite3 supports question mark
+// placeholders.
+func (dialect) Rebind(query string) string {
+	return query
+}
+
+// NoLimit returns -1, as SQLite3 requires an integer LIMIT.
+func (dialect) NoLimit() any {
+	return -1
+}
+
+// SingleWriter returns true, as SQLite3 supports a single writer and fails to
+// upgrade concurrent read transactions to write transactions.
+func (dialect) SingleWriter() bool {
+	return true
+}
+
+func (dialect) ConstraintViolation(err error) sqlstore.Constraint {
+	var sqliteErr gosqlite3.Error
+	if !errors.As(err, &sqliteErr) {
+		return sqlstore.ConstraintNone
+	}
+
+	switch sqliteErr.ExtendedCode {
+	case gosqlite3.ErrConstraintUnique, gosqlite3.ErrConstraintPrimaryKey:
+		return sqlstore.ConstraintUnique
+	case gosqlite3.ErrConstraintForeignKey:
+		return sqlstore.ConstraintForeignKey
+	}
+
+	return sqlstore.ConstraintNone
+}
+
+func (dialect) TextCondition(terms []submission.SearchTerm) (string, []any) {
+	return "sm.id IN (SELECT rowid FROM submissions_fts WHERE submissions_fts MATCH ?)",
+		[]any{ftsMatchQuery(terms)}
+}
+
+func (dialect) TextSearch(terms []submission.SearchTerm) *sqlstore.TextSearch {
+	return &sqlstore.TextSearch{
+		Snippet:       "snippet(submissions_fts, -1, ?, ?, '…', 16)",
+		SnippetParams: []any{submission.HighlightStart, submission.HighlightEnd},
+		From: `FROM submissions_fts
+JOIN submissions sm ON sm.id=submissions_fts.rowid`,
+		Condition:       "submissions_fts MATCH ?",
+		ConditionParams: []any{ftsMatchQuery(terms)},
+		Rank:            "bm25(submissions_fts, " + ftsColumnWeights + ")",
+	}
+}
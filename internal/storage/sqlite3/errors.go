@@ -0,0 +1,9 @@
+package sqlite3
+
+import (
+	"errors"
+)
+
+var (
+	ErrFTS5Unavailable error = errors.New("sqlite3: FTS5 full-text search is not available, build with the sqlite_fts5 tag")
+)
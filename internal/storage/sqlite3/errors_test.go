@@ -0,0 +1,88 @@
+//go:build sqlite_fts5
+
+package sqlite3
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/virtualtam/walric/pkg/history"
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+func TestConstraintError(t *testing.T) {
+	testCases := []struct {
+		tname   string
+		create  func(ctx context.Context, r *Repository) error
+		wantErr error
+	}{
+		// error cases
+		{
+			tname: "duplicate subreddit name",
+			create: func(ctx context.Context, r *Repository) error {
+				return r.SubredditCreate(ctx, &submission.Subreddit{Name: "EarthPorn"})
+			},
+			wantErr: submission.ErrSubredditNameAlreadyRegistered,
+		},
+		{
+			tname: "duplicate submission post ID",
+			create: func(ctx context.Context, r *Repository) error {
+				return r.SubmissionCreate(ctx, &submission.Submission{
+					Subreddit: &submission.Subreddit{ID: 1},
+					PostID:    "m31aga",
+					Title:     "Messier 31 - The Andromeda Galaxy",
+				})
+			},
+			wantErr: submission.ErrSubmissionPostIDAlreadyRegistered,
+		},
+		{
+			tname: "submission with an unknown subreddit",
+			create: func(ctx context.Context, r *Repository) error {
+				return r.SubmissionCreate(ctx, &submission.Submission{
+					Subreddit: &submission.Subreddit{ID: 404},
+					PostID:    "owlsrf",
+					Title:     "The Owl Nebula and Surfboard Galaxy",
+				})
+			},
+			wantErr: submission.ErrSubredditNotFound,
+		},
+		{
+			tname: "history entry with an unknown submission",
+			create: func(ctx context.Context, r *Repository) error {
+				return r.HistoryCreate(ctx, &history.Entry{
+					Date:       time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+					Submission: &submission.Submission{ID: 404},
+				})
+			},
+			wantErr: submission.ErrSubmissionNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			r := newTestRepository(t).(*Repository)
+
+			if err := r.SubredditCreate(t.Context(), &submission.Subreddit{Name: "EarthPorn"}); err != nil {
+				t.Fatalf("failed to create subreddit: %q", err)
+			}
+
+			if err := r.SubmissionCreate(t.Context(), &submission.Submission{
+				Subreddit: &submission.Subreddit{ID: 1},
+				PostID:    "m31aga",
+				Title:     "Messier 31 - The Andromeda Galaxy",
+			}); err != nil {
+				t.Fatalf("failed to create submission: %q", err)
+			}
+
+			err := tc.create(t.Context(), r)
+
+			if err == nil {
+				t.Error("expected an error but got none")
+			} else if !errors.Is(err, tc.wantErr) {
+				t.Errorf("want error %q, got %q", tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1 @@
+ALTER TABLE submissions ADD COLUMN unavailable BOOLEAN NOT NULL DEFAULT 0 CHECK (unavailable IN (0, 1));
@@ -0,0 +1,2 @@
+ALTER TABLE submissions DROP COLUMN last_refreshed_at;
+ALTER TABLE submissions DROP COLUMN removed;
@@ -0,0 +1,2 @@
+ALTER TABLE submissions ADD COLUMN removed BOOLEAN NOT NULL DEFAULT 0 CHECK (removed IN (0, 1));
+ALTER TABLE submissions ADD COLUMN last_refreshed_at DATETIME;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_bans_image_sha256;
+DROP INDEX IF EXISTS idx_bans_post_id;
+DROP TABLE IF EXISTS bans;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS bans (
+    id            INTEGER NOT NULL,
+    post_id       VARCHAR NOT NULL,
+    image_sha256  VARCHAR,
+    date          DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bans_post_id ON bans (post_id);
+CREATE INDEX IF NOT EXISTS idx_bans_image_sha256 ON bans (image_sha256);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS ratings (
+    submission_id INTEGER NOT NULL,
+    rating        INTEGER NOT NULL CHECK (rating BETWEEN -1 AND 5),
+    date          DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (submission_id),
+    FOREIGN KEY(submission_id) REFERENCES submissions (id)
+);
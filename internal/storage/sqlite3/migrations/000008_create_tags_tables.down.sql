@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_submission_tags_tag_id;
+DROP TABLE IF EXISTS submission_tags;
+DROP INDEX IF EXISTS idx_tags_name;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS tags (
+    id   INTEGER NOT NULL,
+    name VARCHAR NOT NULL,
+
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_name ON tags (name);
+
+CREATE TABLE IF NOT EXISTS submission_tags (
+    submission_id INTEGER NOT NULL,
+    tag_id        INTEGER NOT NULL,
+
+    PRIMARY KEY (submission_id, tag_id),
+    FOREIGN KEY(submission_id) REFERENCES submissions (id),
+    FOREIGN KEY(tag_id) REFERENCES tags (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_submission_tags_tag_id ON submission_tags (tag_id);
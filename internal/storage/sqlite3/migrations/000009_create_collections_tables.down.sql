@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_collection_items_submission_id;
+DROP TABLE IF EXISTS collection_items;
+DROP INDEX IF EXISTS idx_collections_name;
+DROP TABLE IF EXISTS collections;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS collections (
+    id         INTEGER NOT NULL,
+    name       VARCHAR NOT NULL,
+    cursor     INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_collections_name ON collections (name);
+
+CREATE TABLE IF NOT EXISTS collection_items (
+    collection_id INTEGER NOT NULL,
+    submission_id INTEGER NOT NULL,
+    position      INTEGER NOT NULL,
+
+    PRIMARY KEY (collection_id, submission_id),
+    FOREIGN KEY(collection_id) REFERENCES collections (id),
+    FOREIGN KEY(submission_id) REFERENCES submissions (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_collection_items_submission_id ON collection_items (submission_id);
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS submissions_fts_after_tag_delete;
+DROP TRIGGER IF EXISTS submissions_fts_after_tag_insert;
+DROP TRIGGER IF EXISTS submissions_fts_after_subreddit_update;
+DROP TRIGGER IF EXISTS submissions_fts_after_delete;
+DROP TRIGGER IF EXISTS submissions_fts_after_update;
+DROP TRIGGER IF EXISTS submissions_fts_after_insert;
+DROP TABLE IF EXISTS submissions_fts;
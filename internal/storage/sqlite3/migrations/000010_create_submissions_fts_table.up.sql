@@ -0,0 +1,95 @@
+CREATE VIRTUAL TABLE IF NOT EXISTS submissions_fts USING fts5(
+    title,
+    author,
+    subreddit,
+    tags,
+    tokenize = 'porter unicode61'
+);
+
+INSERT INTO submissions_fts(rowid, title, author, subreddit, tags)
+SELECT
+    sm.id,
+    sm.title,
+    sm.author,
+    COALESCE(sr.name, ''),
+    COALESCE(
+        (
+            SELECT GROUP_CONCAT(t.name, ' ')
+            FROM submission_tags st
+            JOIN tags t ON st.tag_id=t.id
+            WHERE st.submission_id=sm.id
+        ),
+        ''
+    )
+FROM submissions sm
+LEFT JOIN subreddits sr ON sm.subreddit_id=sr.id;
+
+CREATE TRIGGER IF NOT EXISTS submissions_fts_after_insert
+AFTER INSERT ON submissions
+BEGIN
+    INSERT INTO submissions_fts(rowid, title, author, subreddit, tags)
+    VALUES (
+        new.id,
+        new.title,
+        new.author,
+        COALESCE((SELECT name FROM subreddits WHERE id=new.subreddit_id), ''),
+        ''
+    );
+END;
+
+CREATE TRIGGER IF NOT EXISTS submissions_fts_after_update
+AFTER UPDATE OF title, author, subreddit_id ON submissions
+BEGIN
+    UPDATE submissions_fts
+    SET
+        title=new.title,
+        author=new.author,
+        subreddit=COALESCE((SELECT name FROM subreddits WHERE id=new.subreddit_id), '')
+    WHERE rowid=new.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS submissions_fts_after_delete
+AFTER DELETE ON submissions
+BEGIN
+    DELETE FROM submissions_fts WHERE rowid=old.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS submissions_fts_after_subreddit_update
+AFTER UPDATE OF name ON subreddits
+BEGIN
+    UPDATE submissions_fts
+    SET subreddit=new.name
+    WHERE rowid IN (SELECT id FROM submissions WHERE subreddit_id=new.id);
+END;
+
+CREATE TRIGGER IF NOT EXISTS submissions_fts_after_tag_insert
+AFTER INSERT ON submission_tags
+BEGIN
+    UPDATE submissions_fts
+    SET tags=COALESCE(
+        (
+            SELECT GROUP_CONCAT(t.name, ' ')
+            FROM submission_tags st
+            JOIN tags t ON st.tag_id=t.id
+            WHERE st.submission_id=new.submission_id
+        ),
+        ''
+    )
+    WHERE rowid=new.submission_id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS submissions_fts_after_tag_delete
+AFTER DELETE ON submission_tags
+BEGIN
+    UPDATE submissions_fts
+    SET tags=COALESCE(
+        (
+            SELECT GROUP_CONCAT(t.name, ' ')
+            FROM submission_tags st
+            JOIN tags t ON st.tag_id=t.id
+            WHERE st.submission_id=old.submission_id
+        ),
+        ''
+    )
+    WHERE rowid=old.submission_id;
+END;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_submissions_aspect_ratio;
+ALTER TABLE submissions DROP COLUMN aspect_ratio;
@@ -0,0 +1,7 @@
+ALTER TABLE submissions ADD COLUMN aspect_ratio REAL NOT NULL DEFAULT 0;
+
+UPDATE submissions
+SET aspect_ratio = CAST(image_width_px AS REAL) / image_height_px
+WHERE image_height_px > 0;
+
+CREATE INDEX IF NOT EXISTS idx_submissions_aspect_ratio ON submissions (aspect_ratio);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_history_date;
+DROP INDEX IF EXISTS idx_history_submission_id;
+DROP INDEX IF EXISTS idx_submissions_subreddit_id;
+DROP INDEX IF EXISTS idx_submissions_post_id;
+DROP INDEX IF EXISTS idx_subreddits_name;
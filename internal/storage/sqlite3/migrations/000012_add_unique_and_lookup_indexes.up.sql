@@ -0,0 +1,52 @@
+-- Merge duplicate subreddits into the first one registered with the same name
+UPDATE submissions
+SET subreddit_id = (
+    SELECT MIN(dup.id)
+    FROM subreddits sr
+    JOIN subreddits dup ON dup.name=sr.name
+    WHERE sr.id=submissions.subreddit_id
+)
+WHERE subreddit_id IN (
+    SELECT id FROM subreddits WHERE id NOT IN (SELECT MIN(id) FROM subreddits GROUP BY name)
+);
+
+DELETE FROM subreddits
+WHERE id NOT IN (SELECT MIN(id) FROM subreddits GROUP BY name);
+
+-- Merge duplicate submissions into the first one registered with the same post ID
+CREATE TEMPORARY TABLE submission_duplicates AS
+SELECT sm.id AS duplicate_id, canonical.id AS canonical_id
+FROM submissions sm
+JOIN (SELECT post_id, MIN(id) AS id FROM submissions GROUP BY post_id) canonical
+    ON canonical.post_id=sm.post_id
+WHERE sm.id<>canonical.id;
+
+UPDATE history
+SET submission_id = (SELECT canonical_id FROM submission_duplicates WHERE duplicate_id=history.submission_id)
+WHERE submission_id IN (SELECT duplicate_id FROM submission_duplicates);
+
+UPDATE OR IGNORE ratings
+SET submission_id = (SELECT canonical_id FROM submission_duplicates WHERE duplicate_id=ratings.submission_id)
+WHERE submission_id IN (SELECT duplicate_id FROM submission_duplicates);
+
+UPDATE OR IGNORE submission_tags
+SET submission_id = (SELECT canonical_id FROM submission_duplicates WHERE duplicate_id=submission_tags.submission_id)
+WHERE submission_id IN (SELECT duplicate_id FROM submission_duplicates);
+
+UPDATE OR IGNORE collection_items
+SET submission_id = (SELECT canonical_id FROM submission_duplicates WHERE duplicate_id=collection_items.submission_id)
+WHERE submission_id IN (SELECT duplicate_id FROM submission_duplicates);
+
+DELETE FROM ratings WHERE submission_id IN (SELECT duplicate_id FROM submission_duplicates);
+DELETE FROM submission_tags WHERE submission_id IN (SELECT duplicate_id FROM submission_duplicates);
+DELETE FROM collection_items WHERE submission_id IN (SELECT duplicate_id FROM submission_duplicates);
+DELETE FROM submissions WHERE id IN (SELECT duplicate_id FROM submission_duplicates);
+
+DROP TABLE submission_duplicates;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_subreddits_name ON subreddits (name);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_submissions_post_id ON submissions (post_id);
+
+CREATE INDEX IF NOT EXISTS idx_submissions_subreddit_id ON submissions (subreddit_id);
+CREATE INDEX IF NOT EXISTS idx_history_submission_id ON history (submission_id);
+CREATE INDEX IF NOT EXISTS idx_history_date ON history (date);
@@ -0,0 +1,98 @@
+//go:build sqlite_fts5
+
+package sqlite3
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMigrationDeduplicateSubmissions(t *testing.T) {
+	ctx := t.Context()
+	dbPath := filepath.Join(t.TempDir(), "walric.db")
+
+	migrater := newTestMigrater(t, dbPath)
+
+	if err := migrater.Migrate(11); err != nil {
+		t.Fatalf("failed to run migrations: %q", err)
+	}
+
+	db := newTestDB(t, dbPath)
+
+	// subreddit 2 duplicates subreddit 1, submission 2 duplicates submission 1
+	statements := []string{
+		"INSERT INTO subreddits(id, name) VALUES (1, 'EarthPorn'), (2, 'EarthPorn'), (3, 'SpacePorn')",
+		`INSERT INTO submissions(id, subreddit_id, post_id, title) VALUES
+			(1, 1, 'm31aga', 'Messier 31 - The Andromeda Galaxy'),
+			(2, 2, 'm31aga', 'Messier 31 - The Andromeda Galaxy'),
+			(3, 2, 'owlsrf', 'The Owl Nebula and Surfboard Galaxy'),
+			(4, 3, 'sombga', 'The Sombrero Galaxy')`,
+		"INSERT INTO history(id, submission_id, date) VALUES (1, 1, '2024-06-01'), (2, 2, '2024-06-02'), (3, 4, '2024-06-03')",
+		"INSERT INTO ratings(submission_id, rating) VALUES (2, 5), (4, -1)",
+		"INSERT INTO tags(id, name) VALUES (1, 'galaxy'), (2, 'andromeda')",
+		"INSERT INTO submission_tags(submission_id, tag_id) VALUES (1, 1), (2, 1), (2, 2)",
+		"INSERT INTO collections(id, name) VALUES (1, 'galaxies'), (2, 'andromeda')",
+		"INSERT INTO collection_items(collection_id, submission_id, position) VALUES (1, 1, 0), (1, 2, 1), (1, 4, 2), (2, 2, 0)",
+	}
+
+	for _, statement := range statements {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			t.Fatalf("failed to insert rows: %q", err)
+		}
+	}
+
+	if err := migrater.Migrate(12); err != nil {
+		t.Fatalf("failed to run migration: %q", err)
+	}
+
+	testCases := []struct {
+		tname string
+		query string
+		want  []string
+	}{
+		{
+			tname: "subreddits",
+			query: "SELECT id || ':' || name FROM subreddits ORDER BY id",
+			want:  []string{"1:EarthPorn", "3:SpacePorn"},
+		},
+		{
+			tname: "submissions",
+			query: "SELECT id || ':' || subreddit_id || ':' || post_id FROM submissions ORDER BY id",
+			want:  []string{"1:1:m31aga", "3:1:owlsrf", "4:3:sombga"},
+		},
+		{
+			tname: "history",
+			query: "SELECT id || ':' || submission_id FROM history ORDER BY id",
+			want:  []string{"1:1", "2:1", "3:4"},
+		},
+		{
+			tname: "ratings",
+			query: "SELECT submission_id || ':' || rating FROM ratings ORDER BY submission_id",
+			want:  []string{"1:5", "4:-1"},
+		},
+		{
+			tname: "tags",
+			query: "SELECT submission_id || ':' || tag_id FROM submission_tags ORDER BY submission_id, tag_id",
+			want:  []string{"1:1", "1:2"},
+		},
+		{
+			tname: "collection items",
+			query: "SELECT collection_id || ':' || submission_id FROM collection_items ORDER BY collection_id, submission_id",
+			want:  []string{"1:1", "1:4", "2:1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			got := []string{}
+			if err := db.SelectContext(ctx, &got, tc.query); err != nil {
+				t.Fatalf("failed to query rows: %q", err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+//go:build sqlite_fts5
+
+package sqlite3
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite3"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/virtualtam/walric/internal/storage/sqlite3/migrations"
+	"github.com/virtualtam/walric/pkg/monitor"
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+const (
+	benchmarkSubreddits  = 50
+	benchmarkSubmissions = 50000
+)
+
+// newBenchmarkRepository returns a Repository backed by a temporary database
+// filled with generated Submissions.
+func newBenchmarkRepository(b *testing.B) *Repository {
+	b.Helper()
+
+	dbPath := filepath.Join(b.TempDir(), "walric.db")
+
+	migrationsSource, err := iofs.New(migrations.MigrationsFS, ".")
+	if err != nil {
+		b.Fatalf("failed to load migrations: %q", err)
+	}
+
+	migrater, err := migrate.NewWithSourceInstance("iofs", migrationsSource, "sqlite3://"+dbPath)
+	if err != nil {
+		b.Fatalf("failed to initialize migrations: %q", err)
+	}
+
+	if err := migrater.Up(); err != nil {
+		b.Fatalf("failed to run migrations: %q", err)
+	}
+
+	db, err := sqlx.Open("sqlite3", dbPath)
+	if err != nil {
+		b.Fatalf("failed to open database: %q", err)
+	}
+	b.Cleanup(func() { _ = db.Close() })
+
+	tx, err := db.Beginx()
+	if err != nil {
+		b.Fatalf("failed to begin transaction: %q", err)
+	}
+
+	for i := 1; i <= benchmarkSubreddits; i++ {
+		tx.MustExec("INSERT INTO subreddits(name) VALUES(?)", fmt.Sprintf("subreddit%d", i))
+	}
+
+	postedAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 1; i <= benchmarkSubmissions; i++ {
+		tx.MustExec(`
+INSERT INTO submissions(
+	subreddit_id, author, permalink, post_id, created_utc, score, title, domain, url, over_18,
+	image_filename, image_height_px, image_width_px, aspect_ratio, unavailable, removed
+)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, 0, ?, 2160, 3840, ?, 0, 0)`,
+			i%benchmarkSubreddits+1,
+			"author",
+			fmt.Sprintf("/r/subreddit/comments/p%d", i),
+			fmt.Sprintf("p%d", i),
+			postedAt.Add(time.Duration(i)*time.Hour),
+			i%1000,
+			fmt.Sprintf("Generated wallpaper #%d [3840x2160]", i),
+			"i.redd.it",
+			fmt.Sprintf("https://i.redd.it/p%d.jpg", i),
+			fmt.Sprintf("p%d.jpg", i),
+			3840.0/2160.0,
+		)
+	}
+
+	if err := tx.Commit(); err != nil {
+		b.Fatalf("failed to commit generated data: %q", err)
+	}
+
+	return NewRepository(db)
+}
+
+func BenchmarkRepository(b *testing.B) {
+	r := newBenchmarkRepository(b)
+	minResolution := &monitor.Resolution{HeightPx: 1080, WidthPx: 1920}
+
+	b.Run("SubmissionGetByMinResolution", func(b *testing.B) {
+		for b.Loop() {
+			submissions, err := r.SubmissionGetByMinResolution(b.Context(), minResolution, &submission.Filter{}, &submission.ListOptions{})
+			if err != nil {
+				b.Fatal(err)
+			}
+			if len(submissions) != benchmarkSubmissions {
+				b.Fatalf("want %d submissions, got %d", benchmarkSubmissions, len(submissions))
+			}
+		}
+	})
+}
@@ -0,0 +1,11 @@
+//go:build !sqlite_fts5
+
+package sqlite3
+
+import "testing"
+
+// The database migrations create full-text search tables, which require
+// SQLite3 to be built with the FTS5 extension.
+func TestRepository(t *testing.T) {
+	t.Skip("the SQLite3 repository tests require the sqlite_fts5 build tag: go test -tags sqlite_fts5")
+}
@@ -0,0 +1,65 @@
+//go:build sqlite_fts5
+
+package sqlite3
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite3"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/virtualtam/walric/internal/storage/sqlite3/migrations"
+	"github.com/virtualtam/walric/pkg/repositorytest"
+)
+
+// newTestMigrater returns a migrater for a temporary database located at
+// dbPath.
+func newTestMigrater(t *testing.T, dbPath string) *migrate.Migrate {
+	t.Helper()
+
+	migrationsSource, err := iofs.New(migrations.MigrationsFS, ".")
+	if err != nil {
+		t.Fatalf("failed to load migrations: %q", err)
+	}
+
+	migrater, err := migrate.NewWithSourceInstance("iofs", migrationsSource, "sqlite3://"+dbPath)
+	if err != nil {
+		t.Fatalf("failed to initialize migrations: %q", err)
+	}
+	t.Cleanup(func() { _, _ = migrater.Close() })
+
+	return migrater
+}
+
+// newTestDB returns a connection to a database located at dbPath.
+func newTestDB(t *testing.T, dbPath string) *sqlx.DB {
+	t.Helper()
+
+	db, err := sqlx.Open("sqlite3", DataSourceName(dbPath))
+	if err != nil {
+		t.Fatalf("failed to open database: %q", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	return db
+}
+
+// newTestRepository returns a Repository backed by a temporary database.
+func newTestRepository(t *testing.T) repositorytest.Repository {
+	t.Helper()
+
+	dbPath := filepath.Join(t.TempDir(), "walric.db")
+
+	if err := newTestMigrater(t, dbPath).Up(); err != nil {
+		t.Fatalf("failed to run migrations: %q", err)
+	}
+
+	return NewRepository(newTestDB(t, dbPath))
+}
+
+func TestRepository(t *testing.T) {
+	repositorytest.Run(t, newTestRepository)
+}
@@ -0,0 +1,54 @@
+package sqlite3
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+const (
+	// ftsColumnWeights holds the relevance of the title, author, subreddit
+	// and tags columns of the submissions_fts table, used to rank search
+	// results.
+	ftsColumnWeights = "10.0, 1.0, 2.0, 5.0"
+)
+
+// RequireFTS5 returns ErrFTS5Unavailable if the SQLite3 library was compiled
+// without the FTS5 extension, required for full-text search.
+func RequireFTS5(ctx context.Context, db *sqlx.DB) error {
+	var enabled bool
+
+	if err := db.QueryRowxContext(ctx, "SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&enabled); err != nil {
+		return err
+	}
+
+	if !enabled {
+		return ErrFTS5Unavailable
+	}
+
+	return nil
+}
+
+// ftsMatchQuery returns the FTS5 query corresponding to a list of
+// submission.SearchTerm.
+//
+// Terms are quoted so that FTS5 operators and special characters are matched
+// literally.
+func ftsMatchQuery(terms []submission.SearchTerm) string {
+	expressions := make([]string, len(terms))
+
+	for index, term := range terms {
+		expression := `"` + strings.ReplaceAll(term.Text, `"`, `""`) + `"`
+
+		if term.Prefix {
+			expression += "*"
+		}
+
+		expressions[index] = expression
+	}
+
+	return strings.Join(expressions, " AND ")
+}
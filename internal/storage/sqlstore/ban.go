@@ -0,0 +1,24 @@
+package sqlstore
+
+import (
+	"time"
+
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+type DBBan struct {
+	ID   int       `db:"id"`
+	Date time.Time `db:"date"`
+
+	PostID      string `db:"post_id"`
+	ImageSHA256 string `db:"image_sha256"`
+}
+
+func newDBBan(ban *submission.Ban) *DBBan {
+	return &DBBan{
+		ID:          ban.ID,
+		Date:        ban.Date,
+		PostID:      ban.PostID,
+		ImageSHA256: ban.ImageSHA256,
+	}
+}
@@ -0,0 +1,46 @@
+package sqlstore
+
+import (
+	"time"
+
+	"github.com/virtualtam/walric/pkg/collection"
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+type DBCollection struct {
+	ID        int       `db:"id"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+	Cursor    int       `db:"cursor"`
+}
+
+func newDBCollection(c *collection.Collection) *DBCollection {
+	return &DBCollection{
+		ID:        c.ID,
+		Name:      c.Name,
+		CreatedAt: c.CreatedAt,
+		Cursor:    c.Cursor,
+	}
+}
+
+func (c *DBCollection) AsCollection() *collection.Collection {
+	return &collection.Collection{
+		ID:        c.ID,
+		Name:      c.Name,
+		CreatedAt: c.CreatedAt,
+		Cursor:    c.Cursor,
+	}
+}
+
+type DBCollectionItem struct {
+	CollectionID int `db:"collection_id"`
+	SubmissionID int `db:"submission_id"`
+	Position     int `db:"position"`
+}
+
+func (i *DBCollectionItem) AsItem() *collection.Item {
+	return &collection.Item{
+		Position:   i.Position,
+		Submission: &submission.Submission{ID: i.SubmissionID},
+	}
+}
@@ -0,0 +1,69 @@
+package sqlstore
+
+import (
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+// Constraint is the kind of database constraint violated by a statement.
+type Constraint int
+
+const (
+	ConstraintNone Constraint = iota
+	ConstraintUnique
+	ConstraintForeignKey
+)
+
+// Dialect provides the parts of queries, and the error codes, that are
+// specific to a database engine.
+//
+// Queries are written with question mark placeholders, and standard SQL
+// supported by all engines otherwise.
+type Dialect interface {
+	// Rebind converts a query written with question mark placeholders to the
+	// placeholder syntax of the database engine.
+	Rebind(query string) string
+
+	// NoLimit returns the LIMIT value selecting all the rows following the
+	// OFFSET.
+	NoLimit() any
+
+	// SingleWriter returns whether the database engine supports a single
+	// writer, in which case transactions are serialized.
+	SingleWriter() bool
+
+	// ConstraintViolation returns the kind of constraint violated by a
+	// statement, if err reports one.
+	ConstraintViolation(err error) Constraint
+
+	// TextCondition returns the SQL condition and query parameters selecting
+	// the Submissions aliased as sm that match all the given terms.
+	TextCondition(terms []submission.SearchTerm) (string, []any)
+
+	// TextSearch returns the parts of a full-text search query matching all
+	// the given terms.
+	TextSearch(terms []submission.SearchTerm) *TextSearch
+}
+
+// TextSearch holds the parts of a full-text search query that are specific
+// to a database engine.
+type TextSearch struct {
+	// Snippet is the expression of the search result snippet, where matching
+	// terms are enclosed between submission.HighlightStart and
+	// submission.HighlightEnd, and SnippetParams its query parameters.
+	Snippet       string
+	SnippetParams []any
+
+	// From is the FROM clause of the query, which MUST alias the submissions
+	// table as sm, and FromParams its query parameters.
+	From       string
+	FromParams []any
+
+	// Condition selects the Submissions matching the search terms, and
+	// ConditionParams holds its query parameters.
+	Condition       string
+	ConditionParams []any
+
+	// Rank is the expression sorting search results from the most to the
+	// least relevant.
+	Rank string
+}
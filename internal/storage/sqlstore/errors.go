@@ -0,0 +1,18 @@
+package sqlstore
+
+// constraintError translates unique and foreign key constraint violations
+// into domain errors, when set.
+func (r *Repository) constraintError(err error, uniqueErr error, foreignKeyErr error) error {
+	switch r.dialect.ConstraintViolation(err) {
+	case ConstraintUnique:
+		if uniqueErr != nil {
+			return uniqueErr
+		}
+	case ConstraintForeignKey:
+		if foreignKeyErr != nil {
+			return foreignKeyErr
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,122 @@
+package sqlstore
+
+import (
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+// filterConditions returns the SQL conditions and query parameters
+// corresponding to a submission.Filter.
+//
+// The conditions expect the submissions, subreddits and ratings tables to be
+// aliased as sm, sr and rt.
+func (r *Repository) filterConditions(filter *submission.Filter) ([]string, []any, error) {
+	conditions := []string{}
+	params := []any{}
+
+	if !filter.PostedBefore.IsZero() {
+		conditions = append(conditions, "sm.created_utc < ?")
+		params = append(params, filter.PostedBefore.UTC())
+	}
+
+	if filter.BelowResolution != nil {
+		conditions = append(conditions, "(sm.image_height_px < ? OR sm.image_width_px < ?)")
+		params = append(params, filter.BelowResolution.HeightPx, filter.BelowResolution.WidthPx)
+	}
+
+	if filter.AspectRatio != nil {
+		conditions = append(conditions, "sm.aspect_ratio BETWEEN ? AND ?")
+		params = append(params, filter.AspectRatio.Min, filter.AspectRatio.Max)
+	}
+
+	if len(filter.SubredditNames) > 0 {
+		names := make([]string, len(filter.SubredditNames))
+		for index, name := range filter.SubredditNames {
+			names[index] = strings.ToLower(name)
+		}
+
+		clause, inParams, err := sqlx.In("LOWER(sr.name) IN (?)", names)
+		if err != nil {
+			return []string{}, []any{}, err
+		}
+
+		conditions = append(conditions, clause)
+		params = append(params, inParams...)
+	}
+
+	switch filter.NSFW {
+	case submission.NSFWExclude:
+		conditions = append(conditions, "COALESCE(sm.over_18, FALSE) = FALSE")
+	case submission.NSFWOnly:
+		conditions = append(conditions, "sm.over_18 = TRUE")
+	}
+
+	if filter.NeverShown {
+		conditions = append(conditions, "sm.id NOT IN (SELECT submission_id FROM history)")
+	}
+
+	if filter.MinRating > submission.RatingNone {
+		conditions = append(conditions, "COALESCE(rt.rating, 0) >= ?")
+		params = append(params, filter.MinRating)
+	}
+
+	if filter.ExcludeDisliked {
+		conditions = append(conditions, "COALESCE(rt.rating, 0) <> ?")
+		params = append(params, submission.RatingDisliked)
+	}
+
+	if filter.DislikedOnly {
+		conditions = append(conditions, "rt.rating = ?")
+		params = append(params, submission.RatingDisliked)
+	}
+
+	for _, tag := range submission.NormalizeTags(filter.Tags) {
+		conditions = append(conditions, `sm.id IN (
+  SELECT st.submission_id
+  FROM submission_tags st
+  JOIN tags t ON st.tag_id=t.id
+  WHERE t.name=?
+)`)
+		params = append(params, tag)
+	}
+
+	if len(filter.ExcludeTags) > 0 {
+		condition, inParams, err := sqlx.In(`sm.id NOT IN (
+  SELECT st.submission_id
+  FROM submission_tags st
+  JOIN tags t ON st.tag_id=t.id
+  WHERE t.name IN (?)
+)`,
+			submission.NormalizeTags(filter.ExcludeTags),
+		)
+		if err != nil {
+			return []string{}, []any{}, err
+		}
+
+		conditions = append(conditions, condition)
+		params = append(params, inParams...)
+	}
+
+	queryConds, queryParams, err := r.queryConditions(filter.Query)
+	if err != nil {
+		return []string{}, []any{}, err
+	}
+
+	conditions = append(conditions, queryConds...)
+	params = append(params, queryParams...)
+
+	return conditions, params, nil
+}
+
+// whereClause returns a SQL WHERE clause combining conditions with a logical
+// AND, or an empty string if there are no conditions.
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+
+	return "WHERE " + strings.Join(conditions, "\nAND   ") + "\n"
+}
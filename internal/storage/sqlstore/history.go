@@ -0,0 +1,38 @@
+package sqlstore
+
+import (
+	"time"
+
+	"github.com/virtualtam/walric/pkg/history"
+)
+
+type DBEntry struct {
+	ID   int       `db:"id"`
+	Date time.Time `db:"date"`
+
+	SubmissionID int `db:"submission_id"`
+}
+
+func newDBEntry(entry *history.Entry) *DBEntry {
+	return &DBEntry{
+		ID:           entry.ID,
+		Date:         entry.Date,
+		SubmissionID: entry.Submission.ID,
+	}
+}
+
+// DBEntrySubmission holds a history Entry joined with its Submission.
+type DBEntrySubmission struct {
+	EntryID   int       `db:"entry_id"`
+	EntryDate time.Time `db:"entry_date"`
+
+	DBSubmission
+}
+
+func (e *DBEntrySubmission) AsEntry() *history.Entry {
+	return &history.Entry{
+		ID:         e.EntryID,
+		Date:       e.EntryDate,
+		Submission: e.AsSubmission(),
+	}
+}
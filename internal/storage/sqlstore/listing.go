@@ -0,0 +1,52 @@
+package sqlstore
+
+import (
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+var sortColumns = map[submission.SortKey]string{
+	submission.SortPosted:     "sm.created_utc",
+	submission.SortRating:     "COALESCE(rt.rating, 0)",
+	submission.SortResolution: "sm.image_width_px * sm.image_height_px",
+	submission.SortScore:      "sm.score",
+	submission.SortSubreddit:  "LOWER(sr.name)",
+	submission.SortTitle:      "LOWER(sm.title)",
+}
+
+// orderByClause returns a SQL ORDER BY clause sorting Submissions according
+// to ListOptions, then by the default order.
+//
+// The clause expects the submissions, subreddits and ratings tables to be
+// aliased as sm, sr and rt.
+func orderByClause(options *submission.ListOptions, defaultOrder string) string {
+	column, ok := sortColumns[options.Sort]
+	if !ok {
+		return "ORDER BY " + defaultOrder + "\n"
+	}
+
+	return "ORDER BY " + column + " " + sortDirection(options.Descending()) + ", " + defaultOrder + "\n"
+}
+
+// sortDirection returns the SQL keyword for a sort direction.
+func sortDirection(descending bool) string {
+	if descending {
+		return "DESC"
+	}
+
+	return "ASC"
+}
+
+// limitClause returns a SQL LIMIT clause and query parameters selecting a
+// Page, or an empty string if the Page selects all rows.
+func (r *Repository) limitClause(page submission.Page) (string, []any) {
+	if page.Limit == 0 && page.Offset == 0 {
+		return "", []any{}
+	}
+
+	var limit any = page.Limit
+	if page.Limit == 0 {
+		limit = r.dialect.NoLimit()
+	}
+
+	return "LIMIT ? OFFSET ?\n", []any{limit, page.Offset}
+}
@@ -0,0 +1,154 @@
+package sqlstore
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+var (
+	queryStringColumns = map[submission.StringField]string{
+		submission.FieldAuthor:    "LOWER(sm.author)",
+		submission.FieldSubreddit: "LOWER(sr.name)",
+	}
+
+	queryNumberColumns = map[submission.NumberField]string{
+		submission.FieldHeight: "sm.image_height_px",
+		submission.FieldRating: "COALESCE(rt.rating, 0)",
+		submission.FieldScore:  "sm.score",
+		submission.FieldWidth:  "sm.image_width_px",
+	}
+
+	queryBoolColumns = map[submission.BoolField]string{
+		submission.FieldNSFW:        "COALESCE(sm.over_18, FALSE)",
+		submission.FieldRemoved:     "sm.removed",
+		submission.FieldUnavailable: "sm.unavailable",
+	}
+
+	queryOperators = map[submission.Operator]string{
+		submission.OperatorEqual:          "=",
+		submission.OperatorNotEqual:       "<>",
+		submission.OperatorLess:           "<",
+		submission.OperatorLessOrEqual:    "<=",
+		submission.OperatorGreater:        ">",
+		submission.OperatorGreaterOrEqual: ">=",
+	}
+)
+
+// queryConditions returns the SQL conditions and query parameters
+// corresponding to a submission.Query.
+//
+// The conditions expect the submissions, subreddits and ratings tables to be
+// aliased as sm, sr and rt.
+func (r *Repository) queryConditions(query *submission.Query) ([]string, []any, error) {
+	conditions := []string{}
+	params := []any{}
+
+	if query.IsEmpty() {
+		return conditions, params, nil
+	}
+
+	for _, queryCondition := range query.Conditions {
+		condition, conditionParams, err := r.queryConditionSQL(queryCondition)
+		if err != nil {
+			return []string{}, []any{}, err
+		}
+
+		conditions = append(conditions, condition)
+		params = append(params, conditionParams...)
+	}
+
+	return conditions, params, nil
+}
+
+func (r *Repository) queryConditionSQL(condition submission.Condition) (string, []any, error) {
+	switch c := condition.(type) {
+	case *submission.TextCondition:
+		condition, params := r.dialect.TextCondition([]submission.SearchTerm{c.Term})
+
+		return condition, params, nil
+
+	case *submission.StringCondition:
+		if c.Field == submission.FieldTag {
+			return `sm.id IN (
+  SELECT st.submission_id
+  FROM submission_tags st
+  JOIN tags t ON st.tag_id=t.id
+  WHERE t.name=?
+)`,
+				[]any{strings.ToLower(c.Value)},
+				nil
+		}
+
+		column, ok := queryStringColumns[c.Field]
+		if !ok {
+			return "", []any{}, fmt.Errorf("%w %q", submission.ErrQueryFieldUnknown, c.Field)
+		}
+
+		return column + " = ?", []any{strings.ToLower(c.Value)}, nil
+
+	case *submission.NumberCondition:
+		column, ok := queryNumberColumns[c.Field]
+		if !ok {
+			return "", []any{}, fmt.Errorf("%w %q", submission.ErrQueryFieldUnknown, c.Field)
+		}
+
+		operator, ok := queryOperators[c.Operator]
+		if !ok {
+			return "", []any{}, fmt.Errorf("%w %q", submission.ErrQueryOperatorInvalid, c.Operator)
+		}
+
+		return column + " " + operator + " ?", []any{c.Value}, nil
+
+	case *submission.BoolCondition:
+		if c.Field == submission.FieldShown {
+			if c.Value {
+				return "sm.id IN (SELECT submission_id FROM history)", []any{}, nil
+			}
+
+			return "sm.id NOT IN (SELECT submission_id FROM history)", []any{}, nil
+		}
+
+		column, ok := queryBoolColumns[c.Field]
+		if !ok {
+			return "", []any{}, fmt.Errorf("%w %q", submission.ErrQueryFieldUnknown, c.Field)
+		}
+
+		return column + " = ?", []any{c.Value}, nil
+
+	case *submission.DateCondition:
+		if c.Field != submission.FieldPosted {
+			return "", []any{}, fmt.Errorf("%w %q", submission.ErrQueryFieldUnknown, c.Field)
+		}
+
+		conditions := []string{}
+		params := []any{}
+
+		if !c.From.IsZero() {
+			conditions = append(conditions, "sm.created_utc >= ?")
+			params = append(params, c.From.UTC())
+		}
+
+		if !c.To.IsZero() {
+			conditions = append(conditions, "sm.created_utc < ?")
+			params = append(params, c.To.UTC())
+		}
+
+		if len(conditions) == 0 {
+			return "TRUE", []any{}, nil
+		}
+
+		return "(" + strings.Join(conditions, " AND ") + ")", params, nil
+
+	case *submission.NotCondition:
+		inner, params, err := r.queryConditionSQL(c.Condition)
+		if err != nil {
+			return "", []any{}, err
+		}
+
+		return "NOT (" + inner + ")", params, nil
+	}
+
+	return "", []any{}, fmt.Errorf("unsupported query condition %T", condition)
+}
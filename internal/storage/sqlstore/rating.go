@@ -0,0 +1,19 @@
+package sqlstore
+
+import (
+	"time"
+)
+
+type DBRating struct {
+	SubmissionID int       `db:"submission_id"`
+	Rating       int       `db:"rating"`
+	Date         time.Time `db:"date"`
+}
+
+func newDBRating(submissionID int, rating int) *DBRating {
+	return &DBRating{
+		SubmissionID: submissionID,
+		Rating:       rating,
+		Date:         time.Now().UTC(),
+	}
+}
@@ -0,0 +1,43 @@
+package sqlstore
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+// historyCountSQL counts how many times the Submission aliased as sm was
+// selected.
+const historyCountSQL = "(SELECT COUNT(*) FROM history h WHERE h.submission_id=sm.id)"
+
+// repeatCondition returns the SQL condition and query parameters excluding the
+// Submissions that a RepeatPolicy does not allow to select at a given date.
+//
+// The candidates' conditions and parameters are required by the RepeatCycle
+// mode, which compares each candidate to the least selected ones.
+func repeatCondition(repeat submission.RepeatPolicy, candidateConditions []string, candidateParams []any, now time.Time) (string, []any, error) {
+	switch repeat.Mode {
+	case submission.RepeatNever:
+		return "sm.id NOT IN (SELECT submission_id FROM history)", []any{}, nil
+
+	case submission.RepeatAfterDays:
+		return "sm.id NOT IN (SELECT submission_id FROM history WHERE date > ?)",
+			[]any{now.AddDate(0, 0, -repeat.Count)},
+			nil
+
+	case submission.RepeatAfterSelections:
+		return "sm.id NOT IN (SELECT submission_id FROM history ORDER BY date DESC, id DESC LIMIT ?)",
+			[]any{repeat.Count},
+			nil
+
+	case submission.RepeatCycle:
+		condition := historyCountSQL + ` = (
+  SELECT MIN(` + historyCountSQL + `)
+  FROM submissions sm` + submissionSelectJoins + whereClause(candidateConditions) + `)`
+
+		return condition, candidateParams, nil
+	}
+
+	return "", []any{}, fmt.Errorf("%w %q", submission.ErrRepeatPolicyInvalid, repeat)
+}
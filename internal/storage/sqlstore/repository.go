@@ -0,0 +1,1021 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/virtualtam/walric/pkg/collection"
+	"github.com/virtualtam/walric/pkg/history"
+	"github.com/virtualtam/walric/pkg/monitor"
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+const (
+	// deleteBatchSize is the maximum number of rows deleted by a single
+	// statement, to stay below the bound parameter limit of the database
+	// engines.
+	deleteBatchSize = 500
+
+	// submissionSelectColumns lists the columns of all queries returning
+	// Submissions, with the submissions and ratings tables respectively
+	// aliased as sm and rt.
+	//
+	// Tags are aggregated as a comma-separated list.
+	submissionSelectColumns = `
+SELECT
+  sm.id,
+  sm.author,
+  sm.created_utc,
+  sm.domain,
+  sm.image_filename,
+  sm.image_height_px,
+  sm.image_width_px,
+  sm.aspect_ratio,
+  sm.over_18,
+  sm.permalink,
+  sm.post_id,
+  sm.score,
+  sm.subreddit_id,
+  sr.name AS subreddit_name,
+  sm.title,
+  sm.url,
+  sm.unavailable,
+  sm.removed,
+  sm.last_refreshed_at,
+  COALESCE(rt.rating, 0) AS rating,
+  (
+    SELECT STRING_AGG(t.name, ',')
+    FROM submission_tags st
+    JOIN tags t ON st.tag_id=t.id
+    WHERE st.submission_id=sm.id
+  ) AS tags`
+
+	// submissionSelectJoins joins the subreddits and ratings tables, aliased as
+	// sr and rt, to the submissions table aliased as sm.
+	submissionSelectJoins = `
+LEFT JOIN subreddits sr ON sm.subreddit_id=sr.id
+LEFT JOIN ratings rt ON sm.id=rt.submission_id
+`
+
+	// submissionSelectQuery is the common part of all queries returning
+	// Submissions.
+	submissionSelectQuery = submissionSelectColumns + `
+FROM submissions sm` + submissionSelectJoins
+
+	// historySelectQuery is the common part of all queries returning history
+	// Entries, joined with their Submission.
+	historySelectQuery = submissionSelectColumns + `,
+  h.id AS entry_id,
+  h.date AS entry_date
+FROM history h
+JOIN submissions sm ON sm.id=h.submission_id` + submissionSelectJoins
+)
+
+var _ collection.Repository = &Repository{}
+var _ history.Repository = &Repository{}
+var _ submission.Repository = &Repository{}
+
+// dbConn is implemented by sqlx.DB and sqlx.Tx, so that queries run within
+// the current transaction if there is one.
+type dbConn interface {
+	sqlx.ExtContext
+
+	NamedExecContext(ctx context.Context, query string, arg any) (sql.Result, error)
+}
+
+// Repository provides a SQL database persistence layer for Submissions,
+// Subreddits, Collections and the History.
+//
+// Queries are written with question mark placeholders, and rebound to the
+// placeholder syntax of the database engine by the Dialect when executed.
+type Repository struct {
+	db      *sqlx.DB
+	conn    dbConn
+	dialect Dialect
+
+	// tx is the transaction started by WithTx, if any
+	tx *sqlx.Tx
+
+	// txLock serializes transactions if the database engine supports a single
+	// writer, and fails to upgrade concurrent read transactions to write
+	// transactions.
+	txLock *sync.Mutex
+}
+
+// NewRepository initializes and returns a SQL repository to persist and
+// manage Submissions, using the given Dialect.
+func NewRepository(db *sqlx.DB, dialect Dialect) *Repository {
+	r := &Repository{
+		db:      db,
+		conn:    db,
+		dialect: dialect,
+	}
+
+	if dialect.SingleWriter() {
+		r.txLock = &sync.Mutex{}
+	}
+
+	return r
+}
+
+func (r *Repository) WithTx(ctx context.Context, fn func(submission.Repository) error) error {
+	return r.inTx(ctx, func(tx *Repository) error {
+		return fn(tx)
+	})
+}
+
+// inTx runs fn with a Repository bound to the transaction started by WithTx,
+// or to a new transaction otherwise.
+func (r *Repository) inTx(ctx context.Context, fn func(tx *Repository) error) error {
+	if r.tx != nil {
+		return fn(r)
+	}
+
+	if r.txLock != nil {
+		r.txLock.Lock()
+		defer r.txLock.Unlock()
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	if err := fn(&Repository{
+		db:      r.db,
+		conn:    tx,
+		dialect: r.dialect,
+		tx:      tx,
+		txLock:  r.txLock,
+	}); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// exec executes a query without returning any rows.
+func (r *Repository) exec(ctx context.Context, query string, queryParams ...any) (sql.Result, error) {
+	return r.conn.ExecContext(ctx, r.dialect.Rebind(query), queryParams...)
+}
+
+// queryx executes a query returning rows.
+func (r *Repository) queryx(ctx context.Context, query string, queryParams ...any) (*sqlx.Rows, error) {
+	return r.conn.QueryxContext(ctx, r.dialect.Rebind(query), queryParams...)
+}
+
+// queryRowx executes a query returning at most one row.
+func (r *Repository) queryRowx(ctx context.Context, query string, queryParams ...any) *sqlx.Row {
+	return r.conn.QueryRowxContext(ctx, r.dialect.Rebind(query), queryParams...)
+}
+
+func (r *Repository) BanIsPostIDRegistered(ctx context.Context, postID string) (bool, error) {
+	return r.isRegistered(ctx, "SELECT id FROM bans WHERE post_id=?", postID)
+}
+
+func (r *Repository) BanIsImageSHA256Registered(ctx context.Context, imageSHA256 string) (bool, error) {
+	return r.isRegistered(ctx, "SELECT id FROM bans WHERE image_sha256=? LIMIT 1", imageSHA256)
+}
+
+func (r *Repository) BanCreate(ctx context.Context, ban *submission.Ban) error {
+	dbBan := newDBBan(ban)
+
+	_, err := r.conn.NamedExecContext(ctx, `
+INSERT INTO bans(date, post_id, image_sha256)
+VALUES (:date, :post_id, :image_sha256)`,
+		dbBan,
+	)
+	if err != nil {
+		return r.constraintError(err, submission.ErrBanPostIDAlreadyRegistered, nil)
+	}
+
+	return nil
+}
+
+func (r *Repository) CollectionGetAll(ctx context.Context) ([]*collection.Collection, error) {
+	rows, err := r.queryx(ctx, "SELECT id, name, created_at, cursor FROM collections ORDER BY LOWER(name)")
+	if err != nil {
+		return []*collection.Collection{}, err
+	}
+
+	collections := []*collection.Collection{}
+
+	for rows.Next() {
+		dbCollection := &DBCollection{}
+
+		if err := rows.StructScan(dbCollection); err != nil {
+			return []*collection.Collection{}, err
+		}
+
+		collections = append(collections, dbCollection.AsCollection())
+	}
+
+	return collections, nil
+}
+
+func (r *Repository) CollectionGetByName(ctx context.Context, name string) (*collection.Collection, error) {
+	dbCollection := &DBCollection{}
+
+	err := r.queryRowx(ctx, "SELECT id, name, created_at, cursor FROM collections WHERE name=?", name).StructScan(dbCollection)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &collection.Collection{}, collection.ErrCollectionNotFound
+	}
+	if err != nil {
+		return &collection.Collection{}, err
+	}
+
+	return dbCollection.AsCollection(), nil
+}
+
+func (r *Repository) CollectionIsNameRegistered(ctx context.Context, name string) (bool, error) {
+	return r.isRegistered(ctx, "SELECT id FROM collections WHERE name=?", name)
+}
+
+func (r *Repository) CollectionCreate(ctx context.Context, c *collection.Collection) error {
+	dbCollection := newDBCollection(c)
+
+	_, err := r.conn.NamedExecContext(ctx, `
+INSERT INTO collections(name, created_at, cursor)
+VALUES (:name, :created_at, :cursor)`,
+		dbCollection,
+	)
+	if err != nil {
+		return r.constraintError(err, collection.ErrCollectionNameAlreadyRegistered, nil)
+	}
+
+	return nil
+}
+
+func (r *Repository) CollectionUpdateCursor(ctx context.Context, collectionID int, cursor int) error {
+	result, err := r.exec(ctx, "UPDATE collections SET cursor=? WHERE id=?", cursor, collectionID)
+	if err != nil {
+		return err
+	}
+
+	return requireRowsAffected(result, collection.ErrCollectionNotFound)
+}
+
+func (r *Repository) CollectionItemGetAll(ctx context.Context, collectionID int) ([]*collection.Item, error) {
+	rows, err := r.queryx(ctx, `
+SELECT collection_id, submission_id, position
+FROM collection_items
+WHERE collection_id=?
+ORDER BY position`,
+		collectionID,
+	)
+	if err != nil {
+		return []*collection.Item{}, err
+	}
+
+	items := []*collection.Item{}
+
+	for rows.Next() {
+		dbItem := &DBCollectionItem{}
+
+		if err := rows.StructScan(dbItem); err != nil {
+			return []*collection.Item{}, err
+		}
+
+		items = append(items, dbItem.AsItem())
+	}
+
+	return items, nil
+}
+
+func (r *Repository) CollectionItemIsSubmissionRegistered(ctx context.Context, collectionID int, submissionID int) (bool, error) {
+	return r.isRegistered(
+		ctx,
+		"SELECT submission_id FROM collection_items WHERE collection_id=? AND submission_id=?",
+		collectionID,
+		submissionID,
+	)
+}
+
+func (r *Repository) CollectionItemAdd(ctx context.Context, collectionID int, submissionID int) error {
+	_, err := r.exec(ctx, `
+INSERT INTO collection_items(collection_id, submission_id, position)
+SELECT CAST(? AS INTEGER), CAST(? AS INTEGER), COALESCE(MAX(position), 0) + 1
+FROM collection_items
+WHERE collection_id=?`,
+		collectionID,
+		submissionID,
+		collectionID,
+	)
+	if err != nil {
+		return r.constraintError(err, collection.ErrItemAlreadyRegistered, nil)
+	}
+
+	return nil
+}
+
+func (r *Repository) CollectionItemRemove(ctx context.Context, collectionID int, submissionID int) error {
+	result, err := r.exec(
+		ctx,
+		"DELETE FROM collection_items WHERE collection_id=? AND submission_id=?",
+		collectionID,
+		submissionID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return requireRowsAffected(result, collection.ErrItemNotFound)
+}
+
+func (r *Repository) HistoryGetAll(ctx context.Context, options *history.ListOptions) ([]*history.Entry, error) {
+	direction := sortDirection(options.Descending())
+	limit, params := r.limitClause(options.Page)
+
+	rows, err := r.queryx(
+		ctx,
+		historySelectQuery+"ORDER BY h.date "+direction+", h.id "+direction+"\n"+limit,
+		params...,
+	)
+	if err != nil {
+		return []*history.Entry{}, err
+	}
+
+	entries := []*history.Entry{}
+
+	for rows.Next() {
+		dbEntry := &DBEntrySubmission{}
+
+		if err := rows.StructScan(dbEntry); err != nil {
+			return []*history.Entry{}, err
+		}
+
+		entries = append(entries, dbEntry.AsEntry())
+	}
+
+	return entries, nil
+}
+
+func (r *Repository) HistoryGetCurrent(ctx context.Context) (*history.Entry, error) {
+	dbEntry := &DBEntrySubmission{}
+
+	err := r.queryRowx(ctx, historySelectQuery+"ORDER BY h.date DESC, h.id DESC LIMIT 1").StructScan(dbEntry)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &history.Entry{}, history.ErrNotFound
+	}
+	if err != nil {
+		return &history.Entry{}, err
+	}
+
+	return dbEntry.AsEntry(), nil
+}
+
+func (r *Repository) HistoryCreate(ctx context.Context, entry *history.Entry) error {
+	dbEntry := newDBEntry(entry)
+
+	_, err := r.conn.NamedExecContext(ctx, `
+INSERT INTO history(date, submission_id)
+VALUES (:date, :submission_id)`,
+		dbEntry,
+	)
+	if err != nil {
+		return r.constraintError(err, nil, submission.ErrSubmissionNotFound)
+	}
+
+	return nil
+}
+
+func (r *Repository) submissionGetQuery(ctx context.Context, query string, queryParams ...any) (*submission.Submission, error) {
+	dbSubmission := &DBSubmission{}
+
+	err := r.queryRowx(ctx, query, queryParams...).StructScan(dbSubmission)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return &submission.Submission{}, submission.ErrSubmissionNotFound
+	}
+	if err != nil {
+		return &submission.Submission{}, err
+	}
+
+	return dbSubmission.AsSubmission(), nil
+}
+
+func (r *Repository) submissionGetManyQuery(ctx context.Context, query string, queryParams ...any) ([]*submission.Submission, error) {
+	rows, err := r.queryx(ctx, query, queryParams...)
+
+	if err != nil {
+		return []*submission.Submission{}, err
+	}
+
+	submissions := []*submission.Submission{}
+
+	for rows.Next() {
+		dbSubmission := &DBSubmission{}
+
+		if err := rows.StructScan(dbSubmission); err != nil {
+			return []*submission.Submission{}, err
+		}
+
+		submissions = append(submissions, dbSubmission.AsSubmission())
+	}
+
+	return submissions, nil
+}
+
+func (r *Repository) SubmissionGetAll(ctx context.Context) ([]*submission.Submission, error) {
+	return r.submissionGetManyQuery(ctx, submissionSelectQuery+`
+ORDER BY sm.id
+`)
+}
+
+func (r *Repository) SubmissionGetByFilter(ctx context.Context, filter *submission.Filter, options *submission.ListOptions) ([]*submission.Submission, error) {
+	conditions, params, err := r.filterConditions(filter)
+	if err != nil {
+		return []*submission.Submission{}, err
+	}
+
+	limit, limitParams := r.limitClause(options.Page)
+
+	return r.submissionGetManyQuery(
+		ctx,
+		submissionSelectQuery+
+			whereClause(conditions)+
+			orderByClause(options, "LOWER(sr.name), sm.created_utc, sm.id")+
+			limit,
+		append(params, limitParams...)...,
+	)
+}
+
+func (r *Repository) SubmissionGetByID(ctx context.Context, id int) (*submission.Submission, error) {
+	return r.submissionGetQuery(ctx, submissionSelectQuery+`
+WHERE sm.id=?`,
+		id,
+	)
+}
+
+func (r *Repository) SubmissionGetByMinResolution(ctx context.Context, minResolution *monitor.Resolution, filter *submission.Filter, options *submission.ListOptions) ([]*submission.Submission, error) {
+	conditions, params, err := r.filterConditions(filter)
+	if err != nil {
+		return []*submission.Submission{}, err
+	}
+
+	conditions = append(
+		[]string{
+			"sm.image_height_px >= ?",
+			"sm.image_width_px  >= ?",
+			"sm.unavailable = FALSE",
+		},
+		conditions...,
+	)
+	params = append([]any{minResolution.HeightPx, minResolution.WidthPx}, params...)
+
+	limit, limitParams := r.limitClause(options.Page)
+
+	return r.submissionGetManyQuery(
+		ctx,
+		submissionSelectQuery+
+			whereClause(conditions)+
+			orderByClause(options, "LOWER(sr.name), sm.created_utc, sm.id")+
+			limit,
+		append(params, limitParams...)...,
+	)
+}
+
+func (r *Repository) SubmissionGetBySubredditID(ctx context.Context, subredditID int) ([]*submission.Submission, error) {
+	return r.submissionGetManyQuery(ctx, submissionSelectQuery+`
+WHERE sm.subreddit_id=?
+ORDER BY sm.created_utc
+`,
+		subredditID,
+	)
+}
+
+func (r *Repository) SubmissionGetByPostID(ctx context.Context, postID string) (*submission.Submission, error) {
+	return r.submissionGetQuery(ctx, submissionSelectQuery+`
+WHERE sm.post_id=?`,
+		postID,
+	)
+}
+
+func (r *Repository) SubmissionIsPostIDRegistered(ctx context.Context, postID string) (bool, error) {
+	return r.isRegistered(ctx, "SELECT id FROM submissions WHERE post_id=?", postID)
+}
+
+func (r *Repository) SubmissionSearch(ctx context.Context, terms []submission.SearchTerm, filter *submission.Filter, options *submission.ListOptions) ([]*submission.SearchResult, error) {
+	conditions, params, err := r.filterConditions(filter)
+	if err != nil {
+		return []*submission.SearchResult{}, err
+	}
+
+	search := r.dialect.TextSearch(terms)
+
+	conditions = append([]string{search.Condition}, conditions...)
+	limit, limitParams := r.limitClause(options.Page)
+
+	rows, err := r.queryx(ctx, submissionSelectColumns+`,
+  `+search.Snippet+` AS snippet
+`+search.From+submissionSelectJoins+
+		whereClause(conditions)+
+		orderByClause(options, search.Rank+", sm.created_utc, sm.id")+
+		limit,
+		slices.Concat(search.SnippetParams, search.FromParams, search.ConditionParams, params, limitParams)...,
+	)
+	if err != nil {
+		return []*submission.SearchResult{}, err
+	}
+
+	results := []*submission.SearchResult{}
+
+	for rows.Next() {
+		dbResult := &DBSearchResult{}
+
+		if err := rows.StructScan(dbResult); err != nil {
+			return []*submission.SearchResult{}, err
+		}
+
+		results = append(results, dbResult.AsSearchResult())
+	}
+
+	return results, nil
+}
+
+func (r *Repository) SubmissionGetRandom(ctx context.Context, minResolution *monitor.Resolution, filter *submission.Filter, repeat submission.RepeatPolicy) (*submission.Submission, error) {
+	conditions, params, err := r.randomConditions(minResolution, filter, repeat)
+	if err != nil {
+		return &submission.Submission{}, err
+	}
+
+	return r.submissionGetQuery(ctx, submissionSelectQuery+whereClause(conditions)+`
+ORDER BY RANDOM() LIMIT 1
+`,
+		params...,
+	)
+}
+
+func (r *Repository) SubmissionGetRandomCandidates(ctx context.Context, minResolution *monitor.Resolution, filter *submission.Filter, repeat submission.RepeatPolicy) ([]*submission.Submission, error) {
+	conditions, params, err := r.randomConditions(minResolution, filter, repeat)
+	if err != nil {
+		return []*submission.Submission{}, err
+	}
+
+	return r.submissionGetManyQuery(ctx, submissionSelectQuery+whereClause(conditions)+`
+ORDER BY sm.id
+`,
+		params...,
+	)
+}
+
+// randomConditions returns the SQL conditions and query parameters selecting
+// the candidates for random selection.
+func (r *Repository) randomConditions(minResolution *monitor.Resolution, filter *submission.Filter, repeat submission.RepeatPolicy) ([]string, []any, error) {
+	conditions, params, err := r.filterConditions(filter)
+	if err != nil {
+		return []string{}, []any{}, err
+	}
+
+	conditions = append(
+		[]string{
+			"sm.image_height_px >= ?",
+			"sm.image_width_px  >= ?",
+			"sm.unavailable = FALSE",
+		},
+		conditions...,
+	)
+	params = append([]any{minResolution.HeightPx, minResolution.WidthPx}, params...)
+
+	condition, repeatParams, err := repeatCondition(repeat, conditions, params, time.Now().UTC())
+	if err != nil {
+		return []string{}, []any{}, err
+	}
+
+	conditions = append(conditions, condition)
+	params = append(params, repeatParams...)
+
+	return conditions, params, nil
+}
+
+func (r *Repository) SubmissionCreate(ctx context.Context, s *submission.Submission) error {
+	dbSubmission := newDBSubmission(s)
+
+	_, err := r.conn.NamedExecContext(ctx, `
+INSERT INTO submissions(
+	subreddit_id,
+	author,
+	permalink,
+	post_id,
+	created_utc,
+	score,
+	title,
+	domain,
+	url,
+	over_18,
+	image_filename,
+	image_height_px,
+	image_width_px,
+	aspect_ratio,
+	unavailable,
+	removed,
+	last_refreshed_at
+)
+VALUES (
+	:subreddit_id,
+	:author,
+	:permalink,
+	:post_id,
+	:created_utc,
+	:score,
+	:title,
+	:domain,
+	:url,
+	:over_18,
+	:image_filename,
+	:image_height_px,
+	:image_width_px,
+	:aspect_ratio,
+	:unavailable,
+	:removed,
+	:last_refreshed_at
+)`,
+		dbSubmission,
+	)
+
+	if err != nil {
+		return r.constraintError(err, submission.ErrSubmissionPostIDAlreadyRegistered, submission.ErrSubredditNotFound)
+	}
+
+	return nil
+}
+
+func (r *Repository) SubmissionUpdate(ctx context.Context, s *submission.Submission) error {
+	dbSubmission := newDBSubmission(s)
+
+	result, err := r.conn.NamedExecContext(ctx, `
+UPDATE submissions
+SET
+	subreddit_id=:subreddit_id,
+	author=:author,
+	permalink=:permalink,
+	post_id=:post_id,
+	created_utc=:created_utc,
+	score=:score,
+	title=:title,
+	domain=:domain,
+	url=:url,
+	over_18=:over_18,
+	image_filename=:image_filename,
+	image_height_px=:image_height_px,
+	image_width_px=:image_width_px,
+	aspect_ratio=:aspect_ratio,
+	unavailable=:unavailable,
+	removed=:removed,
+	last_refreshed_at=:last_refreshed_at
+WHERE id=:id`,
+		dbSubmission,
+	)
+	if err != nil {
+		return r.constraintError(err, submission.ErrSubmissionPostIDAlreadyRegistered, submission.ErrSubredditNotFound)
+	}
+
+	return requireRowsAffected(result, submission.ErrSubmissionNotFound)
+}
+
+func (r *Repository) SubmissionRate(ctx context.Context, id int, rating int) error {
+	return r.inTx(ctx, func(tx *Repository) error {
+		if err := tx.requireSubmission(ctx, id); err != nil {
+			return err
+		}
+
+		if rating == submission.RatingNone {
+			if _, err := tx.exec(ctx, "DELETE FROM ratings WHERE submission_id=?", id); err != nil {
+				return err
+			}
+
+			return nil
+		}
+
+		_, err := tx.conn.NamedExecContext(ctx, `
+INSERT INTO ratings(submission_id, rating, date)
+VALUES (:submission_id, :rating, :date)
+ON CONFLICT(submission_id) DO UPDATE SET
+	rating=excluded.rating,
+	date=excluded.date`,
+			newDBRating(id, rating),
+		)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (r *Repository) SubmissionAddTags(ctx context.Context, id int, tags []string) error {
+	return r.inTx(ctx, func(tx *Repository) error {
+		if err := tx.requireSubmission(ctx, id); err != nil {
+			return err
+		}
+
+		for _, tag := range tags {
+			if _, err := tx.exec(ctx, "INSERT INTO tags(name) VALUES (?) ON CONFLICT(name) DO NOTHING", tag); err != nil {
+				return err
+			}
+
+			_, err := tx.exec(ctx, `
+INSERT INTO submission_tags(submission_id, tag_id)
+SELECT CAST(? AS INTEGER), id FROM tags WHERE name=?
+ON CONFLICT(submission_id, tag_id) DO NOTHING`,
+				id,
+				tag,
+			)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *Repository) SubmissionRemoveTags(ctx context.Context, id int, tags []string) error {
+	return r.inTx(ctx, func(tx *Repository) error {
+		if err := tx.requireSubmission(ctx, id); err != nil {
+			return err
+		}
+
+		if len(tags) > 0 {
+			query, params, err := sqlx.In(`
+DELETE FROM submission_tags
+WHERE submission_id=?
+AND   tag_id IN (SELECT id FROM tags WHERE name IN (?))`,
+				id,
+				tags,
+			)
+			if err != nil {
+				return err
+			}
+
+			if _, err := tx.exec(ctx, query, params...); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *Repository) SubmissionDelete(ctx context.Context, id int) error {
+	return r.inTx(ctx, func(tx *Repository) error {
+		if _, err := tx.exec(ctx, "DELETE FROM history WHERE submission_id=?", id); err != nil {
+			return err
+		}
+
+		if _, err := tx.exec(ctx, "DELETE FROM ratings WHERE submission_id=?", id); err != nil {
+			return err
+		}
+
+		if _, err := tx.exec(ctx, "DELETE FROM submission_tags WHERE submission_id=?", id); err != nil {
+			return err
+		}
+
+		if _, err := tx.exec(ctx, "DELETE FROM collection_items WHERE submission_id=?", id); err != nil {
+			return err
+		}
+
+		result, err := tx.exec(ctx, "DELETE FROM submissions WHERE id=?", id)
+		if err != nil {
+			return err
+		}
+
+		if err := requireRowsAffected(result, submission.ErrSubmissionNotFound); err != nil {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (r *Repository) SubmissionDeleteMany(ctx context.Context, ids []int) error {
+	return r.inTx(ctx, func(tx *Repository) error {
+		for batchIDs := range slices.Chunk(ids, deleteBatchSize) {
+			historyQuery, historyParams, err := sqlx.In("DELETE FROM history WHERE submission_id IN (?)", batchIDs)
+			if err != nil {
+				return err
+			}
+
+			if _, err := tx.exec(ctx, historyQuery, historyParams...); err != nil {
+				return err
+			}
+
+			ratingsQuery, ratingsParams, err := sqlx.In("DELETE FROM ratings WHERE submission_id IN (?)", batchIDs)
+			if err != nil {
+				return err
+			}
+
+			if _, err := tx.exec(ctx, ratingsQuery, ratingsParams...); err != nil {
+				return err
+			}
+
+			tagsQuery, tagsParams, err := sqlx.In("DELETE FROM submission_tags WHERE submission_id IN (?)", batchIDs)
+			if err != nil {
+				return err
+			}
+
+			if _, err := tx.exec(ctx, tagsQuery, tagsParams...); err != nil {
+				return err
+			}
+
+			collectionItemsQuery, collectionItemsParams, err := sqlx.In("DELETE FROM collection_items WHERE submission_id IN (?)", batchIDs)
+			if err != nil {
+				return err
+			}
+
+			if _, err := tx.exec(ctx, collectionItemsQuery, collectionItemsParams...); err != nil {
+				return err
+			}
+
+			submissionsQuery, submissionsParams, err := sqlx.In("DELETE FROM submissions WHERE id IN (?)", batchIDs)
+			if err != nil {
+				return err
+			}
+
+			result, err := tx.exec(ctx, submissionsQuery, submissionsParams...)
+			if err != nil {
+				return err
+			}
+
+			rowsAffected, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+
+			if rowsAffected != int64(len(batchIDs)) {
+				return submission.ErrSubmissionNotFound
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *Repository) SubredditCreate(ctx context.Context, s *submission.Subreddit) error {
+	_, err := r.conn.NamedExecContext(ctx, "INSERT INTO subreddits(name) VALUES(:name)", s)
+	if err != nil {
+		return r.constraintError(err, submission.ErrSubredditNameAlreadyRegistered, nil)
+	}
+
+	return nil
+}
+
+func (r *Repository) SubredditGetAll(ctx context.Context) ([]*submission.Subreddit, error) {
+	rows, err := r.queryx(ctx, "SELECT id, name from subreddits ORDER BY LOWER(name)")
+
+	if err != nil {
+		return []*submission.Subreddit{}, err
+	}
+
+	subreddits := []*submission.Subreddit{}
+
+	for rows.Next() {
+		s := &submission.Subreddit{}
+
+		if err := rows.StructScan(s); err != nil {
+			return []*submission.Subreddit{}, err
+		}
+
+		subreddits = append(subreddits, s)
+	}
+
+	return subreddits, nil
+}
+
+func (r *Repository) SubredditGetByID(ctx context.Context, id int) (*submission.Subreddit, error) {
+	s := &submission.Subreddit{}
+
+	err := r.queryRowx(ctx, "SELECT id, name FROM subreddits WHERE id=?", id).StructScan(s)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &submission.Subreddit{}, submission.ErrSubredditNotFound
+	}
+	if err != nil {
+		return &submission.Subreddit{}, err
+	}
+
+	return s, nil
+}
+
+func (r *Repository) SubredditGetByName(ctx context.Context, name string) (*submission.Subreddit, error) {
+	s := &submission.Subreddit{}
+
+	err := r.queryRowx(ctx, "SELECT id, name FROM subreddits WHERE name=?", name).StructScan(s)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &submission.Subreddit{}, submission.ErrSubredditNotFound
+	}
+	if err != nil {
+		return &submission.Subreddit{}, err
+	}
+
+	return s, nil
+}
+
+func (r *Repository) SubredditIsNameRegistered(ctx context.Context, name string) (bool, error) {
+	return r.isRegistered(ctx, "SELECT id FROM subreddits WHERE name=?", name)
+}
+
+func (r *Repository) SubredditGetStats(ctx context.Context) ([]submission.SubredditStats, error) {
+	rows, err := r.queryx(ctx, `
+SELECT sr.name as name, COUNT(sm.post_id) as submissions
+FROM subreddits AS sr
+LEFT JOIN submissions AS sm ON sr.id = sm.subreddit_id
+GROUP BY sr.name
+ORDER BY LOWER(sr.name)
+`)
+
+	if err != nil {
+		return []submission.SubredditStats{}, err
+	}
+
+	subredditStats := []submission.SubredditStats{}
+
+	for rows.Next() {
+		stats := submission.SubredditStats{}
+
+		if err := rows.StructScan(&stats); err != nil {
+			return []submission.SubredditStats{}, err
+		}
+
+		subredditStats = append(subredditStats, stats)
+	}
+
+	return subredditStats, nil
+}
+
+func (r *Repository) TagGetStats(ctx context.Context) ([]submission.TagStats, error) {
+	rows, err := r.queryx(ctx, `
+SELECT t.name as name, COUNT(st.submission_id) as submissions
+FROM tags AS t
+JOIN submission_tags AS st ON t.id = st.tag_id
+GROUP BY t.name
+ORDER BY t.name
+`)
+
+	if err != nil {
+		return []submission.TagStats{}, err
+	}
+
+	tagStats := []submission.TagStats{}
+
+	for rows.Next() {
+		stats := submission.TagStats{}
+
+		if err := rows.StructScan(&stats); err != nil {
+			return []submission.TagStats{}, err
+		}
+
+		tagStats = append(tagStats, stats)
+	}
+
+	return tagStats, nil
+}
+
+// requireSubmission returns submission.ErrSubmissionNotFound if there is no
+// Submission with the given ID.
+func (r *Repository) requireSubmission(ctx context.Context, id int) error {
+	var submissionID int
+
+	err := r.queryRowx(ctx, "SELECT id FROM submissions WHERE id=?", id).Scan(&submissionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return submission.ErrSubmissionNotFound
+	}
+
+	return err
+}
+
+// requireRowsAffected returns notFoundErr if a statement did not affect any
+// row.
+func requireRowsAffected(result sql.Result, notFoundErr error) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return notFoundErr
+	}
+
+	return nil
+}
+
+// isRegistered returns whether a query returns at least one row.
+func (r *Repository) isRegistered(ctx context.Context, query string, queryParams ...any) (bool, error) {
+	var registered int64
+
+	err := r.queryRowx(ctx, query, queryParams...).Scan(&registered)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,18 @@
+package sqlstore
+
+import (
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+type DBSearchResult struct {
+	DBSubmission
+
+	Snippet string `db:"snippet"`
+}
+
+func (r *DBSearchResult) AsSearchResult() *submission.SearchResult {
+	return &submission.SearchResult{
+		Submission: r.AsSubmission(),
+		Snippet:    r.Snippet,
+	}
+}
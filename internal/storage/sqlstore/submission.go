@@ -0,0 +1,112 @@
+package sqlstore
+
+import (
+	"database/sql"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+type DBSubmission struct {
+	ID int `db:"id"`
+
+	SubredditID int `db:"subreddit_id"`
+
+	// SubredditName is joined from the subreddits table
+	SubredditName sql.NullString `db:"subreddit_name"`
+
+	// Reddit post metadata
+	Author    string    `db:"author"`
+	Permalink string    `db:"permalink"`
+	PostID    string    `db:"post_id"`
+	PostedAt  time.Time `db:"created_utc"`
+	Score     int       `db:"score"`
+	Title     string    `db:"title"`
+
+	// Reddit post status
+	Removed         bool         `db:"removed"`
+	LastRefreshedAt sql.NullTime `db:"last_refreshed_at"`
+
+	// Attached image metadata
+	ImageDomain      string `db:"domain"`
+	ImageURL         string `db:"url"`
+	ImageNSFW        bool   `db:"over_18"`
+	ImageUnavailable bool   `db:"unavailable"`
+
+	// Local image metadata
+	ImageFilename string `db:"image_filename"`
+	ImageHeightPx int    `db:"image_height_px"`
+	ImageWidthPx  int    `db:"image_width_px"`
+
+	// AspectRatio is derived from the image size, to select images in SQL
+	AspectRatio float64 `db:"aspect_ratio"`
+
+	Rating int            `db:"rating"`
+	Tags   sql.NullString `db:"tags"`
+}
+
+func newDBSubmission(sub *submission.Submission) *DBSubmission {
+	return &DBSubmission{
+		ID:               sub.ID,
+		SubredditID:      sub.Subreddit.ID,
+		Author:           sub.Author,
+		Permalink:        sub.Permalink,
+		PostID:           sub.PostID,
+		PostedAt:         sub.PostedAt,
+		Score:            sub.Score,
+		Title:            sub.Title,
+		Removed:          sub.Removed,
+		LastRefreshedAt:  sql.NullTime{Time: sub.LastRefreshedAt, Valid: !sub.LastRefreshedAt.IsZero()},
+		ImageDomain:      sub.ImageDomain,
+		ImageURL:         sub.ImageURL,
+		ImageNSFW:        sub.ImageNSFW,
+		ImageUnavailable: sub.ImageUnavailable,
+		ImageFilename:    sub.ImageFilename,
+		ImageHeightPx:    sub.ImageHeightPx,
+		ImageWidthPx:     sub.ImageWidthPx,
+		AspectRatio:      sub.AspectRatio(),
+		Rating:           sub.Rating,
+		Tags:             sql.NullString{String: strings.Join(sub.Tags, ","), Valid: len(sub.Tags) > 0},
+	}
+}
+
+func (s *DBSubmission) AsSubmission() *submission.Submission {
+	return &submission.Submission{
+		ID: s.ID,
+		Subreddit: &submission.Subreddit{
+			ID:   s.SubredditID,
+			Name: s.SubredditName.String,
+		},
+		Author:           s.Author,
+		Permalink:        s.Permalink,
+		PostID:           s.PostID,
+		PostedAt:         s.PostedAt,
+		Score:            s.Score,
+		Title:            s.Title,
+		Removed:          s.Removed,
+		LastRefreshedAt:  s.LastRefreshedAt.Time,
+		ImageDomain:      s.ImageDomain,
+		ImageURL:         s.ImageURL,
+		ImageNSFW:        s.ImageNSFW,
+		ImageUnavailable: s.ImageUnavailable,
+		ImageFilename:    s.ImageFilename,
+		ImageHeightPx:    s.ImageHeightPx,
+		ImageWidthPx:     s.ImageWidthPx,
+		Rating:           s.Rating,
+		Tags:             s.tags(),
+	}
+}
+
+// tags returns the sorted list of tags from the aggregated tags column.
+func (s *DBSubmission) tags() []string {
+	if !s.Tags.Valid || s.Tags.String == "" {
+		return []string{}
+	}
+
+	tags := strings.Split(s.Tags.String, ",")
+	slices.Sort(tags)
+
+	return tags
+}
@@ -0,0 +1,109 @@
+package collection
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+// Collection represents a named, ordered set of Submissions curated by the
+// user.
+type Collection struct {
+	ID        int
+	Name      string
+	CreatedAt time.Time
+
+	// Cursor is the position of the last Item selected when rotating through
+	// the Collection, or zero if no Item was selected yet.
+	Cursor int
+}
+
+// Item represents a Submission belonging to a Collection.
+type Item struct {
+	// Position is the rank of this Item in the Collection, starting at 1.
+	Position int
+
+	Submission *submission.Submission
+}
+
+// NewCollection initializes and returns a new Collection.
+func NewCollection(name string) *Collection {
+	return &Collection{
+		Name:      name,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// Normalize sanitizes and normalizes all fields.
+func (c *Collection) Normalize() {
+	c.Name = strings.TrimSpace(c.Name)
+}
+
+// ValidateForAddition ensures mandatory fields are properly set when adding a
+// new Collection.
+func (c *Collection) ValidateForAddition(ctx context.Context, r ValidationRepository) error {
+	fns := []func() error{
+		c.requireDefaultID,
+		c.requireName,
+		c.ensureNameIsNotRegistered(ctx, r),
+	}
+
+	for _, fn := range fns {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// next returns the Item following the Collection's Cursor, wrapping around to
+// the first Item after the last one.
+//
+// Items MUST be sorted by position.
+func (c *Collection) next(items []*Item) (*Item, error) {
+	if len(items) == 0 {
+		return &Item{}, ErrCollectionEmpty
+	}
+
+	for _, item := range items {
+		if item.Position > c.Cursor {
+			return item, nil
+		}
+	}
+
+	return items[0], nil
+}
+
+func (c *Collection) ensureNameIsNotRegistered(ctx context.Context, r ValidationRepository) func() error {
+	return func() error {
+		registered, err := r.CollectionIsNameRegistered(ctx, c.Name)
+		if err != nil {
+			return err
+		}
+
+		if registered {
+			return ErrCollectionNameAlreadyRegistered
+		}
+
+		return nil
+	}
+}
+
+func (c *Collection) requireDefaultID() error {
+	if c.ID != 0 {
+		return ErrCollectionIDInvalid
+	}
+
+	return nil
+}
+
+func (c *Collection) requireName() error {
+	if c.Name == "" {
+		return ErrCollectionNameEmpty
+	}
+
+	return nil
+}
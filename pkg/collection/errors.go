@@ -0,0 +1,14 @@
+package collection
+
+import "errors"
+
+var (
+	ErrCollectionEmpty                 error = errors.New("collection: no items")
+	ErrCollectionIDInvalid             error = errors.New("collection: invalid ID")
+	ErrCollectionNameAlreadyRegistered error = errors.New("collection: name already registered")
+	ErrCollectionNameEmpty             error = errors.New("collection: empty name")
+	ErrCollectionNotFound              error = errors.New("collection: not found")
+
+	ErrItemAlreadyRegistered error = errors.New("collection item: submission already registered")
+	ErrItemNotFound          error = errors.New("collection item: not found")
+)
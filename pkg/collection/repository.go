@@ -0,0 +1,42 @@
+package collection
+
+import "context"
+
+// ValidationRepository provides methods for Collection validation.
+type ValidationRepository interface {
+	// CollectionIsNameRegistered returns whether this Collection was previously saved.
+	CollectionIsNameRegistered(ctx context.Context, name string) (bool, error)
+}
+
+// Repository defines the basic operations available to access and persist
+// Collections and their Items.
+type Repository interface {
+	ValidationRepository
+
+	// CollectionGetAll returns all persisted Collections.
+	CollectionGetAll(ctx context.Context) ([]*Collection, error)
+
+	// CollectionGetByName returns the Collection for a given name.
+	CollectionGetByName(ctx context.Context, name string) (*Collection, error)
+
+	// CollectionCreate creates and persists a Collection.
+	CollectionCreate(ctx context.Context, collection *Collection) error
+
+	// CollectionUpdateCursor updates the rotation cursor for a given Collection.
+	CollectionUpdateCursor(ctx context.Context, collectionID int, cursor int) error
+
+	// CollectionItemGetAll returns all Items for a given Collection, sorted by
+	// position.
+	// Only the ID of each Item's Submission is required to be set.
+	CollectionItemGetAll(ctx context.Context, collectionID int) ([]*Item, error)
+
+	// CollectionItemIsSubmissionRegistered returns whether a Submission belongs
+	// to a given Collection.
+	CollectionItemIsSubmissionRegistered(ctx context.Context, collectionID int, submissionID int) (bool, error)
+
+	// CollectionItemAdd appends a Submission to a given Collection.
+	CollectionItemAdd(ctx context.Context, collectionID int, submissionID int) error
+
+	// CollectionItemRemove removes a Submission from a given Collection.
+	CollectionItemRemove(ctx context.Context, collectionID int, submissionID int) error
+}
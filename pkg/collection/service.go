@@ -0,0 +1,159 @@
+package collection
+
+import (
+	"context"
+	"math/rand"
+
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+// Service handles domain operations for Collection management.
+type Service struct {
+	r Repository
+
+	submissionService *submission.Service
+}
+
+// NewService creates and initializes a Collection Service.
+func NewService(r Repository, submissionService *submission.Service) *Service {
+	return &Service{
+		r:                 r,
+		submissionService: submissionService,
+	}
+}
+
+// All returns all Collections.
+func (s *Service) All(ctx context.Context) ([]*Collection, error) {
+	return s.r.CollectionGetAll(ctx)
+}
+
+// ByName returns the Collection for a given name.
+func (s *Service) ByName(ctx context.Context, name string) (*Collection, error) {
+	collection := &Collection{Name: name}
+	collection.Normalize()
+
+	if err := collection.requireName(); err != nil {
+		return &Collection{}, err
+	}
+
+	return s.r.CollectionGetByName(ctx, collection.Name)
+}
+
+// Create creates a new Collection.
+func (s *Service) Create(ctx context.Context, collection *Collection) error {
+	collection.Normalize()
+
+	if err := collection.ValidateForAddition(ctx, s.r); err != nil {
+		return err
+	}
+
+	return s.r.CollectionCreate(ctx, collection)
+}
+
+// Items returns all Items for a given Collection, sorted by position.
+func (s *Service) Items(ctx context.Context, collection *Collection) ([]*Item, error) {
+	items, err := s.r.CollectionItemGetAll(ctx, collection.ID)
+	if err != nil {
+		return []*Item{}, err
+	}
+
+	for _, item := range items {
+		sub, err := s.submissionService.ByID(ctx, item.Submission.ID)
+		if err != nil {
+			return []*Item{}, err
+		}
+
+		item.Submission = sub
+	}
+
+	return items, nil
+}
+
+// Add appends a Submission to a given Collection.
+func (s *Service) Add(ctx context.Context, collection *Collection, sub *submission.Submission) error {
+	registered, err := s.r.CollectionItemIsSubmissionRegistered(ctx, collection.ID, sub.ID)
+	if err != nil {
+		return err
+	}
+
+	if registered {
+		return ErrItemAlreadyRegistered
+	}
+
+	return s.r.CollectionItemAdd(ctx, collection.ID, sub.ID)
+}
+
+// Remove removes a Submission from a given Collection.
+func (s *Service) Remove(ctx context.Context, collection *Collection, sub *submission.Submission) error {
+	registered, err := s.r.CollectionItemIsSubmissionRegistered(ctx, collection.ID, sub.ID)
+	if err != nil {
+		return err
+	}
+
+	if !registered {
+		return ErrItemNotFound
+	}
+
+	return s.r.CollectionItemRemove(ctx, collection.ID, sub.ID)
+}
+
+// Next returns the Submission following the last selected one in a given
+// Collection, and moves the Collection's cursor forward.
+//
+// Submissions whose image is unavailable are skipped.
+func (s *Service) Next(ctx context.Context, collection *Collection) (*submission.Submission, error) {
+	items, err := s.availableItems(ctx, collection)
+	if err != nil {
+		return &submission.Submission{}, err
+	}
+
+	item, err := collection.next(items)
+	if err != nil {
+		return &submission.Submission{}, err
+	}
+
+	if err := s.r.CollectionUpdateCursor(ctx, collection.ID, item.Position); err != nil {
+		return &submission.Submission{}, err
+	}
+
+	collection.Cursor = item.Position
+
+	return item.Submission, nil
+}
+
+// Random returns a randomly selected Submission from a given Collection.
+//
+// Submissions whose image is unavailable are skipped.
+func (s *Service) Random(ctx context.Context, collection *Collection) (*submission.Submission, error) {
+	items, err := s.availableItems(ctx, collection)
+	if err != nil {
+		return &submission.Submission{}, err
+	}
+
+	if len(items) == 0 {
+		return &submission.Submission{}, ErrCollectionEmpty
+	}
+
+	return items[rand.Intn(len(items))].Submission, nil
+}
+
+// availableItems returns the Items of a given Collection whose image is
+// available.
+func (s *Service) availableItems(ctx context.Context, collection *Collection) ([]*Item, error) {
+	items, err := s.Items(ctx, collection)
+	if err != nil {
+		return []*Item{}, err
+	}
+
+	available := []*Item{}
+
+	for _, item := range items {
+		if item.Submission.ImageUnavailable {
+			continue
+		}
+
+		available = append(available, item)
+	}
+
+	return available, nil
+}
@@ -0,0 +1,325 @@
+package collection_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/virtualtam/walric/internal/storage/memory"
+	"github.com/virtualtam/walric/pkg/collection"
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+// newTestRepository returns an in-memory Repository containing the given
+// Submissions, whose IDs are assigned from 1, and a Collection for each of
+// the given names, whose IDs are assigned from 1.
+func newTestRepository(t *testing.T, submissions []*submission.Submission, collectionNames ...string) *memory.Repository {
+	t.Helper()
+
+	repository := memory.NewRepository()
+
+	subreddit := &submission.Subreddit{Name: "wallpaper"}
+	if err := repository.SubredditCreate(t.Context(), subreddit); err != nil {
+		t.Fatalf("failed to create subreddit: %q", err)
+	}
+
+	for _, sub := range submissions {
+		sub.Subreddit = subreddit
+
+		if err := repository.SubmissionCreate(t.Context(), sub); err != nil {
+			t.Fatalf("failed to create submission: %q", err)
+		}
+	}
+
+	for _, name := range collectionNames {
+		if err := repository.CollectionCreate(t.Context(), collection.NewCollection(name)); err != nil {
+			t.Fatalf("failed to create collection: %q", err)
+		}
+	}
+
+	return repository
+}
+
+func TestServiceCreate(t *testing.T) {
+	testCases := []struct {
+		tname                     string
+		repositoryCollectionNames []string
+		collection                *collection.Collection
+		wantName                  string
+		wantErr                   error
+	}{
+		// nominal cases
+		{
+			tname:      "new collection",
+			collection: collection.NewCollection("Work Monitor"),
+			wantName:   "Work Monitor",
+		},
+		{
+			tname:      "new collection with surrounding whitespace",
+			collection: collection.NewCollection("  Seasonal  "),
+			wantName:   "Seasonal",
+		},
+
+		// error cases
+		{
+			tname:      "empty name",
+			collection: collection.NewCollection("   "),
+			wantErr:    collection.ErrCollectionNameEmpty,
+		},
+		{
+			tname:                     "duplicate name",
+			repositoryCollectionNames: []string{"Seasonal"},
+			collection:                collection.NewCollection("Seasonal"),
+			wantErr:                   collection.ErrCollectionNameAlreadyRegistered,
+		},
+		{
+			tname:      "non-default ID",
+			collection: &collection.Collection{ID: 8, Name: "Presentations"},
+			wantErr:    collection.ErrCollectionIDInvalid,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			repository := newTestRepository(t, []*submission.Submission{}, tc.repositoryCollectionNames...)
+			service := collection.NewService(repository, nil)
+
+			err := service.Create(t.Context(), tc.collection)
+
+			if tc.wantErr != nil {
+				if err == nil {
+					t.Error("expected an error but got none")
+				} else if !errors.Is(err, tc.wantErr) {
+					t.Errorf("want error %q, got %q", tc.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error but got %q", err)
+				return
+			}
+
+			got, err := service.ByName(t.Context(), tc.wantName)
+			if err != nil {
+				t.Errorf("expected no error but got %q", err)
+				return
+			}
+
+			if got.ID != len(tc.repositoryCollectionNames)+1 {
+				t.Errorf("want ID %d, got %d", len(tc.repositoryCollectionNames)+1, got.ID)
+			}
+		})
+	}
+}
+
+func TestServiceAddRemove(t *testing.T) {
+	seasonal := &collection.Collection{ID: 1, Name: "Seasonal"}
+
+	testCases := []struct {
+		tname         string
+		repositoryIDs []int
+		add           []int
+		remove        []int
+		wantIDs       []int
+		wantErr       error
+	}{
+		// nominal cases
+		{
+			tname:   "add to empty collection",
+			add:     []int{2, 1},
+			wantIDs: []int{2, 1},
+		},
+		{
+			tname:         "add to existing collection",
+			repositoryIDs: []int{3},
+			add:           []int{1},
+			wantIDs:       []int{3, 1},
+		},
+		{
+			tname:         "remove",
+			repositoryIDs: []int{1, 2, 3},
+			remove:        []int{2},
+			wantIDs:       []int{1, 3},
+		},
+
+		// error cases
+		{
+			tname:         "add duplicate",
+			repositoryIDs: []int{1},
+			add:           []int{1},
+			wantErr:       collection.ErrItemAlreadyRegistered,
+		},
+		{
+			tname:         "remove unknown",
+			repositoryIDs: []int{1},
+			remove:        []int{2},
+			wantErr:       collection.ErrItemNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			submissions := []*submission.Submission{
+				{PostID: "first", Title: "First"},
+				{PostID: "second", Title: "Second"},
+				{PostID: "third", Title: "Third"},
+			}
+
+			repository := newTestRepository(t, submissions, seasonal.Name)
+
+			for _, id := range tc.repositoryIDs {
+				if err := repository.CollectionItemAdd(t.Context(), seasonal.ID, id); err != nil {
+					t.Fatalf("failed to add item: %q", err)
+				}
+			}
+
+			service := collection.NewService(repository, nil)
+
+			var err error
+
+			for _, id := range tc.add {
+				if err = service.Add(t.Context(), seasonal, &submission.Submission{ID: id}); err != nil {
+					break
+				}
+			}
+
+			for _, id := range tc.remove {
+				if err = service.Remove(t.Context(), seasonal, &submission.Submission{ID: id}); err != nil {
+					break
+				}
+			}
+
+			if tc.wantErr != nil {
+				if err == nil {
+					t.Error("expected an error but got none")
+				} else if !errors.Is(err, tc.wantErr) {
+					t.Errorf("want error %q, got %q", tc.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error but got %q", err)
+				return
+			}
+
+			items, err := repository.CollectionItemGetAll(t.Context(), seasonal.ID)
+			if err != nil {
+				t.Errorf("expected no error but got %q", err)
+				return
+			}
+
+			if len(items) != len(tc.wantIDs) {
+				t.Errorf("want %d items, got %d", len(tc.wantIDs), len(items))
+				return
+			}
+
+			for index, item := range items {
+				if item.Submission.ID != tc.wantIDs[index] {
+					t.Errorf("want submission ID %d at index %d, got %d", tc.wantIDs[index], index, item.Submission.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestServiceNext(t *testing.T) {
+	testCases := []struct {
+		tname         string
+		repositoryIDs []int
+		cursor        int
+		wantPostID    string
+		wantCursor    int
+		wantErr       error
+	}{
+		// nominal cases
+		{
+			tname:         "first rotation",
+			repositoryIDs: []int{3, 1},
+			wantPostID:    "third",
+			wantCursor:    1,
+		},
+		{
+			tname:         "next item",
+			repositoryIDs: []int{3, 1},
+			cursor:        1,
+			wantPostID:    "first",
+			wantCursor:    2,
+		},
+		{
+			tname:         "wrap around",
+			repositoryIDs: []int{3, 1},
+			cursor:        2,
+			wantPostID:    "third",
+			wantCursor:    1,
+		},
+		{
+			tname:         "skip unavailable image",
+			repositoryIDs: []int{1, 2, 3},
+			cursor:        1,
+			wantPostID:    "third",
+			wantCursor:    3,
+		},
+
+		// error cases
+		{
+			tname:   "empty collection",
+			wantErr: collection.ErrCollectionEmpty,
+		},
+		{
+			tname:         "only unavailable images",
+			repositoryIDs: []int{2},
+			wantErr:       collection.ErrCollectionEmpty,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			seasonal := &collection.Collection{ID: 1, Name: "Seasonal", Cursor: tc.cursor}
+
+			submissions := []*submission.Submission{
+				{PostID: "first", Title: "First"},
+				{PostID: "second", Title: "Second", ImageUnavailable: true},
+				{PostID: "third", Title: "Third"},
+			}
+
+			repository := newTestRepository(t, submissions, seasonal.Name)
+
+			for _, id := range tc.repositoryIDs {
+				if err := repository.CollectionItemAdd(t.Context(), seasonal.ID, id); err != nil {
+					t.Fatalf("failed to add item: %q", err)
+				}
+			}
+
+			submissionService := submission.NewService(repository)
+			service := collection.NewService(repository, submissionService)
+
+			got, err := service.Next(t.Context(), seasonal)
+
+			if tc.wantErr != nil {
+				if err == nil {
+					t.Error("expected an error but got none")
+				} else if !errors.Is(err, tc.wantErr) {
+					t.Errorf("want error %q, got %q", tc.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error but got %q", err)
+				return
+			}
+
+			if got.PostID != tc.wantPostID {
+				t.Errorf("want post ID %q, got %q", tc.wantPostID, got.PostID)
+			}
+
+			if seasonal.Cursor != tc.wantCursor {
+				t.Errorf("want cursor %d, got %d", tc.wantCursor, seasonal.Cursor)
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package gather
+
+import "errors"
+
+var (
+	ErrImageCorrupted       error = errors.New("gather: corrupted image file")
+	ErrImageFilenameInvalid error = errors.New("gather: image filename does not contain a post ID")
+	ErrImageUnavailable     error = errors.New("gather: image is no longer available")
+)
@@ -1,6 +1,9 @@
 package gather
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"image"
 	_ "image/jpeg"
@@ -13,6 +16,8 @@ import (
 	"strings"
 
 	"github.com/sethjones/go-reddit/v2/reddit"
+
+	"github.com/virtualtam/walric/pkg/submission"
 )
 
 type postImage struct {
@@ -38,6 +43,13 @@ func newPostImage(subredditDir string, post *reddit.Post) (*postImage, error) {
 	}, nil
 }
 
+func newSubmissionImage(sub *submission.Submission) *postImage {
+	return &postImage{
+		url:      sub.ImageURL,
+		filePath: sub.ImageFilename,
+	}
+}
+
 // Download downloads an image locally.
 func (i *postImage) Download() error {
 	resp, err := http.Get(i.url)
@@ -46,10 +58,18 @@ func (i *postImage) Download() error {
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return fmt.Errorf("%w: %s", ErrImageUnavailable, resp.Status)
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("failed to download image: %s", resp.Status)
 	}
 
+	if isRemovedImageURL(resp.Request.URL) {
+		return fmt.Errorf("%w: redirected to %s", ErrImageUnavailable, resp.Request.URL)
+	}
+
 	out, err := os.Create(i.filePath)
 	if err != nil {
 		return err
@@ -64,24 +84,87 @@ func (i *postImage) Download() error {
 	return nil
 }
 
-// GetResolutionFromFile retrieves an image's resolution (height, width).
+// GetResolutionFromFile fully decodes the local image file and retrieves its
+// resolution (height, width).
 func (i *postImage) GetResolutionFromFile() error {
-	reader, err := os.Open(i.filePath)
+	heightPx, widthPx, err := DecodeImageFile(i.filePath)
 	if err != nil {
 		return err
 	}
 
+	i.HeightPx = heightPx
+	i.WidthPx = widthPx
+
+	return nil
+}
+
+// DecodeImageFile fully decodes an image file and returns its resolution
+// (height, width).
+//
+// Decoding the whole file, rather than its header only, ensures the image is
+// neither truncated nor corrupted. Unknown or unsupported formats are reported
+// with image.ErrFormat, and decoding failures with ErrImageCorrupted.
+func DecodeImageFile(filePath string) (int, int, error) {
+	reader, err := os.Open(filePath)
+	if err != nil {
+		return 0, 0, err
+	}
+
 	defer reader.Close()
 
-	config, _, err := image.DecodeConfig(reader)
+	recorder := &readErrorRecorder{reader: reader}
+
+	img, _, err := image.Decode(recorder)
+
+	// Failing to read the file, e.g. due to an I/O error, does not mean
+	// its contents are corrupted, although decoders may report it as such
+	if recorder.err != nil {
+		return 0, 0, recorder.err
+	}
+
+	if errors.Is(err, image.ErrFormat) {
+		return 0, 0, err
+	} else if err != nil {
+		return 0, 0, fmt.Errorf("%w: %w", ErrImageCorrupted, err)
+	}
+
+	bounds := img.Bounds()
+
+	return bounds.Dy(), bounds.Dx(), nil
+}
+
+// readErrorRecorder records the first error returned by a reader, other than
+// io.EOF.
+type readErrorRecorder struct {
+	reader io.Reader
+	err    error
+}
+
+func (r *readErrorRecorder) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if err != nil && !errors.Is(err, io.EOF) && r.err == nil {
+		r.err = err
+	}
+
+	return n, err
+}
+
+// ImageFileSHA256 returns the hex-encoded SHA-256 checksum of an image file.
+func ImageFileSHA256(filePath string) (string, error) {
+	reader, err := os.Open(filePath)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	i.HeightPx = config.Height
-	i.WidthPx = config.Width
+	defer reader.Close()
 
-	return nil
+	hash := sha256.New()
+
+	if _, err := io.Copy(hash, reader); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
 }
 
 // maybeImageURL attempts to determine whether a URL points to a JPEG or PNG
@@ -125,6 +208,17 @@ func maybeImageURL(mediaURL *url.URL) bool {
 	return true
 }
 
+// isRemovedImageURL returns whether a URL points to the placeholder image served
+// by an image hosting service in place of a removed image.
+func isRemovedImageURL(mediaURL *url.URL) bool {
+	switch mediaURL.Host {
+	case "i.imgur.com", "imgur.com":
+		return mediaURL.Path == "/removed.png"
+	}
+
+	return false
+}
+
 // isSupportedImageURL performs a HTTP HEAD request to retrieve the Content-Type
 // header for the remote file, and determine whether the type of the remote file
 // is a  supported image format.
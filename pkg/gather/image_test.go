@@ -1,8 +1,16 @@
 package gather
 
 import (
+	"bytes"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -160,3 +168,201 @@ func TestIsSupportedImageURL(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeImageFile(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 64, 48))
+
+	var jpegBuf bytes.Buffer
+	if err := jpeg.Encode(&jpegBuf, img, nil); err != nil {
+		t.Fatalf("failed to encode JPEG image: %q", err)
+	}
+
+	var pngBuf bytes.Buffer
+	if err := png.Encode(&pngBuf, img); err != nil {
+		t.Fatalf("failed to encode PNG image: %q", err)
+	}
+
+	testCases := []struct {
+		tname        string
+		data         []byte
+		wantHeightPx int
+		wantWidthPx  int
+		wantErr      error
+	}{
+		// nominal cases
+		{
+			tname:        "JPEG image",
+			data:         jpegBuf.Bytes(),
+			wantHeightPx: 48,
+			wantWidthPx:  64,
+		},
+		{
+			tname:        "PNG image",
+			data:         pngBuf.Bytes(),
+			wantHeightPx: 48,
+			wantWidthPx:  64,
+		},
+
+		// error cases
+		{
+			tname:   "truncated JPEG image",
+			data:    jpegBuf.Bytes()[:jpegBuf.Len()/2],
+			wantErr: ErrImageCorrupted,
+		},
+		{
+			tname:   "truncated PNG image",
+			data:    pngBuf.Bytes()[:pngBuf.Len()/2],
+			wantErr: ErrImageCorrupted,
+		},
+		{
+			tname:   "unsupported format",
+			data:    []byte("<html><body>Not an image</body></html>"),
+			wantErr: image.ErrFormat,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "image")
+
+			if err := os.WriteFile(filePath, tc.data, 0o644); err != nil {
+				t.Fatalf("failed to write image file: %q", err)
+			}
+
+			heightPx, widthPx, err := DecodeImageFile(filePath)
+
+			if tc.wantErr != nil {
+				if err == nil {
+					t.Error("expected an error but got none")
+				} else if !errors.Is(err, tc.wantErr) {
+					t.Errorf("want error %q, got %q", tc.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error, got %q", err)
+				return
+			}
+
+			if heightPx != tc.wantHeightPx {
+				t.Errorf("want height %d, got %d", tc.wantHeightPx, heightPx)
+			}
+			if widthPx != tc.wantWidthPx {
+				t.Errorf("want width %d, got %d", tc.wantWidthPx, widthPx)
+			}
+		})
+	}
+}
+
+func TestDecodeImageFileReadError(t *testing.T) {
+	// Reading a directory fails with an I/O error
+	dirPath := t.TempDir()
+
+	_, _, err := DecodeImageFile(dirPath)
+	if err == nil {
+		t.Fatal("expected an error but got none")
+	}
+
+	if errors.Is(err, ErrImageCorrupted) || errors.Is(err, image.ErrFormat) {
+		t.Errorf("want a read error, got %q", err)
+	}
+}
+
+func TestPostImageDownload(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/image.jpg", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("image data"))
+	})
+	mux.HandleFunc("/gone.jpg", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	testCases := []struct {
+		tname   string
+		path    string
+		wantErr error
+	}{
+		// nominal cases
+		{
+			tname: "available image",
+			path:  "/image.jpg",
+		},
+
+		// error cases
+		{
+			tname:   "unavailable image",
+			path:    "/gone.jpg",
+			wantErr: ErrImageUnavailable,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			postImage := &postImage{
+				url:      server.URL + tc.path,
+				filePath: filepath.Join(t.TempDir(), "image.jpg"),
+			}
+
+			err := postImage.Download()
+
+			if tc.wantErr != nil {
+				if err == nil {
+					t.Error("expected an error but got none")
+				} else if !errors.Is(err, tc.wantErr) {
+					t.Errorf("want error %q, got %q", tc.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+		})
+	}
+
+}
+
+func TestIsRemovedImageURL(t *testing.T) {
+	testCases := []struct {
+		tname  string
+		rawURL string
+		want   bool
+	}{
+		{
+			tname:  "Imgur placeholder",
+			rawURL: "https://i.imgur.com/removed.png",
+			want:   true,
+		},
+		{
+			tname:  "Imgur image",
+			rawURL: "https://i.imgur.com/btn0DzA.jpg",
+			want:   false,
+		},
+		{
+			tname:  "other host",
+			rawURL: "https://domain.tld/removed.png",
+			want:   false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			mediaURL, err := url.Parse(tc.rawURL)
+			if err != nil {
+				t.Fatalf("failed to parse URL: %q", err)
+			}
+
+			got := isRemovedImageURL(mediaURL)
+
+			if got != tc.want {
+				t.Errorf("want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package gather
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+const (
+	// refreshBatchSize is the maximum number of posts that can be queried
+	// with a single call to the Reddit info API endpoint.
+	refreshBatchSize = 100
+
+	redditDeletedAuthor = "[deleted]"
+)
+
+// infoListing represents the response of the Reddit info API endpoint.
+//
+// The post status fields are not exposed by the Reddit client, thus the
+// response is decoded here.
+type infoListing struct {
+	Data struct {
+		Children []struct {
+			Data infoPost `json:"data"`
+		} `json:"children"`
+	} `json:"data"`
+}
+
+type infoPost struct {
+	ID                string  `json:"id"`
+	Author            string  `json:"author"`
+	NSFW              bool    `json:"over_18"`
+	RemovedByCategory *string `json:"removed_by_category"`
+	Score             int     `json:"score"`
+	Title             string  `json:"title"`
+}
+
+// isRemoved returns whether this post was deleted by its author or removed by
+// the moderators.
+func (p *infoPost) isRemoved() bool {
+	return p.Author == redditDeletedAuthor || p.RemovedByCategory != nil
+}
+
+// Refresh retrieves up-to-date post metadata from Reddit for a list of
+// existing Submissions, and updates their score, title, NSFW and removal
+// status.
+//
+// Posts that are no longer returned by Reddit are considered as removed.
+func (s *Service) Refresh(ctx context.Context, submissions []*submission.Submission) error {
+	s.logger.Info().
+		Int("n_submissions", len(submissions)).
+		Msg("refreshing post metadata")
+
+	for start := 0; start < len(submissions); start += refreshBatchSize {
+		end := min(start+refreshBatchSize, len(submissions))
+
+		if err := s.refreshBatch(ctx, submissions[start:end]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) refreshBatch(ctx context.Context, submissions []*submission.Submission) error {
+	fullIDs := make([]string, len(submissions))
+	for index, sub := range submissions {
+		fullIDs[index] = fmt.Sprintf("t3_%s", sub.PostID)
+	}
+
+	req, err := s.client.NewRequest(
+		http.MethodGet,
+		fmt.Sprintf("api/info?id=%s", strings.Join(fullIDs, ",")),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	listing := &infoListing{}
+
+	if _, err := s.client.Do(ctx, req, listing); err != nil {
+		s.logger.Error().
+			Err(err).
+			Msg("failed to retrieve post metadata")
+		return err
+	}
+
+	posts := make(map[string]infoPost, len(listing.Data.Children))
+	for _, child := range listing.Data.Children {
+		posts[child.Data.ID] = child.Data
+	}
+
+	now := time.Now().UTC()
+
+	for _, sub := range submissions {
+		refreshLogger := s.logger.With().
+			Str("post_id", sub.PostID).
+			Logger()
+
+		post, found := posts[sub.PostID]
+
+		if found {
+			sub.Score = post.Score
+			sub.ImageNSFW = post.NSFW
+			sub.Removed = post.isRemoved()
+
+			if post.Title != "" {
+				sub.Title = post.Title
+			}
+		} else {
+			sub.Removed = true
+		}
+
+		sub.LastRefreshedAt = now
+
+		if err := s.submissionService.Update(ctx, sub); err != nil {
+			refreshLogger.Error().
+				Err(err).
+				Msg("failed to update submission")
+			return err
+		}
+
+		refreshLogger.Debug().
+			Int("score", sub.Score).
+			Bool("nsfw", sub.ImageNSFW).
+			Bool("removed", sub.Removed).
+			Msg("post metadata refreshed")
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package gather
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/sethjones/go-reddit/v2/reddit"
+
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+const testInfoResponse = `{
+  "kind": "Listing",
+  "data": {
+    "children": [
+      {
+        "kind": "t3",
+        "data": {"id": "m31aga", "author": "janedoe", "over_18": false, "removed_by_category": null, "score": 4280, "title": "Messier 31 - The Andromeda Galaxy [OC]"}
+      },
+      {
+        "kind": "t3",
+        "data": {"id": "owlsrf", "author": "johndoe", "over_18": true, "removed_by_category": "moderator", "score": 12, "title": "The Owl Nebula and Surfboard Galaxy"}
+      },
+      {
+        "kind": "t3",
+        "data": {"id": "dltdlt", "author": "[deleted]", "over_18": false, "removed_by_category": null, "score": 87, "title": "Deleted post"}
+      }
+    ]
+  }
+}`
+
+func TestServiceRefresh(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/info" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(testInfoResponse))
+	}))
+	defer server.Close()
+
+	client, err := reddit.NewReadonlyClient(reddit.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("failed to create Reddit client: %q", err)
+	}
+
+	subreddits := []*submission.Subreddit{
+		{ID: 1, Name: "astrophotography"},
+	}
+	submissions := []*submission.Submission{
+		{ID: 1, PostID: "m31aga", Subreddit: &submission.Subreddit{ID: 1}, Title: "Messier 31 - The Andromeda Galaxy", Score: 1200},
+		{ID: 2, PostID: "owlsrf", Subreddit: &submission.Subreddit{ID: 1}, Title: "The Owl Nebula and Surfboard Galaxy", Score: 10},
+		{ID: 3, PostID: "dltdlt", Subreddit: &submission.Subreddit{ID: 1}, Title: "Deleted post", Score: 85},
+		{ID: 4, PostID: "gonegn", Subreddit: &submission.Subreddit{ID: 1}, Title: "Gone post", Score: 3},
+	}
+
+	submissionService := submission.NewService(submission.NewRepositoryInMemory(submissions, subreddits))
+	service := NewService(zerolog.Nop(), client, submissionService, t.TempDir(), nil)
+
+	if err := service.Refresh(context.Background(), submissions); err != nil {
+		t.Fatalf("expected no error, got %q", err)
+	}
+
+	testCases := []struct {
+		postID      string
+		wantTitle   string
+		wantScore   int
+		wantNSFW    bool
+		wantRemoved bool
+	}{
+		{
+			postID:    "m31aga",
+			wantTitle: "Messier 31 - The Andromeda Galaxy [OC]",
+			wantScore: 4280,
+		},
+		{
+			postID:      "owlsrf",
+			wantTitle:   "The Owl Nebula and Surfboard Galaxy",
+			wantScore:   12,
+			wantNSFW:    true,
+			wantRemoved: true,
+		},
+		{
+			postID:      "dltdlt",
+			wantTitle:   "Deleted post",
+			wantScore:   87,
+			wantRemoved: true,
+		},
+		{
+			postID:      "gonegn",
+			wantTitle:   "Gone post",
+			wantScore:   3,
+			wantRemoved: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.postID, func(t *testing.T) {
+			sub, err := submissionService.ByPostID(t.Context(), tc.postID)
+			if err != nil {
+				t.Fatalf("failed to retrieve submission: %q", err)
+			}
+
+			if sub.Title != tc.wantTitle {
+				t.Errorf("want title %q, got %q", tc.wantTitle, sub.Title)
+			}
+			if sub.Score != tc.wantScore {
+				t.Errorf("want score %d, got %d", tc.wantScore, sub.Score)
+			}
+			if sub.ImageNSFW != tc.wantNSFW {
+				t.Errorf("want NSFW %t, got %t", tc.wantNSFW, sub.ImageNSFW)
+			}
+			if sub.Removed != tc.wantRemoved {
+				t.Errorf("want removed %t, got %t", tc.wantRemoved, sub.Removed)
+			}
+			if sub.LastRefreshedAt.IsZero() {
+				t.Error("expected refresh date to be set")
+			}
+		})
+	}
+}
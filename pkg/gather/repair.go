@@ -0,0 +1,239 @@
+package gather
+
+import (
+	"context"
+	"errors"
+	"image"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sourcegraph/conc/pool"
+
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+// Redownload downloads the image files for a list of existing Submissions
+// again, if they are missing or corrupted, and updates their stored resolution
+// if it changed.
+//
+// Images are downloaded to a temporary file, moved into place once decoded, so
+// that a failed download leaves the existing image file untouched.
+//
+// Submissions whose image can no longer be retrieved from its source URL are
+// marked as unavailable.
+func (s *Service) Redownload(ctx context.Context, submissions []*submission.Submission) error {
+	s.logger.Info().
+		Int("n_submissions", len(submissions)).
+		Msg("checking image files")
+
+	workerPool := pool.New().WithErrors().WithMaxGoroutines(nWorkers)
+	for _, sub := range submissions {
+		workerSubmission := sub
+		workerPool.Go(func() error {
+			return s.redownloadSubmission(ctx, workerSubmission)
+		})
+	}
+	if err := workerPool.Wait(); err != nil {
+		s.logger.Error().
+			Err(err).
+			Msg("failed to download some submissions")
+	}
+
+	return nil
+}
+
+func (s *Service) redownloadSubmission(ctx context.Context, sub *submission.Submission) error {
+	redownloadLogger := s.logger.With().
+		Str("post_id", sub.PostID).
+		Str("filepath", sub.ImageFilename).
+		Logger()
+
+	_, _, err := DecodeImageFile(sub.ImageFilename)
+	if err == nil {
+		redownloadLogger.Debug().Msg("image file is valid")
+		return nil
+	}
+
+	redownloadLogger.Debug().
+		Err(err).
+		Msg("image file is missing or corrupted")
+
+	subImage := newSubmissionImage(sub)
+	imageFilePath := subImage.filePath
+
+	if err := os.MkdirAll(filepath.Dir(imageFilePath), os.ModePerm); err != nil {
+		redownloadLogger.Error().
+			Err(err).
+			Msg("failed to create directory")
+		return err
+	}
+
+	tmpFilePath, err := createTmpImageFile(filepath.Dir(imageFilePath))
+	if err != nil {
+		redownloadLogger.Error().
+			Err(err).
+			Msg("failed to create temporary file")
+		return err
+	}
+
+	defer removeTmpImageFile(redownloadLogger, tmpFilePath)
+
+	subImage.filePath = tmpFilePath
+
+	err = subImage.Download()
+	if errors.Is(err, ErrImageUnavailable) {
+		redownloadLogger.Warn().
+			Err(err).
+			Str("post_url", sub.ImageURL).
+			Msg("image is no longer available")
+
+		return s.markSubmissionUnavailable(ctx, sub)
+	} else if err != nil {
+		redownloadLogger.Error().
+			Err(err).
+			Str("post_url", sub.ImageURL).
+			Msg("failed to download image")
+		return err
+	}
+
+	err = subImage.GetResolutionFromFile()
+	if errors.Is(err, image.ErrFormat) || errors.Is(err, ErrImageCorrupted) {
+		redownloadLogger.Warn().
+			Err(err).
+			Msg("unsupported or corrupted image file")
+		return err
+	} else if err != nil {
+		redownloadLogger.Error().
+			Err(err).
+			Msg("failed to get image resolution")
+		return err
+	}
+
+	if err := os.Rename(tmpFilePath, imageFilePath); err != nil {
+		redownloadLogger.Error().
+			Err(err).
+			Msg("failed to move image file into place")
+		return err
+	}
+
+	subImage.filePath = imageFilePath
+
+	if subImage.HeightPx != sub.ImageHeightPx || subImage.WidthPx != sub.ImageWidthPx || sub.ImageUnavailable {
+		sub.ImageHeightPx = subImage.HeightPx
+		sub.ImageWidthPx = subImage.WidthPx
+		sub.ImageUnavailable = false
+
+		if err := s.submissionService.Update(ctx, sub); err != nil {
+			redownloadLogger.Error().
+				Err(err).
+				Msg("failed to update submission")
+			return err
+		}
+	}
+
+	redownloadLogger.Info().Msg("image file downloaded")
+
+	return nil
+}
+
+func (s *Service) markSubmissionUnavailable(ctx context.Context, sub *submission.Submission) error {
+	if sub.ImageUnavailable {
+		return nil
+	}
+
+	sub.ImageUnavailable = true
+
+	if err := s.submissionService.Update(ctx, sub); err != nil {
+		s.logger.Error().
+			Err(err).
+			Str("post_id", sub.PostID).
+			Msg("failed to update submission")
+		return err
+	}
+
+	return nil
+}
+
+// Import creates Submissions for local image files that are not registered in
+// the database, by retrieving the corresponding post metadata from Reddit.
+//
+// Image files are expected to be stored under a directory named after their
+// subreddit, with a filename prefixed by the Reddit post ID, as done by
+// GatherTopImageSubmissions.
+func (s *Service) Import(ctx context.Context, filePaths []string) error {
+	s.logger.Info().
+		Int("n_files", len(filePaths)).
+		Msg("importing image files")
+
+	var errs []error
+
+	for _, filePath := range filePaths {
+		if err := s.importImageFile(ctx, filePath); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		s.logger.Error().
+			Err(err).
+			Msg("failed to import some image files")
+	}
+
+	return nil
+}
+
+func (s *Service) importImageFile(ctx context.Context, filePath string) error {
+	importLogger := s.logger.With().Str("filepath", filePath).Logger()
+
+	if IsTmpFile(filePath) {
+		importLogger.Debug().Msg("temporary download file, skipping")
+		return nil
+	}
+
+	postID, _, found := strings.Cut(filepath.Base(filePath), "-")
+	if !found || postID == "" {
+		importLogger.Error().
+			Err(ErrImageFilenameInvalid).
+			Msg("failed to determine post ID")
+		return ErrImageFilenameInvalid
+	}
+
+	subredditName := filepath.Base(filepath.Dir(filePath))
+
+	importLogger = importLogger.With().
+		Str("post_id", postID).
+		Str("subreddit", subredditName).
+		Logger()
+
+	postAndComments, _, err := s.client.Post.Get(ctx, postID)
+	if err != nil {
+		importLogger.Error().
+			Err(err).
+			Msg("failed to retrieve post")
+		return err
+	}
+
+	fileImage := &postImage{
+		url:      postAndComments.Post.URL,
+		filePath: filePath,
+	}
+
+	if err := fileImage.GetResolutionFromFile(); err != nil {
+		importLogger.Error().
+			Err(err).
+			Msg("failed to get image resolution")
+		return err
+	}
+
+	if err := s.saveSubmission(ctx, subredditName, postAndComments.Post, fileImage); err != nil {
+		importLogger.Error().
+			Err(err).
+			Msg("failed to save submission")
+		return err
+	}
+
+	importLogger.Info().Msg("submission imported to database")
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package gather
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+func TestServiceRedownloadSubmission(t *testing.T) {
+	var pngBuf bytes.Buffer
+	if err := png.Encode(&pngBuf, image.NewRGBA(image.Rect(0, 0, 64, 48))); err != nil {
+		t.Fatalf("failed to encode PNG image: %q", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/image.png", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(pngBuf.Bytes())
+	})
+	mux.HandleFunc("/corrupted.png", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(pngBuf.Bytes()[:pngBuf.Len()/2])
+	})
+	mux.HandleFunc("/gone.png", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	truncatedImageData := pngBuf.Bytes()[:pngBuf.Len()/3]
+
+	testCases := []struct {
+		tname           string
+		imageURL        string
+		imageData       []byte
+		wantImageData   []byte
+		wantUnavailable bool
+		wantErr         error
+	}{
+		// nominal cases
+		{
+			tname:         "missing image file",
+			imageURL:      server.URL + "/image.png",
+			wantImageData: pngBuf.Bytes(),
+		},
+		{
+			tname:         "corrupted image file",
+			imageURL:      server.URL + "/image.png",
+			imageData:     truncatedImageData,
+			wantImageData: pngBuf.Bytes(),
+		},
+		{
+			tname:           "image no longer available",
+			imageURL:        server.URL + "/gone.png",
+			imageData:       truncatedImageData,
+			wantImageData:   truncatedImageData,
+			wantUnavailable: true,
+		},
+
+		// error cases
+		{
+			tname:         "corrupted download",
+			imageURL:      server.URL + "/corrupted.png",
+			imageData:     truncatedImageData,
+			wantImageData: truncatedImageData,
+			wantErr:       ErrImageCorrupted,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			subredditDir := filepath.Join(t.TempDir(), "astrophotography")
+			if err := os.MkdirAll(subredditDir, os.ModePerm); err != nil {
+				t.Fatalf("failed to create directory: %q", err)
+			}
+
+			imageFilename := filepath.Join(subredditDir, "m31aga-image.png")
+
+			if tc.imageData != nil {
+				if err := os.WriteFile(imageFilename, tc.imageData, 0o644); err != nil {
+					t.Fatalf("failed to write image file: %q", err)
+				}
+			}
+
+			subreddits := []*submission.Subreddit{
+				{ID: 1, Name: "astrophotography"},
+			}
+			submissions := []*submission.Submission{
+				{
+					ID:            1,
+					PostID:        "m31aga",
+					Subreddit:     &submission.Subreddit{ID: 1},
+					Title:         "Messier 31 - The Andromeda Galaxy",
+					ImageURL:      tc.imageURL,
+					ImageFilename: imageFilename,
+				},
+			}
+
+			submissionService := submission.NewService(submission.NewRepositoryInMemory(submissions, subreddits))
+			service := NewService(zerolog.Nop(), nil, submissionService, filepath.Dir(subredditDir), nil)
+
+			err := service.redownloadSubmission(t.Context(), submissions[0])
+
+			if tc.wantErr != nil {
+				if err == nil {
+					t.Error("expected an error but got none")
+				} else if !errors.Is(err, tc.wantErr) {
+					t.Errorf("want error %q, got %q", tc.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("expected no error, got %q", err)
+			}
+
+			imageData, err := os.ReadFile(imageFilename)
+			if err != nil {
+				t.Errorf("failed to read image file: %q", err)
+			} else if !bytes.Equal(imageData, tc.wantImageData) {
+				t.Errorf("want image file %q to contain %d bytes, got %d", imageFilename, len(tc.wantImageData), len(imageData))
+			}
+
+			sub, err := submissionService.ByPostID(t.Context(), "m31aga")
+			if err != nil {
+				t.Fatalf("failed to retrieve submission: %q", err)
+			}
+
+			if sub.ImageUnavailable != tc.wantUnavailable {
+				t.Errorf("want image unavailable %t, got %t", tc.wantUnavailable, sub.ImageUnavailable)
+			}
+
+			if tc.wantErr == nil && !tc.wantUnavailable && (sub.ImageHeightPx != 48 || sub.ImageWidthPx != 64) {
+				t.Errorf("want resolution 64 x 48, got %d x %d", sub.ImageWidthPx, sub.ImageHeightPx)
+			}
+
+			tmpFilenames, err := filepath.Glob(filepath.Join(subredditDir, TmpFilePattern))
+			if err != nil {
+				t.Fatalf("failed to list temporary files: %q", err)
+			}
+			if len(tmpFilenames) > 0 {
+				t.Errorf("want no temporary files, got %q", tmpFilenames)
+			}
+		})
+	}
+}
+
+func TestServiceImportImageFile(t *testing.T) {
+	testCases := []struct {
+		tname    string
+		filename string
+		wantErr  error
+	}{
+		// nominal cases
+		{
+			tname:    "temporary download file",
+			filename: ".download-1234567890",
+		},
+
+		// error cases
+		{
+			tname:    "filename without post ID",
+			filename: "image.png",
+			wantErr:  ErrImageFilenameInvalid,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			submissionService := submission.NewService(submission.NewRepositoryInMemory([]*submission.Submission{}, []*submission.Subreddit{}))
+			service := NewService(zerolog.Nop(), nil, submissionService, t.TempDir(), nil)
+
+			err := service.importImageFile(t.Context(), filepath.Join(t.TempDir(), "astrophotography", tc.filename))
+
+			if tc.wantErr != nil {
+				if err == nil {
+					t.Error("expected an error but got none")
+				} else if !errors.Is(err, tc.wantErr) {
+					t.Errorf("want error %q, got %q", tc.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+		})
+	}
+}
@@ -17,6 +17,13 @@ import (
 
 const (
 	nWorkers = 4
+
+	// TmpFilePattern is the name pattern of the temporary files images are
+	// downloaded to, before being moved into place.
+	TmpFilePattern = ".download-*"
+
+	// imageFileMode is the permission mode of downloaded image files.
+	imageFileMode os.FileMode = 0o644
 )
 
 // Service handles domain operations for gathering image files from Reddit.
@@ -41,7 +48,7 @@ func NewService(rootLogger zerolog.Logger, client *reddit.Client, submissionServ
 	}
 }
 
-func (s *Service) filterPosts(posts []*reddit.Post) ([]*reddit.Post, error) {
+func (s *Service) filterPosts(ctx context.Context, posts []*reddit.Post) ([]*reddit.Post, error) {
 	var imagePosts []*reddit.Post
 
 	for _, post := range posts {
@@ -66,8 +73,20 @@ func (s *Service) filterPosts(posts []*reddit.Post) ([]*reddit.Post, error) {
 			continue
 		}
 
+		// check whether the post was banned
+		banned, err := s.submissionService.IsBanned(ctx, post.ID)
+		if err != nil {
+			postLogger.Error().Err(err).Msg("database: failed to query ban list")
+			return []*reddit.Post{}, err
+		}
+
+		if banned {
+			postLogger.Debug().Msg("submission banned")
+			continue
+		}
+
 		// check whether the post was already saved
-		_, err = s.submissionService.ByPostID(post.ID)
+		_, err = s.submissionService.ByPostID(ctx, post.ID)
 
 		if err == nil {
 			postLogger.Debug().Msg("submission already saved")
@@ -102,7 +121,13 @@ func (s *Service) filterPosts(posts []*reddit.Post) ([]*reddit.Post, error) {
 	return imagePosts, nil
 }
 
-func (s *Service) gatherImageSubmission(sr *submission.Subreddit, subredditName string, subredditDir string, post *reddit.Post) error {
+// gatherImageSubmission downloads the image of a Reddit post and saves the
+// corresponding Submission.
+//
+// The image is first downloaded to a temporary file, then renamed into place
+// once the Submission is saved, so that a failure never removes or overwrites
+// an image file already referenced by a Submission.
+func (s *Service) gatherImageSubmission(ctx context.Context, subredditName string, subredditDir string, post *reddit.Post) error {
 	gatherLogger := s.logger.With().Str("subreddit", subredditName).Logger()
 
 	postImage, err := newPostImage(subredditDir, post)
@@ -114,6 +139,21 @@ func (s *Service) gatherImageSubmission(sr *submission.Subreddit, subredditName
 		return err
 	}
 
+	imageFilePath := postImage.filePath
+
+	tmpFilePath, err := createTmpImageFile(subredditDir)
+	if err != nil {
+		gatherLogger.Error().
+			Err(err).
+			Str("subreddit_dir", subredditDir).
+			Msg("failed to create temporary file")
+		return err
+	}
+
+	defer removeTmpImageFile(gatherLogger, tmpFilePath)
+
+	postImage.filePath = tmpFilePath
+
 	if err := postImage.Download(); err != nil {
 		gatherLogger.Error().
 			Err(err).
@@ -122,24 +162,42 @@ func (s *Service) gatherImageSubmission(sr *submission.Subreddit, subredditName
 		return err
 	}
 
+	imageSHA256, err := ImageFileSHA256(postImage.filePath)
+	if err != nil {
+		gatherLogger.Error().
+			Err(err).
+			Str("filepath", postImage.filePath).
+			Msg("failed to compute image checksum")
+		return err
+	}
+
+	banned, err := s.submissionService.IsImageBanned(ctx, imageSHA256)
+	if err != nil {
+		gatherLogger.Error().
+			Err(err).
+			Msg("database: failed to query ban list")
+		return err
+	}
+
+	if banned {
+		gatherLogger.Info().
+			Str("post_id", post.ID).
+			Str("filepath", imageFilePath).
+			Msg("image banned")
+		return nil
+	}
+
 	err = postImage.GetResolutionFromFile()
 	if errors.Is(err, image.ErrFormat) {
 		gatherLogger.Warn().
-			Str("filepath", postImage.filePath).
+			Str("filepath", imageFilePath).
 			Msgf("unknown or unsupported image file format")
-
-		if err := os.Remove(postImage.filePath); err != nil {
-			gatherLogger.Error().
-				Err(err).
-				Str("filepath", postImage.filePath).
-				Msg("failed to remove unsupported image file")
-			return err
-		}
-
+		return nil
+	} else if errors.Is(err, ErrImageCorrupted) {
 		gatherLogger.Warn().
-			Str("filepath", postImage.filePath).
-			Msg("unsupported image file removed")
-
+			Err(err).
+			Str("filepath", imageFilePath).
+			Msgf("truncated or corrupted image file")
 		return nil
 	} else if err != nil {
 		gatherLogger.Error().
@@ -149,37 +207,23 @@ func (s *Service) gatherImageSubmission(sr *submission.Subreddit, subredditName
 		return err
 	}
 
-	imageURL, err := url.Parse(post.URL)
-	if err != nil {
+	postImage.filePath = imageFilePath
+
+	if err := s.saveSubmission(ctx, subredditName, post, postImage); err != nil {
 		gatherLogger.Error().
 			Err(err).
-			Stringer("image_url", imageURL).
-			Msg("failed to parse image URL")
+			Str("post_id", post.ID).
+			Str("post_title", post.Title).
+			Msgf("failed to save submission")
 		return err
 	}
 
-	dbSubmission := &submission.Submission{
-		Subreddit:     sr,
-		Author:        post.Author,
-		Permalink:     post.Permalink,
-		PostID:        post.ID,
-		PostedAt:      post.Created.UTC(),
-		Score:         post.Score,
-		Title:         post.Title,
-		ImageDomain:   imageURL.Host,
-		ImageURL:      post.URL,
-		ImageNSFW:     post.NSFW,
-		ImageFilename: postImage.filePath,
-		ImageHeightPx: postImage.HeightPx,
-		ImageWidthPx:  postImage.WidthPx,
-	}
-
-	if err := s.submissionService.Create(dbSubmission); err != nil {
+	if err := os.Rename(tmpFilePath, imageFilePath); err != nil {
 		gatherLogger.Error().
 			Err(err).
 			Str("post_id", post.ID).
-			Str("post_title", post.Title).
-			Msgf("failed to create submission")
+			Str("filepath", imageFilePath).
+			Msg("failed to move image file into place")
 		return err
 	}
 
@@ -190,6 +234,47 @@ func (s *Service) gatherImageSubmission(sr *submission.Subreddit, subredditName
 	return nil
 }
 
+// createTmpImageFile creates an empty temporary file in dir, where an image is
+// downloaded before being moved into place, and returns its path.
+func createTmpImageFile(dir string) (string, error) {
+	tmpFile, err := os.CreateTemp(dir, TmpFilePattern)
+	if err != nil {
+		return "", err
+	}
+
+	tmpFilePath := tmpFile.Name()
+
+	if err := tmpFile.Close(); err != nil {
+		return "", errors.Join(err, os.Remove(tmpFilePath))
+	}
+
+	// temporary files are only readable by their owner
+	if err := os.Chmod(tmpFilePath, imageFileMode); err != nil {
+		return "", errors.Join(err, os.Remove(tmpFilePath))
+	}
+
+	return tmpFilePath, nil
+}
+
+// IsTmpFile returns whether a file is a temporary file an image is being
+// downloaded to, or was left behind by an interrupted download.
+func IsTmpFile(filePath string) bool {
+	matched, _ := filepath.Match(TmpFilePattern, filepath.Base(filePath))
+
+	return matched
+}
+
+// removeTmpImageFile removes a temporary image file, unless it was moved into
+// place.
+func removeTmpImageFile(logger zerolog.Logger, tmpFilePath string) {
+	if err := os.Remove(tmpFilePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.Error().
+			Err(err).
+			Str("filepath", tmpFilePath).
+			Msg("failed to remove temporary file")
+	}
+}
+
 func (s *Service) gatherImageSubmissions(ctx context.Context, subredditName string, posts []*reddit.Post) error {
 	gatherLogger := s.logger.With().Str("subreddit", subredditName).Logger()
 
@@ -202,18 +287,11 @@ func (s *Service) gatherImageSubmissions(ctx context.Context, subredditName stri
 		return err
 	}
 
-	sr, err := s.submissionService.SubredditGetOrCreateByName(subredditName)
-	if err != nil {
-		gatherLogger.Error().
-			Err(err).
-			Msg("failed to query database")
-	}
-
 	workerPool := pool.New().WithErrors().WithMaxGoroutines(nWorkers)
 	for _, post := range posts {
 		workerPost := post
 		workerPool.Go(func() error {
-			return s.gatherImageSubmission(sr, subredditName, subredditDir, workerPost)
+			return s.gatherImageSubmission(ctx, subredditName, subredditDir, workerPost)
 		})
 	}
 	if err := workerPool.Wait(); err != nil {
@@ -253,7 +331,7 @@ func (s *Service) GatherTopImageSubmissions(ctx context.Context, subredditNames
 			Int("n_posts", len(topPosts)).
 			Msg("found top posts")
 
-		posts, err := s.filterPosts(topPosts)
+		posts, err := s.filterPosts(ctx, topPosts)
 		if err != nil {
 			gatherLogger.Error().Err(err).Msg("failed to filter posts")
 			return err
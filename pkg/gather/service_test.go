@@ -0,0 +1,157 @@
+package gather
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/sethjones/go-reddit/v2/reddit"
+
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+func TestServiceGatherImageSubmission(t *testing.T) {
+	var pngBuf bytes.Buffer
+	if err := png.Encode(&pngBuf, image.NewRGBA(image.Rect(0, 0, 64, 48))); err != nil {
+		t.Fatalf("failed to encode PNG image: %q", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/image.png", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(pngBuf.Bytes())
+	})
+	mux.HandleFunc("/corrupted.png", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(pngBuf.Bytes()[:pngBuf.Len()/2])
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	existingImageData := []byte("existing image data")
+
+	testCases := []struct {
+		tname         string
+		post          *reddit.Post
+		wantImageData []byte
+		wantSaved     bool
+		wantErr       error
+	}{
+		// nominal cases
+		{
+			tname: "new post",
+			post: &reddit.Post{
+				ID:      "m31aga",
+				Title:   "Messier 31 - The Andromeda Galaxy",
+				URL:     server.URL + "/image.png",
+				Created: &reddit.Timestamp{Time: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			wantImageData: pngBuf.Bytes(),
+			wantSaved:     true,
+		},
+		{
+			tname: "corrupted image",
+			post: &reddit.Post{
+				ID:      "owlsrf",
+				Title:   "The Owl Nebula and Surfboard Galaxy",
+				URL:     server.URL + "/corrupted.png",
+				Created: &reddit.Timestamp{Time: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+
+		// error cases
+		{
+			tname: "post already registered",
+			post: &reddit.Post{
+				ID:      "exists",
+				Title:   "Existing submission",
+				URL:     server.URL + "/image.png",
+				Created: &reddit.Timestamp{Time: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			wantImageData: existingImageData,
+			wantSaved:     true,
+			wantErr:       submission.ErrSubmissionPostIDAlreadyRegistered,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			subredditDir := filepath.Join(t.TempDir(), "astrophotography")
+			if err := os.MkdirAll(subredditDir, os.ModePerm); err != nil {
+				t.Fatalf("failed to create directory: %q", err)
+			}
+
+			existingImageFilename := filepath.Join(subredditDir, "exists-image.png")
+			if err := os.WriteFile(existingImageFilename, existingImageData, 0o644); err != nil {
+				t.Fatalf("failed to write image file: %q", err)
+			}
+
+			subreddits := []*submission.Subreddit{
+				{ID: 1, Name: "astrophotography"},
+			}
+			submissions := []*submission.Submission{
+				{
+					ID:            1,
+					PostID:        "exists",
+					Subreddit:     &submission.Subreddit{ID: 1},
+					Title:         "Existing submission",
+					ImageFilename: existingImageFilename,
+				},
+			}
+
+			submissionService := submission.NewService(submission.NewRepositoryInMemory(submissions, subreddits))
+			service := NewService(zerolog.Nop(), nil, submissionService, filepath.Dir(subredditDir), nil)
+
+			err := service.gatherImageSubmission(t.Context(), "astrophotography", subredditDir, tc.post)
+
+			if tc.wantErr != nil {
+				if err == nil {
+					t.Error("expected an error but got none")
+				} else if !errors.Is(err, tc.wantErr) {
+					t.Errorf("want error %q, got %q", tc.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("expected no error, got %q", err)
+			}
+
+			imageFilename := filepath.Join(subredditDir, tc.post.ID+"-"+filepath.Base(tc.post.URL))
+
+			imageData, err := os.ReadFile(imageFilename)
+			if tc.wantImageData == nil {
+				if !errors.Is(err, os.ErrNotExist) {
+					t.Errorf("want image file %q not to exist, got %v", imageFilename, err)
+				}
+			} else if err != nil {
+				t.Errorf("failed to read image file: %q", err)
+			} else if !bytes.Equal(imageData, tc.wantImageData) {
+				t.Errorf("want image file %q to contain %d bytes, got %d", imageFilename, len(tc.wantImageData), len(imageData))
+			}
+
+			sub, err := submissionService.ByPostID(t.Context(), tc.post.ID)
+			if !tc.wantSaved {
+				if !errors.Is(err, submission.ErrSubmissionNotFound) {
+					t.Errorf("want submission %q not to be saved, got %v", tc.post.ID, err)
+				}
+			} else if err != nil {
+				t.Errorf("want submission %q to be saved, got %q", tc.post.ID, err)
+			} else if sub.ImageFilename != imageFilename {
+				t.Errorf("want image filename %q, got %q", imageFilename, sub.ImageFilename)
+			}
+
+			tmpFilenames, err := filepath.Glob(filepath.Join(subredditDir, TmpFilePattern))
+			if err != nil {
+				t.Fatalf("failed to list temporary files: %q", err)
+			}
+			if len(tmpFilenames) > 0 {
+				t.Errorf("want no temporary files, got %q", tmpFilenames)
+			}
+		})
+	}
+}
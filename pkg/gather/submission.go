@@ -0,0 +1,53 @@
+package gather
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/sethjones/go-reddit/v2/reddit"
+
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+// newSubmission initializes a Submission from a Reddit post and its local
+// image file.
+func newSubmission(sr *submission.Subreddit, post *reddit.Post, postImage *postImage) (*submission.Submission, error) {
+	imageURL, err := url.Parse(post.URL)
+	if err != nil {
+		return &submission.Submission{}, err
+	}
+
+	return &submission.Submission{
+		Subreddit:     sr,
+		Author:        post.Author,
+		Permalink:     post.Permalink,
+		PostID:        post.ID,
+		PostedAt:      post.Created.UTC(),
+		Score:         post.Score,
+		Title:         post.Title,
+		ImageDomain:   imageURL.Host,
+		ImageURL:      post.URL,
+		ImageNSFW:     post.NSFW,
+		ImageFilename: postImage.filePath,
+		ImageHeightPx: postImage.HeightPx,
+		ImageWidthPx:  postImage.WidthPx,
+	}, nil
+}
+
+// saveSubmission creates the Subreddit of a Reddit post if needed, and the
+// Submission for its local image file, within a single transaction.
+func (s *Service) saveSubmission(ctx context.Context, subredditName string, post *reddit.Post, postImage *postImage) error {
+	return s.submissionService.WithTx(ctx, func(tx *submission.Service) error {
+		sr, err := tx.SubredditGetOrCreateByName(ctx, subredditName)
+		if err != nil {
+			return err
+		}
+
+		dbSubmission, err := newSubmission(sr, post, postImage)
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(ctx, dbSubmission)
+	})
+}
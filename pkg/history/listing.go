@@ -0,0 +1,74 @@
+package history
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+// SortKey is an Entry attribute used to sort listings.
+type SortKey string
+
+const (
+	// SortDefault sorts Entries in chronological order.
+	SortDefault SortKey = ""
+
+	// SortDate sorts Entries by date, most recent first unless the direction
+	// is set to ascending.
+	SortDate SortKey = "date"
+)
+
+// ListOptions controls the order and range of an Entry listing.
+type ListOptions struct {
+	Sort      SortKey
+	Direction submission.SortDirection
+	submission.Page
+}
+
+// Descending returns whether the listing is sorted in descending order.
+func (o *ListOptions) Descending() bool {
+	switch o.Direction {
+	case submission.SortAscending:
+		return false
+	case submission.SortDescending:
+		return true
+	}
+
+	return o.Sort == SortDate
+}
+
+// Validate ensures these ListOptions are valid.
+func (o *ListOptions) Validate() error {
+	switch o.Sort {
+	case SortDefault:
+		if o.Direction != submission.SortNatural {
+			return fmt.Errorf("%w: a direction requires a sort key", submission.ErrListSortInvalid)
+		}
+	case SortDate:
+	default:
+		return fmt.Errorf("%w %q", submission.ErrListSortInvalid, o.Sort)
+	}
+
+	if err := submission.ValidateSortDirection(o.Direction); err != nil {
+		return err
+	}
+
+	return o.Page.Validate()
+}
+
+// ParseSort parses a sort specification, formatted as `KEY[:asc|desc]`.
+func ParseSort(value string) (SortKey, submission.SortDirection, error) {
+	key, direction, _ := strings.Cut(strings.ToLower(strings.TrimSpace(value)), ":")
+
+	options := &ListOptions{
+		Sort:      SortKey(key),
+		Direction: submission.SortDirection(direction),
+	}
+
+	if err := options.Validate(); err != nil {
+		return SortDefault, submission.SortNatural, err
+	}
+
+	return options.Sort, options.Direction, nil
+}
@@ -1,14 +1,20 @@
 package history
 
+import "context"
+
 // Repository defines the basic operations available to access and persist
 // history Entries.
+//
+// The Entries returned by a Repository MUST have their Submission, and the
+// Submission's Subreddit, fully loaded.
 type Repository interface {
-	// HistoryGetAll returns all persisted Entries.
-	HistoryGetAll() ([]*Entry, error)
+	// HistoryGetAll returns the persisted Entries, sorted and paginated
+	// according to the specified ListOptions.
+	HistoryGetAll(ctx context.Context, options *ListOptions) ([]*Entry, error)
 
 	// HistoryGetCurrent returns the last chosen Entry.
-	HistoryGetCurrent() (*Entry, error)
+	HistoryGetCurrent(ctx context.Context) (*Entry, error)
 
 	// HistoryCreate creates and persists an Entry.
-	HistoryCreate(entry *Entry) error
+	HistoryCreate(ctx context.Context, entry *Entry) error
 }
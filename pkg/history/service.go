@@ -1,61 +1,35 @@
 package history
 
-import (
-	"github.com/virtualtam/walric/pkg/submission"
-)
+import "context"
 
 // Service handles domain operations for Entry history management.
 type Service struct {
 	r Repository
-
-	submissionService *submission.Service
 }
 
 // NewService creates and initializes an Entry history Service.
-func NewService(r Repository, submissionService *submission.Service) *Service {
+func NewService(r Repository) *Service {
 	return &Service{
-		r:                 r,
-		submissionService: submissionService,
+		r: r,
 	}
 }
 
-// All returns the history of all saved entries.
-func (s *Service) All() ([]*Entry, error) {
-	entries, err := s.r.HistoryGetAll()
-	if err != nil {
+// All returns the history of saved entries, sorted and paginated according to
+// the provided ListOptions.
+func (s *Service) All(ctx context.Context, options *ListOptions) ([]*Entry, error) {
+	if err := options.Validate(); err != nil {
 		return []*Entry{}, err
 	}
 
-	for _, entry := range entries {
-		submission, err := s.submissionService.ByID(entry.Submission.ID)
-		if err != nil {
-			return []*Entry{}, err
-		}
-
-		entry.Submission = submission
-	}
-
-	return entries, nil
+	return s.r.HistoryGetAll(ctx, options)
 }
 
 // Current returns the last selected history Entry.
-func (s *Service) Current() (*Entry, error) {
-	entry, err := s.r.HistoryGetCurrent()
-	if err != nil {
-		return &Entry{}, err
-	}
-
-	submission, err := s.submissionService.ByID(entry.Submission.ID)
-	if err != nil {
-		return &Entry{}, err
-	}
-
-	entry.Submission = submission
-
-	return entry, nil
+func (s *Service) Current(ctx context.Context) (*Entry, error) {
+	return s.r.HistoryGetCurrent(ctx)
 }
 
 // Save adds a new Entry to the history.
-func (s *Service) Save(entry *Entry) error {
-	return s.r.HistoryCreate(entry)
+func (s *Service) Save(ctx context.Context, entry *Entry) error {
+	return s.r.HistoryCreate(ctx, entry)
 }
@@ -1,56 +1,95 @@
-package history
+package history_test
 
 import (
 	"errors"
 	"testing"
 	"time"
 
+	"github.com/virtualtam/walric/internal/storage/memory"
+	"github.com/virtualtam/walric/pkg/history"
 	"github.com/virtualtam/walric/pkg/submission"
 )
 
+// newTestRepository returns an in-memory Repository containing a Submission
+// for each of the given post IDs, with IDs starting from 1.
+func newTestRepository(t *testing.T, postIDs ...string) *memory.Repository {
+	t.Helper()
+
+	repository := memory.NewRepository()
+
+	subreddit := &submission.Subreddit{Name: "EarthPorn"}
+	if err := repository.SubredditCreate(t.Context(), subreddit); err != nil {
+		t.Fatalf("failed to create subreddit: %q", err)
+	}
+
+	for _, postID := range postIDs {
+		if err := repository.SubmissionCreate(t.Context(), &submission.Submission{
+			PostID:    postID,
+			Subreddit: subreddit,
+			Title:     postID,
+		}); err != nil {
+			t.Fatalf("failed to create submission: %q", err)
+		}
+	}
+
+	return repository
+}
+
 func TestServiceCreate(t *testing.T) {
 	now := time.Now()
 
 	testCases := []struct {
 		tname             string
-		repositoryEntries []*Entry
-		entry             *Entry
+		repositoryEntries []*history.Entry
+		entry             *history.Entry
 		wantErr           error
 	}{
 		// nominal cases
 		{
 			tname: "new entry",
-			entry: &Entry{
+			entry: &history.Entry{
 				Date:       now,
-				Submission: &submission.Submission{ID: 856},
+				Submission: &submission.Submission{ID: 1},
 			},
 		},
 		{
 			tname: "new duplicate entry",
-			repositoryEntries: []*Entry{
+			repositoryEntries: []*history.Entry{
 				{
-					ID:         1,
 					Date:       now,
-					Submission: &submission.Submission{ID: 856},
+					Submission: &submission.Submission{ID: 1},
 				},
 			},
-			entry: &Entry{
+			entry: &history.Entry{
+				Date:       now,
+				Submission: &submission.Submission{ID: 1},
+			},
+		},
+
+		// error cases
+		{
+			tname: "unknown submission",
+			entry: &history.Entry{
 				Date:       now,
 				Submission: &submission.Submission{ID: 856},
 			},
+			wantErr: submission.ErrSubmissionNotFound,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.tname, func(t *testing.T) {
-			nEntries := len(tc.repositoryEntries)
+			repository := newTestRepository(t, "m31aga")
 
-			repository := &repositoryInMemory{
-				entries: tc.repositoryEntries,
+			for _, entry := range tc.repositoryEntries {
+				if err := repository.HistoryCreate(t.Context(), entry); err != nil {
+					t.Fatalf("failed to create entry: %q", err)
+				}
 			}
-			service := NewService(repository, nil)
 
-			err := service.Save(tc.entry)
+			service := history.NewService(repository)
+
+			err := service.Save(t.Context(), tc.entry)
 
 			if tc.wantErr != nil {
 				if err == nil {
@@ -67,13 +106,19 @@ func TestServiceCreate(t *testing.T) {
 				return
 			}
 
-			wantNEntries := nEntries + 1
-			if len(repository.entries) != wantNEntries {
-				t.Errorf("want %d entries, got %d", wantNEntries, len(repository.entries))
+			entries, err := repository.HistoryGetAll(t.Context(), &history.ListOptions{})
+			if err != nil {
+				t.Errorf("failed to retrieve entries: %q", err)
+				return
+			}
+
+			wantNEntries := len(tc.repositoryEntries) + 1
+			if len(entries) != wantNEntries {
+				t.Errorf("want %d entries, got %d", wantNEntries, len(entries))
 				return
 			}
 
-			entry, err := repository.HistoryGetCurrent()
+			entry, err := repository.HistoryGetCurrent(t.Context())
 			if err != nil {
 				t.Errorf("failed to retrieve entry: %q", err)
 				return
@@ -85,3 +130,95 @@ func TestServiceCreate(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceAll(t *testing.T) {
+	repositoryDates := []time.Time{
+		time.Date(2023, 1, 1, 8, 0, 0, 0, time.UTC),
+		time.Date(2023, 1, 2, 8, 0, 0, 0, time.UTC),
+		time.Date(2023, 1, 3, 8, 0, 0, 0, time.UTC),
+	}
+
+	testCases := []struct {
+		tname       string
+		options     history.ListOptions
+		wantPostIDs []string
+		wantErr     error
+	}{
+		// nominal cases
+		{
+			tname:       "chronological order",
+			wantPostIDs: []string{"first", "second", "third"},
+		},
+		{
+			tname:       "most recent first",
+			options:     history.ListOptions{Sort: history.SortDate},
+			wantPostIDs: []string{"third", "second", "first"},
+		},
+		{
+			tname: "second page",
+			options: history.ListOptions{
+				Sort:      history.SortDate,
+				Direction: submission.SortAscending,
+				Page:      submission.Page{Limit: 2, Offset: 2},
+			},
+			wantPostIDs: []string{"third"},
+		},
+
+		// error cases
+		{
+			tname:   "unknown sort key",
+			options: history.ListOptions{Sort: "score"},
+			wantErr: submission.ErrListSortInvalid,
+		},
+		{
+			tname:   "negative limit",
+			options: history.ListOptions{Page: submission.Page{Limit: -5}},
+			wantErr: submission.ErrListLimitInvalid,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			repository := newTestRepository(t, "first", "second", "third")
+
+			for index, date := range repositoryDates {
+				if err := repository.HistoryCreate(t.Context(), &history.Entry{
+					Date:       date,
+					Submission: &submission.Submission{ID: index + 1},
+				}); err != nil {
+					t.Fatalf("failed to create entry: %q", err)
+				}
+			}
+
+			service := history.NewService(repository)
+
+			entries, err := service.All(t.Context(), &tc.options)
+
+			if tc.wantErr != nil {
+				if err == nil {
+					t.Error("expected an error but got none")
+				} else if !errors.Is(err, tc.wantErr) {
+					t.Errorf("want error %q, got %q", tc.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error, got %q", err)
+				return
+			}
+
+			if len(entries) != len(tc.wantPostIDs) {
+				t.Errorf("want %d entries, got %d", len(tc.wantPostIDs), len(entries))
+				return
+			}
+
+			for index, wantPostID := range tc.wantPostIDs {
+				if entries[index].Submission.PostID != wantPostID {
+					t.Errorf("want entry %d with post ID %q, got %q", index, wantPostID, entries[index].Submission.PostID)
+				}
+			}
+		})
+	}
+}
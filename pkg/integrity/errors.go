@@ -0,0 +1,7 @@
+package integrity
+
+import "errors"
+
+var (
+	ErrImageFileOutsideDataDir error = errors.New("integrity: image file is outside the data directory")
+)
@@ -0,0 +1,33 @@
+package integrity
+
+import "github.com/virtualtam/walric/pkg/submission"
+
+// CorruptedImage represents a Submission whose local image file could not be
+// fully decoded.
+type CorruptedImage struct {
+	Submission *submission.Submission
+	Err        error
+}
+
+// ResolutionMismatch represents a Submission whose stored resolution differs
+// from the resolution of its local image file.
+type ResolutionMismatch struct {
+	Submission *submission.Submission
+	HeightPx   int
+	WidthPx    int
+}
+
+// Report holds the discrepancies found between the database and the data
+// directory.
+type Report struct {
+	// MissingFiles lists the Submissions whose image file does not exist.
+	MissingFiles []*submission.Submission
+
+	// OrphanFiles lists the files under the data directory that do not
+	// belong to any Submission.
+	OrphanFiles []string
+
+	// ResolutionMismatches lists the Submissions whose stored resolution
+	// does not match their image file.
+	ResolutionMismatches []*ResolutionMismatch
+}
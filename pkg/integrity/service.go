@@ -0,0 +1,358 @@
+package integrity
+
+import (
+	"context"
+	"errors"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"github.com/sourcegraph/conc/pool"
+
+	"github.com/virtualtam/walric/pkg/gather"
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+const (
+	nWorkers = 4
+
+	// QuarantineDirName is the name of the directory, relative to the data
+	// directory, where corrupted image files are moved.
+	QuarantineDirName = "quarantine"
+)
+
+// Service handles domain operations for checking the integrity of the local
+// image collection.
+type Service struct {
+	logger zerolog.Logger
+
+	submissionService *submission.Service
+	dataDir           string
+}
+
+// NewService creates and initializes a new Service.
+func NewService(rootLogger zerolog.Logger, submissionService *submission.Service, dataDir string) *Service {
+	return &Service{
+		logger: rootLogger.With().Str("service", "integrity").Logger(),
+
+		submissionService: submissionService,
+		dataDir:           dataDir,
+	}
+}
+
+// Verify fully decodes the local image file of every Submission, and returns
+// the Submissions whose file is truncated, corrupted or in an unsupported
+// format.
+//
+// Submissions whose image file is missing or cannot be read are skipped.
+func (s *Service) Verify(ctx context.Context) ([]*CorruptedImage, error) {
+	submissions, err := s.submissionService.All(ctx)
+	if err != nil {
+		return []*CorruptedImage{}, err
+	}
+
+	s.logger.Info().
+		Int("n_submissions", len(submissions)).
+		Msg("verifying image files")
+
+	workerPool := pool.NewWithResults[*CorruptedImage]().WithMaxGoroutines(nWorkers)
+
+	for _, sub := range submissions {
+		workerSubmission := sub
+		workerPool.Go(func() *CorruptedImage {
+			return s.verifySubmission(workerSubmission)
+		})
+	}
+
+	corruptedImages := []*CorruptedImage{}
+
+	for _, corruptedImage := range workerPool.Wait() {
+		if corruptedImage == nil {
+			continue
+		}
+
+		corruptedImages = append(corruptedImages, corruptedImage)
+	}
+
+	slices.SortFunc(corruptedImages, func(a, b *CorruptedImage) int {
+		return a.Submission.ID - b.Submission.ID
+	})
+
+	return corruptedImages, nil
+}
+
+func (s *Service) verifySubmission(sub *submission.Submission) *CorruptedImage {
+	verifyLogger := s.logger.With().
+		Str("post_id", sub.PostID).
+		Str("filepath", sub.ImageFilename).
+		Logger()
+
+	_, _, err := gather.DecodeImageFile(sub.ImageFilename)
+
+	if errors.Is(err, os.ErrNotExist) {
+		verifyLogger.Debug().Msg("image file not found")
+		return nil
+	}
+
+	if errors.Is(err, gather.ErrImageCorrupted) || errors.Is(err, image.ErrFormat) {
+		verifyLogger.Warn().Err(err).Msg("corrupted image file")
+
+		return &CorruptedImage{
+			Submission: sub,
+			Err:        err,
+		}
+	}
+
+	if err != nil {
+		// The file could not be read, which does not mean it is corrupted
+		verifyLogger.Error().Err(err).Msg("failed to verify image file")
+		return nil
+	}
+
+	verifyLogger.Debug().Msg("image file verified")
+
+	return nil
+}
+
+// Check reconciles the database with the data directory, and reports
+// Submissions whose image file is missing, files that do not belong to any
+// Submission, and Submissions whose stored resolution does not match their
+// image file.
+//
+// Submissions whose image is unavailable, e.g. moved to the quarantine
+// directory, are not considered: their image file is expected to be missing,
+// and is restored with redownload.
+//
+// Files located directly under the data directory, such as the database,
+// files located in the quarantine directory and temporary download files are
+// not considered.
+func (s *Service) Check(ctx context.Context) (*Report, error) {
+	submissions, err := s.submissionService.All(ctx)
+	if err != nil {
+		return &Report{}, err
+	}
+
+	report := &Report{
+		MissingFiles:         []*submission.Submission{},
+		OrphanFiles:          []string{},
+		ResolutionMismatches: []*ResolutionMismatch{},
+	}
+
+	registeredFiles := make(map[string]bool, len(submissions))
+
+	for _, sub := range submissions {
+		registeredFiles[filepath.Clean(sub.ImageFilename)] = true
+
+		checkLogger := s.logger.With().
+			Str("post_id", sub.PostID).
+			Str("filepath", sub.ImageFilename).
+			Logger()
+
+		if sub.ImageUnavailable {
+			checkLogger.Debug().Msg("image unavailable, skipping")
+			continue
+		}
+
+		heightPx, widthPx, err := imageFileResolution(sub.ImageFilename)
+		if errors.Is(err, os.ErrNotExist) {
+			checkLogger.Debug().Msg("image file not found")
+			report.MissingFiles = append(report.MissingFiles, sub)
+			continue
+		} else if err != nil {
+			checkLogger.Warn().Err(err).Msg("failed to get image resolution")
+			continue
+		}
+
+		if heightPx != sub.ImageHeightPx || widthPx != sub.ImageWidthPx {
+			checkLogger.Debug().Msg("image resolution mismatch")
+			report.ResolutionMismatches = append(report.ResolutionMismatches, &ResolutionMismatch{
+				Submission: sub,
+				HeightPx:   heightPx,
+				WidthPx:    widthPx,
+			})
+		}
+	}
+
+	quarantineDir := filepath.Join(s.dataDir, QuarantineDirName)
+
+	err = filepath.WalkDir(s.dataDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path == quarantineDir {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if filepath.Dir(path) == filepath.Clean(s.dataDir) || !d.Type().IsRegular() || gather.IsTmpFile(path) {
+			return nil
+		}
+
+		if !registeredFiles[filepath.Clean(path)] {
+			s.logger.Debug().Str("filepath", path).Msg("orphan file")
+			report.OrphanFiles = append(report.OrphanFiles, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return &Report{}, err
+	}
+
+	s.logger.Info().
+		Int("n_missing_files", len(report.MissingFiles)).
+		Int("n_orphan_files", len(report.OrphanFiles)).
+		Int("n_resolution_mismatches", len(report.ResolutionMismatches)).
+		Msg("database and data directory checked")
+
+	return report, nil
+}
+
+// DeleteSubmission deletes a Submission and its image file, if it exists.
+func (s *Service) DeleteSubmission(ctx context.Context, sub *submission.Submission) error {
+	if err := os.Remove(sub.ImageFilename); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	if err := s.submissionService.Delete(ctx, sub.ID); err != nil {
+		return err
+	}
+
+	s.logger.Info().
+		Str("post_id", sub.PostID).
+		Str("filepath", sub.ImageFilename).
+		Msg("submission deleted")
+
+	return nil
+}
+
+// Remove deletes a Submission and its image file, and bans the corresponding
+// Reddit post and image so they are never gathered again.
+func (s *Service) Remove(ctx context.Context, sub *submission.Submission) error {
+	imageSHA256, err := gather.ImageFileSHA256(sub.ImageFilename)
+	if errors.Is(err, os.ErrNotExist) {
+		s.logger.Warn().
+			Str("post_id", sub.PostID).
+			Str("filepath", sub.ImageFilename).
+			Msg("image file not found, only the post ID will be banned")
+	} else if err != nil {
+		return err
+	}
+
+	err = s.submissionService.Ban(ctx, submission.NewBan(sub, imageSHA256))
+	if err != nil && !errors.Is(err, submission.ErrBanPostIDAlreadyRegistered) {
+		return err
+	}
+
+	return s.DeleteSubmission(ctx, sub)
+}
+
+// DeleteOrphanFile deletes a file that does not belong to any Submission.
+func (s *Service) DeleteOrphanFile(filePath string) error {
+	if err := os.Remove(filePath); err != nil {
+		return err
+	}
+
+	s.logger.Info().
+		Str("filepath", filePath).
+		Msg("orphan file deleted")
+
+	return nil
+}
+
+// UpdateResolution updates the stored resolution of a Submission to match its
+// image file.
+func (s *Service) UpdateResolution(ctx context.Context, mismatch *ResolutionMismatch) error {
+	mismatch.Submission.ImageHeightPx = mismatch.HeightPx
+	mismatch.Submission.ImageWidthPx = mismatch.WidthPx
+
+	if err := s.submissionService.Update(ctx, mismatch.Submission); err != nil {
+		return err
+	}
+
+	s.logger.Info().
+		Str("post_id", mismatch.Submission.PostID).
+		Int("height_px", mismatch.HeightPx).
+		Int("width_px", mismatch.WidthPx).
+		Msg("submission resolution updated")
+
+	return nil
+}
+
+// Quarantine moves the image file for a given Submission to the quarantine
+// directory, preserving its path relative to the data directory, and marks
+// the Submission's image as unavailable so that it is no longer selected.
+//
+// The Submission keeps its image filename, so that the image can be restored
+// with redownload.
+//
+// It returns the path to the quarantined file.
+func (s *Service) Quarantine(ctx context.Context, sub *submission.Submission) (string, error) {
+	relPath, err := filepath.Rel(s.dataDir, sub.ImageFilename)
+	if err != nil {
+		return "", err
+	}
+
+	if relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return "", ErrImageFileOutsideDataDir
+	}
+
+	quarantinePath := filepath.Join(s.dataDir, QuarantineDirName, relPath)
+
+	if err := os.MkdirAll(filepath.Dir(quarantinePath), os.ModePerm); err != nil {
+		return "", err
+	}
+
+	if err := os.Rename(sub.ImageFilename, quarantinePath); err != nil {
+		return "", err
+	}
+
+	wasUnavailable := sub.ImageUnavailable
+	sub.ImageUnavailable = true
+
+	if err := s.submissionService.Update(ctx, sub); err != nil {
+		sub.ImageUnavailable = wasUnavailable
+
+		if renameErr := os.Rename(quarantinePath, sub.ImageFilename); renameErr != nil {
+			return "", errors.Join(err, renameErr)
+		}
+
+		return "", err
+	}
+
+	s.logger.Info().
+		Str("post_id", sub.PostID).
+		Str("filepath", sub.ImageFilename).
+		Str("quarantine_path", quarantinePath).
+		Msg("image file moved to quarantine")
+
+	return quarantinePath, nil
+}
+
+// imageFileResolution decodes the header of an image file and returns its
+// resolution (height, width).
+func imageFileResolution(filePath string) (int, int, error) {
+	reader, err := os.Open(filePath)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	defer reader.Close()
+
+	config, _, err := image.DecodeConfig(reader)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return config.Height, config.Width, nil
+}
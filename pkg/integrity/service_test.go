@@ -0,0 +1,282 @@
+package integrity
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/virtualtam/walric/pkg/gather"
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+func writeTestImage(t *testing.T, filePath string, truncate bool) {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 64, 48))); err != nil {
+		t.Fatalf("failed to encode PNG image: %q", err)
+	}
+
+	data := buf.Bytes()
+	if truncate {
+		data = data[:len(data)/2]
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+		t.Fatalf("failed to create directory: %q", err)
+	}
+
+	if err := os.WriteFile(filePath, data, 0o644); err != nil {
+		t.Fatalf("failed to write image file: %q", err)
+	}
+}
+
+func TestServiceVerify(t *testing.T) {
+	dataDir := t.TempDir()
+
+	validPath := filepath.Join(dataDir, "Dummy", "valid-image.png")
+	truncatedPath := filepath.Join(dataDir, "Dummy", "trunc-image.png")
+	missingPath := filepath.Join(dataDir, "Dummy", "missing-image.png")
+	unreadablePath := filepath.Join(dataDir, "Dummy", "unreadable-image.png")
+
+	writeTestImage(t, validPath, false)
+	writeTestImage(t, truncatedPath, true)
+
+	// Reading a directory fails with an I/O error
+	if err := os.MkdirAll(unreadablePath, os.ModePerm); err != nil {
+		t.Fatalf("failed to create directory: %q", err)
+	}
+
+	subreddits := []*submission.Subreddit{
+		{ID: 1, Name: "Dummy"},
+	}
+	submissions := []*submission.Submission{
+		{ID: 1, PostID: "valid", Subreddit: &submission.Subreddit{ID: 1}, Title: "Valid", ImageFilename: validPath},
+		{ID: 2, PostID: "trunc", Subreddit: &submission.Subreddit{ID: 1}, Title: "Trunc", ImageFilename: truncatedPath},
+		{ID: 3, PostID: "missing", Subreddit: &submission.Subreddit{ID: 1}, Title: "Missing", ImageFilename: missingPath},
+		{ID: 4, PostID: "unreadable", Subreddit: &submission.Subreddit{ID: 1}, Title: "Unreadable", ImageFilename: unreadablePath},
+	}
+
+	repository := submission.NewRepositoryInMemory(submissions, subreddits)
+	submissionService := submission.NewService(repository)
+	service := NewService(zerolog.Nop(), submissionService, dataDir)
+
+	corruptedImages, err := service.Verify(t.Context())
+	if err != nil {
+		t.Fatalf("expected no error, got %q", err)
+	}
+
+	if len(corruptedImages) != 1 {
+		t.Fatalf("want 1 corrupted image, got %d", len(corruptedImages))
+	}
+
+	if corruptedImages[0].Submission.PostID != "trunc" {
+		t.Errorf("want post ID %q, got %q", "trunc", corruptedImages[0].Submission.PostID)
+	}
+
+	quarantinePath, err := service.Quarantine(t.Context(), corruptedImages[0].Submission)
+	if err != nil {
+		t.Fatalf("failed to quarantine image file: %q", err)
+	}
+
+	wantQuarantinePath := filepath.Join(dataDir, QuarantineDirName, "Dummy", "trunc-image.png")
+	if quarantinePath != wantQuarantinePath {
+		t.Errorf("want quarantine path %q, got %q", wantQuarantinePath, quarantinePath)
+	}
+
+	if _, err := os.Stat(quarantinePath); err != nil {
+		t.Errorf("expected quarantined file to exist: %q", err)
+	}
+	if _, err := os.Stat(truncatedPath); !os.IsNotExist(err) {
+		t.Errorf("expected original file to be removed, got %v", err)
+	}
+
+	quarantined, err := submissionService.ByPostID(t.Context(), "trunc")
+	if err != nil {
+		t.Fatalf("expected no error, got %q", err)
+	}
+
+	if !quarantined.ImageUnavailable {
+		t.Error("want quarantined submission to be marked as unavailable")
+	}
+
+	if quarantined.ImageFilename != truncatedPath {
+		t.Errorf("want image filename %q, got %q", truncatedPath, quarantined.ImageFilename)
+	}
+}
+
+func TestServiceCheck(t *testing.T) {
+	dataDir := t.TempDir()
+
+	validPath := filepath.Join(dataDir, "Dummy", "valid-image.png")
+	mismatchPath := filepath.Join(dataDir, "Dummy", "mismatch-image.png")
+	missingPath := filepath.Join(dataDir, "Dummy", "missing-image.png")
+	orphanPath := filepath.Join(dataDir, "Dummy", "orphan-image.png")
+	quarantinedPath := filepath.Join(dataDir, QuarantineDirName, "Dummy", "trunc-image.png")
+
+	writeTestImage(t, validPath, false)
+	writeTestImage(t, mismatchPath, false)
+	writeTestImage(t, orphanPath, false)
+	writeTestImage(t, quarantinedPath, true)
+	writeTestImage(t, filepath.Join(dataDir, "Dummy", ".download-1234567890"), true)
+
+	if err := os.WriteFile(filepath.Join(dataDir, "walric.db"), []byte{}, 0o644); err != nil {
+		t.Fatalf("failed to write database file: %q", err)
+	}
+
+	subreddits := []*submission.Subreddit{
+		{ID: 1, Name: "Dummy"},
+	}
+	submissions := []*submission.Submission{
+		{ID: 1, PostID: "valid", Subreddit: &submission.Subreddit{ID: 1}, Title: "Valid", ImageFilename: validPath, ImageHeightPx: 48, ImageWidthPx: 64},
+		{ID: 2, PostID: "mismatch", Subreddit: &submission.Subreddit{ID: 1}, Title: "Mismatch", ImageFilename: mismatchPath, ImageHeightPx: 1200, ImageWidthPx: 1600},
+		{ID: 3, PostID: "missing", Subreddit: &submission.Subreddit{ID: 1}, Title: "Missing", ImageFilename: missingPath, ImageHeightPx: 48, ImageWidthPx: 64},
+	}
+
+	repository := submission.NewRepositoryInMemory(submissions, subreddits)
+	submissionService := submission.NewService(repository)
+	service := NewService(zerolog.Nop(), submissionService, dataDir)
+
+	report, err := service.Check(t.Context())
+	if err != nil {
+		t.Fatalf("expected no error, got %q", err)
+	}
+
+	if len(report.MissingFiles) != 1 || report.MissingFiles[0].PostID != "missing" {
+		t.Errorf("want missing file for post %q, got %d missing file(s)", "missing", len(report.MissingFiles))
+	}
+
+	if len(report.OrphanFiles) != 1 || report.OrphanFiles[0] != orphanPath {
+		t.Errorf("want orphan file %q, got %v", orphanPath, report.OrphanFiles)
+	}
+
+	if len(report.ResolutionMismatches) != 1 {
+		t.Fatalf("want 1 resolution mismatch, got %d", len(report.ResolutionMismatches))
+	}
+
+	mismatch := report.ResolutionMismatches[0]
+	if mismatch.Submission.PostID != "mismatch" {
+		t.Errorf("want post ID %q, got %q", "mismatch", mismatch.Submission.PostID)
+	}
+
+	if err := service.UpdateResolution(t.Context(), mismatch); err != nil {
+		t.Fatalf("failed to update resolution: %q", err)
+	}
+
+	updated, err := submissionService.ByID(t.Context(), 2)
+	if err != nil {
+		t.Fatalf("failed to retrieve submission: %q", err)
+	}
+	if updated.ImageHeightPx != 48 || updated.ImageWidthPx != 64 {
+		t.Errorf("want resolution 64 x 48, got %d x %d", updated.ImageWidthPx, updated.ImageHeightPx)
+	}
+
+	if err := service.DeleteSubmission(t.Context(), report.MissingFiles[0]); err != nil {
+		t.Fatalf("failed to delete submission: %q", err)
+	}
+	if _, err := submissionService.ByID(t.Context(), 3); err == nil {
+		t.Error("expected deleted submission to be not found")
+	}
+
+	if err := service.DeleteOrphanFile(orphanPath); err != nil {
+		t.Fatalf("failed to delete orphan file: %q", err)
+	}
+	if _, err := os.Stat(orphanPath); !os.IsNotExist(err) {
+		t.Errorf("expected orphan file to be removed, got %v", err)
+	}
+}
+
+func TestServiceCheckQuarantined(t *testing.T) {
+	dataDir := t.TempDir()
+
+	imagePath := filepath.Join(dataDir, "Dummy", "trunc-image.png")
+	writeTestImage(t, imagePath, true)
+
+	subreddits := []*submission.Subreddit{
+		{ID: 1, Name: "Dummy"},
+	}
+	submissions := []*submission.Submission{
+		{ID: 1, PostID: "trunc", Subreddit: &submission.Subreddit{ID: 1}, Title: "Truncated", ImageFilename: imagePath, ImageHeightPx: 48, ImageWidthPx: 64},
+	}
+
+	repository := submission.NewRepositoryInMemory(submissions, subreddits)
+	submissionService := submission.NewService(repository)
+	service := NewService(zerolog.Nop(), submissionService, dataDir)
+
+	if _, err := service.Quarantine(t.Context(), submissions[0]); err != nil {
+		t.Fatalf("failed to quarantine image file: %q", err)
+	}
+
+	report, err := service.Check(t.Context())
+	if err != nil {
+		t.Fatalf("expected no error, got %q", err)
+	}
+
+	if len(report.MissingFiles) != 0 {
+		t.Errorf("want no missing files, got %d missing file(s)", len(report.MissingFiles))
+	}
+
+	if len(report.OrphanFiles) != 0 {
+		t.Errorf("want no orphan files, got %v", report.OrphanFiles)
+	}
+
+	if len(report.ResolutionMismatches) != 0 {
+		t.Errorf("want no resolution mismatches, got %d", len(report.ResolutionMismatches))
+	}
+}
+
+func TestServiceRemove(t *testing.T) {
+	dataDir := t.TempDir()
+
+	imagePath := filepath.Join(dataDir, "Dummy", "remove-image.png")
+	writeTestImage(t, imagePath, false)
+
+	imageSHA256, err := gather.ImageFileSHA256(imagePath)
+	if err != nil {
+		t.Fatalf("failed to compute image checksum: %q", err)
+	}
+
+	subreddits := []*submission.Subreddit{
+		{ID: 1, Name: "Dummy"},
+	}
+	submissions := []*submission.Submission{
+		{ID: 1, PostID: "remove", Subreddit: &submission.Subreddit{ID: 1}, Title: "Remove", ImageFilename: imagePath},
+	}
+
+	repository := submission.NewRepositoryInMemory(submissions, subreddits)
+	submissionService := submission.NewService(repository)
+	service := NewService(zerolog.Nop(), submissionService, dataDir)
+
+	if err := service.Remove(t.Context(), submissions[0]); err != nil {
+		t.Fatalf("expected no error, got %q", err)
+	}
+
+	if _, err := os.Stat(imagePath); !os.IsNotExist(err) {
+		t.Errorf("expected image file to be removed, got %v", err)
+	}
+
+	if _, err := submissionService.ByPostID(t.Context(), "remove"); err == nil {
+		t.Error("expected removed submission to be not found")
+	}
+
+	banned, err := submissionService.IsBanned(t.Context(), "remove")
+	if err != nil {
+		t.Fatalf("failed to query ban list: %q", err)
+	}
+	if !banned {
+		t.Error("expected post to be banned")
+	}
+
+	imageBanned, err := submissionService.IsImageBanned(t.Context(), imageSHA256)
+	if err != nil {
+		t.Fatalf("failed to query ban list: %q", err)
+	}
+	if !imageBanned {
+		t.Error("expected image to be banned")
+	}
+}
@@ -0,0 +1,44 @@
+package monitor
+
+// AspectRatioRange represents a range of aspect ratios, expressed as
+// width / height.
+type AspectRatioRange struct {
+	Min float64
+	Max float64
+}
+
+// Contains returns whether an aspect ratio is within this range.
+func (a *AspectRatioRange) Contains(aspectRatio float64) bool {
+	return aspectRatio >= a.Min && aspectRatio <= a.Max
+}
+
+// AspectRatio returns the aspect ratio of this Resolution, expressed as
+// width / height.
+func (r *Resolution) AspectRatio() float64 {
+	return float64(r.WidthPx) / float64(r.HeightPx)
+}
+
+// CropRange returns the range of aspect ratios of the images that can fill
+// this Resolution when scaled, with at most maxCropPercent of their area being
+// cropped.
+//
+// An image whose aspect ratio differs from the monitor's is scaled to cover
+// the monitor, and loses a fraction of its area equal to
+// 1 - min(image, monitor) / max(image, monitor).
+func (r *Resolution) CropRange(maxCropPercent int) (*AspectRatioRange, error) {
+	if err := r.Validate(); err != nil {
+		return &AspectRatioRange{}, err
+	}
+
+	if maxCropPercent < 0 || maxCropPercent > 99 {
+		return &AspectRatioRange{}, ErrCropPercentInvalid
+	}
+
+	kept := 1 - float64(maxCropPercent)/100
+	aspectRatio := r.AspectRatio()
+
+	return &AspectRatioRange{
+		Min: aspectRatio * kept,
+		Max: aspectRatio / kept,
+	}, nil
+}
@@ -0,0 +1,86 @@
+package monitor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResolutionCropRange(t *testing.T) {
+	testCases := []struct {
+		tname          string
+		resolution     *Resolution
+		maxCropPercent int
+		wantContains   []float64
+		wantExcludes   []float64
+		wantErr        error
+	}{
+		// nominal cases
+		{
+			tname:          "no crop",
+			resolution:     &Resolution{HeightPx: 1080, WidthPx: 1920},
+			maxCropPercent: 0,
+			wantContains:   []float64{(&Resolution{HeightPx: 2160, WidthPx: 3840}).AspectRatio()},
+			wantExcludes:   []float64{1.6, 2.0},
+		},
+		{
+			tname:          "ultrawide monitor",
+			resolution:     &Resolution{HeightPx: 1080, WidthPx: 2560},
+			maxCropPercent: 25,
+			wantContains:   []float64{16.0 / 9.0, 21.0 / 9.0, 3.0},
+			wantExcludes:   []float64{1.0, 0.5, 3.2},
+		},
+
+		// error cases
+		{
+			tname:          "negative crop percentage",
+			resolution:     &Resolution{HeightPx: 1080, WidthPx: 1920},
+			maxCropPercent: -5,
+			wantErr:        ErrCropPercentInvalid,
+		},
+		{
+			tname:          "full crop",
+			resolution:     &Resolution{HeightPx: 1080, WidthPx: 1920},
+			maxCropPercent: 100,
+			wantErr:        ErrCropPercentInvalid,
+		},
+		{
+			tname:          "invalid resolution",
+			resolution:     &Resolution{HeightPx: 0, WidthPx: 1920},
+			maxCropPercent: 10,
+			wantErr:        ErrResolutionInvalid,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			got, err := tc.resolution.CropRange(tc.maxCropPercent)
+
+			if tc.wantErr != nil {
+				if err == nil {
+					t.Error("expected an error but got none")
+				} else if !errors.Is(err, tc.wantErr) {
+					t.Errorf("want error %q, got %q", tc.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error, got %q", err)
+				return
+			}
+
+			for _, aspectRatio := range tc.wantContains {
+				if !got.Contains(aspectRatio) {
+					t.Errorf("want range [%f, %f] to contain %f", got.Min, got.Max, aspectRatio)
+				}
+			}
+
+			for _, aspectRatio := range tc.wantExcludes {
+				if got.Contains(aspectRatio) {
+					t.Errorf("want range [%f, %f] to exclude %f", got.Min, got.Max, aspectRatio)
+				}
+			}
+		})
+	}
+}
@@ -3,5 +3,6 @@ package monitor
 import "errors"
 
 var (
-	ErrResolutionInvalid error = errors.New("submission: invalid resolution")
+	ErrCropPercentInvalid error = errors.New("monitor: invalid crop percentage")
+	ErrResolutionInvalid  error = errors.New("monitor: invalid resolution")
 )
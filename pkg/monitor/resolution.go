@@ -1,5 +1,10 @@
 package monitor
 
+import (
+	"strconv"
+	"strings"
+)
+
 // Resolution represents a monitor's resolution, in pixels.
 type Resolution struct {
 	HeightPx int
@@ -14,3 +19,33 @@ func (r *Resolution) Validate() error {
 
 	return nil
 }
+
+// ParseResolution parses a resolution formatted as WIDTHxHEIGHT, e.g.
+// "1920x1080".
+func ParseResolution(text string) (*Resolution, error) {
+	widthText, heightText, found := strings.Cut(strings.ToLower(strings.TrimSpace(text)), "x")
+	if !found {
+		return &Resolution{}, ErrResolutionInvalid
+	}
+
+	widthPx, err := strconv.Atoi(strings.TrimSpace(widthText))
+	if err != nil {
+		return &Resolution{}, ErrResolutionInvalid
+	}
+
+	heightPx, err := strconv.Atoi(strings.TrimSpace(heightText))
+	if err != nil {
+		return &Resolution{}, ErrResolutionInvalid
+	}
+
+	resolution := &Resolution{
+		HeightPx: heightPx,
+		WidthPx:  widthPx,
+	}
+
+	if err := resolution.Validate(); err != nil {
+		return &Resolution{}, err
+	}
+
+	return resolution, nil
+}
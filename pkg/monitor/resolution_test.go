@@ -0,0 +1,69 @@
+package monitor
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseResolution(t *testing.T) {
+	testCases := []struct {
+		tname   string
+		text    string
+		want    *Resolution
+		wantErr error
+	}{
+		// nominal cases
+		{
+			tname: "lowercase separator",
+			text:  "1920x1080",
+			want:  &Resolution{HeightPx: 1080, WidthPx: 1920},
+		},
+		{
+			tname: "uppercase separator and whitespace",
+			text:  " 2560 X 1440 ",
+			want:  &Resolution{HeightPx: 1440, WidthPx: 2560},
+		},
+
+		// error cases
+		{
+			tname:   "missing separator",
+			text:    "1920",
+			wantErr: ErrResolutionInvalid,
+		},
+		{
+			tname:   "non-numeric value",
+			text:    "widexhigh",
+			wantErr: ErrResolutionInvalid,
+		},
+		{
+			tname:   "zero value",
+			text:    "0x1080",
+			wantErr: ErrResolutionInvalid,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			got, err := ParseResolution(tc.text)
+
+			if tc.wantErr != nil {
+				if err == nil {
+					t.Error("expected an error but got none")
+				} else if !errors.Is(err, tc.wantErr) {
+					t.Errorf("want error %q, got %q", tc.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error, got %q", err)
+				return
+			}
+
+			if *got != *tc.want {
+				t.Errorf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package prune
+
+import "errors"
+
+var (
+	ErrFilterEmpty error = errors.New("prune: no selection criteria")
+)
@@ -0,0 +1,10 @@
+package prune
+
+import "github.com/virtualtam/walric/pkg/submission"
+
+// Plan holds the Submissions selected for pruning, and the disk space used by
+// their image files.
+type Plan struct {
+	Submissions []*submission.Submission
+	SizeBytes   int64
+}
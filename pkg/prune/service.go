@@ -0,0 +1,173 @@
+package prune
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/rs/zerolog"
+
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+const (
+	stagingDirPattern = ".prune-*"
+)
+
+// Service handles domain operations for pruning Submissions from the
+// collection.
+type Service struct {
+	logger zerolog.Logger
+
+	submissionService *submission.Service
+	dataDir           string
+}
+
+// NewService creates and initializes a new Service.
+func NewService(rootLogger zerolog.Logger, submissionService *submission.Service, dataDir string) *Service {
+	return &Service{
+		logger: rootLogger.With().Str("service", "prune").Logger(),
+
+		submissionService: submissionService,
+		dataDir:           dataDir,
+	}
+}
+
+// Plan returns the Submissions matching the provided Filter, and the disk
+// space used by their image files.
+//
+// Disliked Submissions are only selected if they were shown as wallpaper, i.e.
+// if they are present in the History.
+//
+// An empty Filter is rejected, as it would select the whole collection.
+func (s *Service) Plan(ctx context.Context, filter *submission.Filter) (*Plan, error) {
+	if filter.IsEmpty() {
+		return &Plan{}, ErrFilterEmpty
+	}
+
+	if filter.DislikedOnly {
+		filter = shownOnly(filter)
+	}
+
+	submissions, err := s.submissionService.ByFilter(ctx, filter, &submission.ListOptions{})
+	if err != nil {
+		return &Plan{}, err
+	}
+
+	plan := &Plan{
+		Submissions: submissions,
+	}
+
+	for _, sub := range submissions {
+		fileInfo, err := os.Stat(sub.ImageFilename)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return &Plan{}, err
+		}
+
+		plan.SizeBytes += fileInfo.Size()
+	}
+
+	return plan, nil
+}
+
+// shownOnly returns a copy of a Filter that only selects Submissions that were
+// shown as wallpaper.
+func shownOnly(filter *submission.Filter) *submission.Filter {
+	shownCondition := &submission.BoolCondition{Field: submission.FieldShown, Value: true}
+
+	shownFilter := *filter
+	shownFilter.Query = &submission.Query{
+		Conditions: []submission.Condition{shownCondition},
+	}
+
+	if filter.Query != nil {
+		shownFilter.Query.Conditions = append(slices.Clone(filter.Query.Conditions), shownCondition)
+	}
+
+	return &shownFilter
+}
+
+// Apply deletes the Submissions selected by a Plan, their History entries and
+// their image files.
+//
+// Image files are first moved to a staging directory, then Submissions are
+// deleted in a single operation. If any step fails, image files are restored
+// to their original location.
+func (s *Service) Apply(ctx context.Context, plan *Plan) error {
+	if len(plan.Submissions) == 0 {
+		return nil
+	}
+
+	stagingDir, err := os.MkdirTemp(s.dataDir, stagingDirPattern)
+	if err != nil {
+		return err
+	}
+
+	// stagedFiles maps staged file paths to their original location
+	stagedFiles := map[string]string{}
+
+	restore := func() {
+		restored := true
+
+		for stagedPath, originalPath := range stagedFiles {
+			if err := os.Rename(stagedPath, originalPath); err != nil {
+				s.logger.Error().
+					Err(err).
+					Str("filepath", originalPath).
+					Str("staged_path", stagedPath).
+					Msg("failed to restore image file")
+				restored = false
+			}
+		}
+
+		if !restored {
+			return
+		}
+
+		if err := os.RemoveAll(stagingDir); err != nil {
+			s.logger.Error().
+				Err(err).
+				Str("staging_dir", stagingDir).
+				Msg("failed to remove staging directory")
+		}
+	}
+
+	ids := make([]int, len(plan.Submissions))
+
+	for index, sub := range plan.Submissions {
+		ids[index] = sub.ID
+
+		stagedPath := filepath.Join(stagingDir, strconv.Itoa(sub.ID)+"-"+filepath.Base(sub.ImageFilename))
+
+		err := os.Rename(sub.ImageFilename, stagedPath)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			restore()
+			return err
+		}
+
+		stagedFiles[stagedPath] = sub.ImageFilename
+	}
+
+	if err := s.submissionService.DeleteMany(ctx, ids); err != nil {
+		restore()
+		return err
+	}
+
+	if err := os.RemoveAll(stagingDir); err != nil {
+		return err
+	}
+
+	s.logger.Info().
+		Int("n_submissions", len(plan.Submissions)).
+		Int64("size_bytes", plan.SizeBytes).
+		Msg("submissions pruned")
+
+	return nil
+}
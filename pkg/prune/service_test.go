@@ -0,0 +1,182 @@
+package prune
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/virtualtam/walric/pkg/monitor"
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+func TestServicePlanAndApply(t *testing.T) {
+	dataDir := t.TempDir()
+
+	subreddits := []*submission.Subreddit{
+		{ID: 1, Name: "EarthPorn"},
+		{ID: 2, Name: "SpacePorn"},
+	}
+
+	newSubmission := func(id int, subredditID int, postID string, postedAt time.Time, widthPx int, heightPx int, nsfw bool) *submission.Submission {
+		imageFilename := filepath.Join(dataDir, subreddits[subredditID-1].Name, postID+"-image.jpg")
+
+		if err := os.MkdirAll(filepath.Dir(imageFilename), os.ModePerm); err != nil {
+			t.Fatalf("failed to create directory: %q", err)
+		}
+		if err := os.WriteFile(imageFilename, make([]byte, 100), 0o644); err != nil {
+			t.Fatalf("failed to write image file: %q", err)
+		}
+
+		return &submission.Submission{
+			ID:            id,
+			Subreddit:     &submission.Subreddit{ID: subredditID},
+			PostID:        postID,
+			PostedAt:      postedAt,
+			Title:         postID,
+			ImageFilename: imageFilename,
+			ImageHeightPx: heightPx,
+			ImageWidthPx:  widthPx,
+			ImageNSFW:     nsfw,
+		}
+	}
+
+	old := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+	recent := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		tname       string
+		filter      *submission.Filter
+		wantPostIDs []string
+		wantErr     error
+	}{
+		// nominal cases
+		{
+			tname:       "older than",
+			filter:      &submission.Filter{PostedBefore: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+			wantPostIDs: []string{"oldsml", "oldbig"},
+		},
+		{
+			tname:       "below resolution",
+			filter:      &submission.Filter{BelowResolution: &monitor.Resolution{HeightPx: 1080, WidthPx: 1920}},
+			wantPostIDs: []string{"oldsml", "nsfwsm"},
+		},
+		{
+			tname:       "combined criteria",
+			filter:      &submission.Filter{SubredditNames: []string{"spaceporn"}, NSFW: submission.NSFWOnly},
+			wantPostIDs: []string{"nsfwsm"},
+		},
+		{
+			tname:       "disliked and shown",
+			filter:      &submission.Filter{DislikedOnly: true},
+			wantPostIDs: []string{"oldbig"},
+		},
+		{
+			tname: "disliked and shown, with a query",
+			filter: &submission.Filter{
+				DislikedOnly: true,
+				Query: &submission.Query{
+					Conditions: []submission.Condition{
+						&submission.BoolCondition{Field: submission.FieldNSFW, Value: false},
+					},
+				},
+			},
+			wantPostIDs: []string{"oldbig"},
+		},
+		{
+			tname: "disliked and never shown",
+			filter: &submission.Filter{
+				DislikedOnly: true,
+				NeverShown:   true,
+			},
+			wantPostIDs: []string{},
+		},
+
+		// error cases
+		{
+			tname:   "empty filter",
+			filter:  &submission.Filter{},
+			wantErr: ErrFilterEmpty,
+		},
+		{
+			tname:   "invalid resolution",
+			filter:  &submission.Filter{BelowResolution: &monitor.Resolution{HeightPx: -1, WidthPx: 1920}},
+			wantErr: monitor.ErrResolutionInvalid,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			submissions := []*submission.Submission{
+				newSubmission(1, 1, "oldsml", old, 1280, 720, false),
+				newSubmission(2, 1, "oldbig", old, 3840, 2160, false),
+				newSubmission(3, 2, "nsfwsm", recent, 1280, 720, true),
+				newSubmission(4, 2, "newbig", recent, 3840, 2160, false),
+			}
+
+			submissions[1].Rating = submission.RatingDisliked
+			submissions[3].Rating = submission.RatingDisliked
+
+			repository := submission.NewRepositoryInMemory(submissions, subreddits)
+			repository.RecordSelection(2, recent)
+			submissionService := submission.NewService(repository)
+			service := NewService(zerolog.Nop(), submissionService, dataDir)
+
+			plan, err := service.Plan(t.Context(), tc.filter)
+
+			if tc.wantErr != nil {
+				if err == nil {
+					t.Error("expected an error but got none")
+				} else if !errors.Is(err, tc.wantErr) {
+					t.Errorf("want error %q, got %q", tc.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("expected no error, got %q", err)
+			}
+
+			if len(plan.Submissions) != len(tc.wantPostIDs) {
+				t.Fatalf("want %d submissions, got %d", len(tc.wantPostIDs), len(plan.Submissions))
+			}
+
+			for index, sub := range plan.Submissions {
+				if sub.PostID != tc.wantPostIDs[index] {
+					t.Errorf("want post ID %q at index %d, got %q", tc.wantPostIDs[index], index, sub.PostID)
+				}
+			}
+
+			wantSizeBytes := int64(100 * len(tc.wantPostIDs))
+			if plan.SizeBytes != wantSizeBytes {
+				t.Errorf("want %d bytes, got %d", wantSizeBytes, plan.SizeBytes)
+			}
+
+			if err := service.Apply(t.Context(), plan); err != nil {
+				t.Fatalf("failed to apply plan: %q", err)
+			}
+
+			for _, sub := range plan.Submissions {
+				if _, err := submissionService.ByID(t.Context(), sub.ID); !errors.Is(err, submission.ErrSubmissionNotFound) {
+					t.Errorf("want submission %q to be deleted, got %v", sub.PostID, err)
+				}
+				if _, err := os.Stat(sub.ImageFilename); !os.IsNotExist(err) {
+					t.Errorf("want image file %q to be deleted, got %v", sub.ImageFilename, err)
+				}
+			}
+
+			remaining, err := submissionService.All(t.Context())
+			if err != nil {
+				t.Fatalf("failed to retrieve submissions: %q", err)
+			}
+
+			if len(remaining) != 4-len(tc.wantPostIDs) {
+				t.Errorf("want %d remaining submissions, got %d", 4-len(tc.wantPostIDs), len(remaining))
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package repositorytest
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/virtualtam/walric/pkg/history"
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+// seedHistory creates the fixture Subreddits and Submissions, and a History
+// holding p1, p5 and p2 in chronological order, where p2 was added first.
+func seedHistory(t *testing.T, r Repository) *fixture {
+	t.Helper()
+
+	f := seed(t, r)
+
+	createEntry(t, r, f.submissions["p2"], time.Date(2023, 4, 3, 8, 0, 0, 0, time.UTC))
+	createEntry(t, r, f.submissions["p1"], time.Date(2023, 4, 1, 8, 0, 0, 0, time.UTC))
+	createEntry(t, r, f.submissions["p5"], time.Date(2023, 4, 2, 8, 0, 0, 0, time.UTC))
+
+	return f
+}
+
+func testHistoryCreate(t *testing.T, newRepository func(t *testing.T) Repository) {
+	testCases := []struct {
+		tname        string
+		submissionID func(f *fixture) int
+		wantErr      error
+	}{
+		// nominal cases
+		{
+			tname:        "new entry",
+			submissionID: func(f *fixture) int { return f.submissions["p3"].ID },
+		},
+		{
+			tname:        "new duplicate entry",
+			submissionID: func(f *fixture) int { return f.submissions["p1"].ID },
+		},
+
+		// error cases
+		{
+			tname:        "unknown submission",
+			submissionID: func(f *fixture) int { return 9999 },
+			wantErr:      submission.ErrSubmissionNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			r := newRepository(t)
+			f := seedHistory(t, r)
+
+			date := time.Date(2023, 4, 4, 8, 0, 0, 0, time.UTC)
+
+			err := r.HistoryCreate(t.Context(), &history.Entry{
+				Date:       date,
+				Submission: &submission.Submission{ID: tc.submissionID(f)},
+			})
+			if !assertError(t, err, tc.wantErr) {
+				return
+			}
+
+			entry, err := r.HistoryGetCurrent(t.Context())
+			if !assertError(t, err, nil) {
+				return
+			}
+
+			if entry.Submission.ID != tc.submissionID(f) {
+				t.Errorf("want submission ID %d, got %d", tc.submissionID(f), entry.Submission.ID)
+			}
+
+			if !entry.Date.Equal(date) {
+				t.Errorf("want date %q, got %q", date, entry.Date)
+			}
+		})
+	}
+}
+
+func testHistoryGetAll(t *testing.T, newRepository func(t *testing.T) Repository) {
+	r := newRepository(t)
+	f := seedHistory(t, r)
+
+	testCases := []struct {
+		tname       string
+		options     history.ListOptions
+		wantPostIDs []string
+	}{
+		{
+			tname:       "chronological order",
+			wantPostIDs: []string{"p1", "p5", "p2"},
+		},
+		{
+			tname:       "most recent first",
+			options:     history.ListOptions{Sort: history.SortDate},
+			wantPostIDs: []string{"p2", "p5", "p1"},
+		},
+		{
+			tname: "second page",
+			options: history.ListOptions{
+				Sort:      history.SortDate,
+				Direction: submission.SortAscending,
+				Page:      submission.Page{Limit: 2, Offset: 2},
+			},
+			wantPostIDs: []string{"p2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			entries, err := r.HistoryGetAll(t.Context(), &tc.options)
+			if !assertError(t, err, nil) {
+				return
+			}
+
+			assertEntryPostIDs(t, entries, tc.wantPostIDs)
+
+			for _, entry := range entries {
+				want := f.submissions[entry.Submission.PostID]
+
+				if entry.Submission.ID != want.ID || entry.Submission.Title != want.Title {
+					t.Errorf("want submission %d %q, got %d %q", want.ID, want.Title, entry.Submission.ID, entry.Submission.Title)
+				}
+
+				assertSubredditLoaded(t, entry.Submission, want.Subreddit)
+			}
+		})
+	}
+}
+
+func testHistoryGetCurrent(t *testing.T, newRepository func(t *testing.T) Repository) {
+	t.Run("empty history", func(t *testing.T) {
+		r := newRepository(t)
+
+		_, err := r.HistoryGetCurrent(t.Context())
+		assertError(t, err, history.ErrNotFound)
+	})
+
+	t.Run("most recent entry", func(t *testing.T) {
+		r := newRepository(t)
+		f := seedHistory(t, r)
+
+		entry, err := r.HistoryGetCurrent(t.Context())
+		if !assertError(t, err, nil) {
+			return
+		}
+
+		assertEntryPostIDs(t, []*history.Entry{entry}, []string{"p2"})
+		assertSubredditLoaded(t, entry.Submission, f.subreddits["EarthPorn"])
+
+		wantDate := time.Date(2023, 4, 3, 8, 0, 0, 0, time.UTC)
+		if !entry.Date.Equal(wantDate) {
+			t.Errorf("want date %q, got %q", wantDate, entry.Date)
+		}
+	})
+
+	t.Run("last entry at the most recent date", func(t *testing.T) {
+		r := newRepository(t)
+		f := seedHistory(t, r)
+
+		createEntry(t, r, f.submissions["p3"], time.Date(2023, 4, 3, 8, 0, 0, 0, time.UTC))
+
+		entry, err := r.HistoryGetCurrent(t.Context())
+		if !assertError(t, err, nil) {
+			return
+		}
+
+		assertEntryPostIDs(t, []*history.Entry{entry}, []string{"p3"})
+	})
+}
+
+// assertEntryPostIDs reports whether history Entries have Submissions with the
+// expected post IDs, in order.
+func assertEntryPostIDs(t *testing.T, entries []*history.Entry, wantPostIDs []string) {
+	t.Helper()
+
+	postIDs := make([]string, len(entries))
+	for index, entry := range entries {
+		postIDs[index] = entry.Submission.PostID
+	}
+
+	if !slices.Equal(postIDs, wantPostIDs) {
+		t.Errorf("want entry post IDs %q, got %q", wantPostIDs, postIDs)
+	}
+}
@@ -0,0 +1,268 @@
+// Package repositorytest provides a behavioral test suite that implementations
+// of the submission and history Repositories must pass.
+package repositorytest
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/virtualtam/walric/pkg/history"
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+// Repository is the combination of Repositories exercised by the test suite.
+type Repository interface {
+	submission.Repository
+	history.Repository
+}
+
+// Run runs the test suite, where newRepository MUST return an empty
+// Repository for each test.
+func Run(t *testing.T, newRepository func(t *testing.T) Repository) {
+	t.Helper()
+
+	tests := []struct {
+		tname string
+		fn    func(t *testing.T, newRepository func(t *testing.T) Repository)
+	}{
+		{"Ban", testBan},
+		{"Subreddit", testSubreddit},
+		{"SubredditGetStats", testSubredditGetStats},
+		{"SubmissionCreate", testSubmissionCreate},
+		{"SubmissionUpdate", testSubmissionUpdate},
+		{"SubmissionRate", testSubmissionRate},
+		{"SubmissionTags", testSubmissionTags},
+		{"SubmissionDelete", testSubmissionDelete},
+		{"SubmissionDeleteMany", testSubmissionDeleteMany},
+		{"SubmissionGetByFilter", testSubmissionGetByFilter},
+		{"SubmissionGetByMinResolution", testSubmissionGetByMinResolution},
+		{"SubmissionSearch", testSubmissionSearch},
+		{"SubmissionGetRandom", testSubmissionGetRandom},
+		{"SubmissionGetRandomCandidates", testSubmissionGetRandomCandidates},
+		{"WithTx", testWithTx},
+		{"HistoryCreate", testHistoryCreate},
+		{"HistoryGetAll", testHistoryGetAll},
+		{"HistoryGetCurrent", testHistoryGetCurrent},
+	}
+
+	for _, test := range tests {
+		t.Run(test.tname, func(t *testing.T) {
+			test.fn(t, newRepository)
+		})
+	}
+}
+
+// fixtureSubredditNames lists the Subreddits created by seed, which are
+// sorted as "CityPorn", "EarthPorn", "wallpapers" regardless of case.
+var fixtureSubredditNames = []string{"EarthPorn", "wallpapers", "CityPorn"}
+
+// fixtureSubmissions lists the Submissions created by seed, in creation order.
+//
+// Sorted by Subreddit name, posting date and ID, they are: p5, p1, p2, p4, p3.
+var fixtureSubmissions = []struct {
+	subredditName string
+	submission    submission.Submission
+}{
+	{
+		subredditName: "EarthPorn",
+		submission: submission.Submission{
+			Author:        "alice",
+			PostID:        "p1",
+			PostedAt:      time.Date(2023, 1, 1, 8, 0, 0, 0, time.UTC),
+			Score:         100,
+			Title:         "Misty mountains at dawn",
+			ImageFilename: "p1.jpg",
+			ImageHeightPx: 2160,
+			ImageWidthPx:  3840,
+		},
+	},
+	{
+		subredditName: "EarthPorn",
+		submission: submission.Submission{
+			Author:        "bob",
+			PostID:        "p2",
+			PostedAt:      time.Date(2023, 2, 1, 8, 0, 0, 0, time.UTC),
+			Score:         50,
+			Title:         "Frozen lake under the aurora",
+			ImageFilename: "p2.jpg",
+			ImageHeightPx: 1080,
+			ImageWidthPx:  1920,
+		},
+	},
+	{
+		subredditName: "wallpapers",
+		submission: submission.Submission{
+			Author:        "carol",
+			PostID:        "p3",
+			PostedAt:      time.Date(2023, 3, 1, 8, 0, 0, 0, time.UTC),
+			Score:         300,
+			Title:         "Neon skyline at night",
+			ImageNSFW:     true,
+			ImageFilename: "p3.jpg",
+			ImageHeightPx: 1440,
+			ImageWidthPx:  2560,
+		},
+	},
+	{
+		subredditName: "wallpapers",
+		submission: submission.Submission{
+			Author:           "alice",
+			PostID:           "p4",
+			PostedAt:         time.Date(2023, 1, 15, 8, 0, 0, 0, time.UTC),
+			Score:            10,
+			Title:            "Abstract waves",
+			ImageUnavailable: true,
+			ImageFilename:    "p4.jpg",
+			ImageHeightPx:    720,
+			ImageWidthPx:     1280,
+		},
+	},
+	{
+		subredditName: "CityPorn",
+		submission: submission.Submission{
+			Author:        "dave",
+			PostID:        "p5",
+			PostedAt:      time.Date(2022, 12, 1, 8, 0, 0, 0, time.UTC),
+			Score:         200,
+			Title:         "Rainy streets of Tokyo",
+			ImageFilename: "p5.jpg",
+			ImageHeightPx: 2160,
+			ImageWidthPx:  3840,
+		},
+	},
+}
+
+// fixture holds the persisted Subreddits and Submissions created by seed.
+type fixture struct {
+	subreddits  map[string]*submission.Subreddit
+	submissions map[string]*submission.Submission
+}
+
+// seed creates the fixture Subreddits and Submissions.
+func seed(t *testing.T, r Repository) *fixture {
+	t.Helper()
+
+	f := &fixture{
+		subreddits:  map[string]*submission.Subreddit{},
+		submissions: map[string]*submission.Submission{},
+	}
+
+	for _, name := range fixtureSubredditNames {
+		f.subreddits[name] = createSubreddit(t, r, name)
+	}
+
+	for _, fixtureSubmission := range fixtureSubmissions {
+		s := fixtureSubmission.submission
+		s.Subreddit = f.subreddits[fixtureSubmission.subredditName]
+		s.Permalink = "/r/" + s.Subreddit.Name + "/comments/" + s.PostID
+		s.ImageDomain = "i.redd.it"
+		s.ImageURL = "https://i.redd.it/" + s.ImageFilename
+
+		f.submissions[s.PostID] = createSubmission(t, r, &s)
+	}
+
+	return f
+}
+
+// createSubreddit persists a Subreddit, and returns it as persisted.
+func createSubreddit(t *testing.T, r Repository, name string) *submission.Subreddit {
+	t.Helper()
+
+	if err := r.SubredditCreate(t.Context(), &submission.Subreddit{Name: name}); err != nil {
+		t.Fatalf("failed to create subreddit %q: %q", name, err)
+	}
+
+	subreddit, err := r.SubredditGetByName(t.Context(), name)
+	if err != nil {
+		t.Fatalf("failed to retrieve subreddit %q: %q", name, err)
+	}
+
+	return subreddit
+}
+
+// createSubmission persists a Submission, and returns it as persisted.
+func createSubmission(t *testing.T, r Repository, s *submission.Submission) *submission.Submission {
+	t.Helper()
+
+	if err := r.SubmissionCreate(t.Context(), s); err != nil {
+		t.Fatalf("failed to create submission %q: %q", s.PostID, err)
+	}
+
+	return getSubmission(t, r, s.PostID)
+}
+
+// getSubmission returns the persisted Submission for a given post ID.
+func getSubmission(t *testing.T, r Repository, postID string) *submission.Submission {
+	t.Helper()
+
+	s, err := r.SubmissionGetByPostID(t.Context(), postID)
+	if err != nil {
+		t.Fatalf("failed to retrieve submission %q: %q", postID, err)
+	}
+
+	return s
+}
+
+// createEntry adds a Submission to the History at a given date.
+func createEntry(t *testing.T, r Repository, s *submission.Submission, date time.Time) {
+	t.Helper()
+
+	if err := r.HistoryCreate(t.Context(), &history.Entry{Date: date, Submission: s}); err != nil {
+		t.Fatalf("failed to create history entry for submission %q: %q", s.PostID, err)
+	}
+}
+
+// assertError reports whether err matches wantErr, and whether the test case
+// may continue.
+func assertError(t *testing.T, err error, wantErr error) bool {
+	t.Helper()
+
+	if wantErr != nil {
+		if err == nil {
+			t.Error("expected an error but got none")
+		} else if !errors.Is(err, wantErr) {
+			t.Errorf("want error %q, got %q", wantErr, err)
+		}
+
+		return false
+	}
+
+	if err != nil {
+		t.Errorf("expected no error, got %q", err)
+		return false
+	}
+
+	return true
+}
+
+// assertPostIDs reports whether Submissions have the expected post IDs, in
+// order.
+func assertPostIDs(t *testing.T, submissions []*submission.Submission, wantPostIDs []string) {
+	t.Helper()
+
+	postIDs := make([]string, len(submissions))
+	for index, s := range submissions {
+		postIDs[index] = s.PostID
+	}
+
+	if !slices.Equal(postIDs, wantPostIDs) {
+		t.Errorf("want post IDs %q, got %q", wantPostIDs, postIDs)
+	}
+}
+
+// assertSubredditLoaded reports whether a Submission's Subreddit is fully
+// loaded.
+func assertSubredditLoaded(t *testing.T, s *submission.Submission, want *submission.Subreddit) {
+	t.Helper()
+
+	if s.Subreddit == nil {
+		t.Errorf("want subreddit %q for submission %q, got none", want.Name, s.PostID)
+		return
+	}
+
+	if s.Subreddit.ID != want.ID || s.Subreddit.Name != want.Name {
+		t.Errorf("want subreddit %d %q for submission %q, got %d %q", want.ID, want.Name, s.PostID, s.Subreddit.ID, s.Subreddit.Name)
+	}
+}
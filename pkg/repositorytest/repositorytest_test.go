@@ -0,0 +1,100 @@
+package repositorytest_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/virtualtam/walric/pkg/history"
+	"github.com/virtualtam/walric/pkg/repositorytest"
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+var _ repositorytest.Repository = &repositoryInMemory{}
+
+// repositoryInMemory provides an in-memory History on top of a
+// submission.RepositoryInMemory, recording the selection of each Entry's
+// Submission.
+type repositoryInMemory struct {
+	*submission.RepositoryInMemory
+
+	entryCurrentID int
+	entries        []*history.Entry
+}
+
+func newRepositoryInMemory(t *testing.T) repositorytest.Repository {
+	return &repositoryInMemory{
+		RepositoryInMemory: submission.NewRepositoryInMemory([]*submission.Submission{}, []*submission.Subreddit{}),
+		entryCurrentID:     1,
+	}
+}
+
+func (r *repositoryInMemory) HistoryGetAll(ctx context.Context, options *history.ListOptions) ([]*history.Entry, error) {
+	entries, err := r.loadEntries(ctx)
+	if err != nil {
+		return []*history.Entry{}, err
+	}
+
+	if options.Descending() {
+		slices.Reverse(entries)
+	}
+
+	return submission.PageItems(entries, options.Page), nil
+}
+
+func (r *repositoryInMemory) HistoryGetCurrent(ctx context.Context) (*history.Entry, error) {
+	entries, err := r.loadEntries(ctx)
+	if err != nil {
+		return &history.Entry{}, err
+	}
+
+	if len(entries) == 0 {
+		return &history.Entry{}, history.ErrNotFound
+	}
+
+	return entries[len(entries)-1], nil
+}
+
+func (r *repositoryInMemory) HistoryCreate(ctx context.Context, entry *history.Entry) error {
+	if _, err := r.SubmissionGetByID(ctx, entry.Submission.ID); err != nil {
+		return err
+	}
+
+	entry.ID = r.entryCurrentID
+	r.entryCurrentID++
+
+	r.entries = append(r.entries, entry)
+	r.RecordSelection(entry.Submission.ID, entry.Date)
+
+	return nil
+}
+
+// loadEntries returns the Entries whose Submission was not deleted, with their
+// Submission loaded, in chronological order.
+func (r *repositoryInMemory) loadEntries(ctx context.Context) ([]*history.Entry, error) {
+	entries := []*history.Entry{}
+
+	for _, entry := range r.entries {
+		s, err := r.SubmissionGetByID(ctx, entry.Submission.ID)
+		if errors.Is(err, submission.ErrSubmissionNotFound) {
+			continue
+		}
+		if err != nil {
+			return []*history.Entry{}, err
+		}
+
+		entries = append(entries, &history.Entry{ID: entry.ID, Date: entry.Date, Submission: s})
+	}
+
+	slices.SortStableFunc(entries, func(a, b *history.Entry) int {
+		return cmp.Or(a.Date.Compare(b.Date), cmp.Compare(a.ID, b.ID))
+	})
+
+	return entries, nil
+}
+
+func TestRepositoryInMemory(t *testing.T) {
+	repositorytest.Run(t, newRepositoryInMemory)
+}
@@ -0,0 +1,489 @@
+package repositorytest
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/virtualtam/walric/pkg/monitor"
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+// seedSelection creates the fixture Subreddits and Submissions, where:
+//   - p1 is rated as favorite, tagged "mountain" and present in the History;
+//   - p2 is rated 3, and tagged "lake" and "winter";
+//   - p4 is rated as disliked.
+func seedSelection(t *testing.T, r Repository) *fixture {
+	t.Helper()
+
+	f := seed(t, r)
+
+	ratings := map[string]int{
+		"p1": submission.RatingFavorite,
+		"p2": 3,
+		"p4": submission.RatingDisliked,
+	}
+
+	for postID, rating := range ratings {
+		if err := r.SubmissionRate(t.Context(), f.submissions[postID].ID, rating); err != nil {
+			t.Fatalf("failed to rate submission %q: %q", postID, err)
+		}
+	}
+
+	tags := map[string][]string{
+		"p1": {"mountain"},
+		"p2": {"lake", "winter"},
+	}
+
+	for postID, submissionTags := range tags {
+		if err := r.SubmissionAddTags(t.Context(), f.submissions[postID].ID, submissionTags); err != nil {
+			t.Fatalf("failed to tag submission %q: %q", postID, err)
+		}
+	}
+
+	createEntry(t, r, f.submissions["p1"], time.Date(2023, 4, 1, 8, 0, 0, 0, time.UTC))
+
+	return f
+}
+
+func testSubmissionGetByFilter(t *testing.T, newRepository func(t *testing.T) Repository) {
+	r := newRepository(t)
+	seedSelection(t, r)
+
+	testCases := []struct {
+		tname       string
+		filter      submission.Filter
+		options     submission.ListOptions
+		wantPostIDs []string
+	}{
+		{
+			tname:       "no filter",
+			wantPostIDs: []string{"p5", "p1", "p2", "p4", "p3"},
+		},
+		{
+			tname:       "posted before",
+			filter:      submission.Filter{PostedBefore: time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC)},
+			wantPostIDs: []string{"p5", "p1"},
+		},
+		{
+			tname:       "below resolution",
+			filter:      submission.Filter{BelowResolution: &monitor.Resolution{HeightPx: 1080, WidthPx: 1920}},
+			wantPostIDs: []string{"p4"},
+		},
+		{
+			tname:       "aspect ratio",
+			filter:      submission.Filter{AspectRatio: &monitor.AspectRatioRange{Min: 1.7, Max: 1.8}},
+			wantPostIDs: []string{"p5", "p1", "p2", "p4", "p3"},
+		},
+		{
+			tname:       "subreddit names, regardless of case",
+			filter:      submission.Filter{SubredditNames: []string{"earthporn", "CITYPORN"}},
+			wantPostIDs: []string{"p5", "p1", "p2"},
+		},
+		{
+			tname:       "exclude NSFW",
+			filter:      submission.Filter{NSFW: submission.NSFWExclude},
+			wantPostIDs: []string{"p5", "p1", "p2", "p4"},
+		},
+		{
+			tname:       "NSFW only",
+			filter:      submission.Filter{NSFW: submission.NSFWOnly},
+			wantPostIDs: []string{"p3"},
+		},
+		{
+			tname:       "never shown",
+			filter:      submission.Filter{NeverShown: true},
+			wantPostIDs: []string{"p5", "p2", "p4", "p3"},
+		},
+		{
+			tname:       "min rating",
+			filter:      submission.Filter{MinRating: 3},
+			wantPostIDs: []string{"p1", "p2"},
+		},
+		{
+			tname:       "exclude disliked",
+			filter:      submission.Filter{ExcludeDisliked: true},
+			wantPostIDs: []string{"p5", "p1", "p2", "p3"},
+		},
+		{
+			tname:       "disliked only",
+			filter:      submission.Filter{DislikedOnly: true},
+			wantPostIDs: []string{"p4"},
+		},
+		{
+			tname:       "tags",
+			filter:      submission.Filter{Tags: []string{"lake", "winter"}},
+			wantPostIDs: []string{"p2"},
+		},
+		{
+			tname:       "exclude tags",
+			filter:      submission.Filter{ExcludeTags: []string{"mountain", "winter"}},
+			wantPostIDs: []string{"p5", "p4", "p3"},
+		},
+		{
+			tname: "query",
+			filter: submission.Filter{Query: &submission.Query{Conditions: []submission.Condition{
+				&submission.StringCondition{Field: submission.FieldAuthor, Value: "Alice"},
+				&submission.NumberCondition{Field: submission.FieldScore, Operator: submission.OperatorGreater, Value: 50},
+			}}},
+			wantPostIDs: []string{"p1"},
+		},
+		{
+			tname: "query shown",
+			filter: submission.Filter{Query: &submission.Query{Conditions: []submission.Condition{
+				&submission.BoolCondition{Field: submission.FieldShown, Value: true},
+			}}},
+			wantPostIDs: []string{"p1"},
+		},
+		{
+			tname: "query not shown",
+			filter: submission.Filter{Query: &submission.Query{Conditions: []submission.Condition{
+				&submission.NotCondition{Condition: &submission.BoolCondition{Field: submission.FieldShown, Value: true}},
+			}}},
+			wantPostIDs: []string{"p5", "p2", "p4", "p3"},
+		},
+		{
+			tname: "query tag and posting date",
+			filter: submission.Filter{Query: &submission.Query{Conditions: []submission.Condition{
+				&submission.DateCondition{Field: submission.FieldPosted, From: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+				&submission.NotCondition{Condition: &submission.StringCondition{Field: submission.FieldTag, Value: "winter"}},
+			}}},
+			wantPostIDs: []string{"p1", "p4", "p3"},
+		},
+		{
+			tname:       "sort by score",
+			options:     submission.ListOptions{Sort: submission.SortScore},
+			wantPostIDs: []string{"p3", "p5", "p1", "p2", "p4"},
+		},
+		{
+			tname:       "sort by rating, ascending",
+			options:     submission.ListOptions{Sort: submission.SortRating, Direction: submission.SortAscending},
+			wantPostIDs: []string{"p4", "p5", "p3", "p2", "p1"},
+		},
+		{
+			tname:       "sort by title",
+			options:     submission.ListOptions{Sort: submission.SortTitle},
+			wantPostIDs: []string{"p4", "p2", "p1", "p3", "p5"},
+		},
+		{
+			tname:       "sort by subreddit, descending",
+			options:     submission.ListOptions{Sort: submission.SortSubreddit, Direction: submission.SortDescending},
+			wantPostIDs: []string{"p4", "p3", "p1", "p2", "p5"},
+		},
+		{
+			tname:       "page",
+			options:     submission.ListOptions{Page: submission.Page{Limit: 2, Offset: 1}},
+			wantPostIDs: []string{"p1", "p2"},
+		},
+		{
+			tname:       "last page",
+			options:     submission.ListOptions{Page: submission.Page{Limit: 2, Offset: 4}},
+			wantPostIDs: []string{"p3"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			submissions, err := r.SubmissionGetByFilter(t.Context(), &tc.filter, &tc.options)
+			if !assertError(t, err, nil) {
+				return
+			}
+
+			assertPostIDs(t, submissions, tc.wantPostIDs)
+		})
+	}
+}
+
+func testSubmissionGetByMinResolution(t *testing.T, newRepository func(t *testing.T) Repository) {
+	r := newRepository(t)
+	seedSelection(t, r)
+
+	testCases := []struct {
+		tname         string
+		minResolution monitor.Resolution
+		filter        submission.Filter
+		options       submission.ListOptions
+		wantPostIDs   []string
+	}{
+		{
+			tname:         "any resolution, excluding unavailable images",
+			minResolution: monitor.Resolution{HeightPx: 1, WidthPx: 1},
+			wantPostIDs:   []string{"p5", "p1", "p2", "p3"},
+		},
+		{
+			tname:         "min resolution",
+			minResolution: monitor.Resolution{HeightPx: 1440, WidthPx: 2560},
+			wantPostIDs:   []string{"p5", "p1", "p3"},
+		},
+		{
+			tname:         "min resolution and filter",
+			minResolution: monitor.Resolution{HeightPx: 1440, WidthPx: 2560},
+			filter:        submission.Filter{NSFW: submission.NSFWExclude, NeverShown: true},
+			wantPostIDs:   []string{"p5"},
+		},
+		{
+			tname:         "min resolution, sorted and paginated",
+			minResolution: monitor.Resolution{HeightPx: 1080, WidthPx: 1920},
+			options: submission.ListOptions{
+				Sort:      submission.SortPosted,
+				Direction: submission.SortDescending,
+				Page:      submission.Page{Limit: 2},
+			},
+			wantPostIDs: []string{"p3", "p2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			submissions, err := r.SubmissionGetByMinResolution(t.Context(), &tc.minResolution, &tc.filter, &tc.options)
+			if !assertError(t, err, nil) {
+				return
+			}
+
+			assertPostIDs(t, submissions, tc.wantPostIDs)
+		})
+	}
+}
+
+func testSubmissionSearch(t *testing.T, newRepository func(t *testing.T) Repository) {
+	r := newRepository(t)
+	seedSelection(t, r)
+
+	testCases := []struct {
+		tname       string
+		terms       []submission.SearchTerm
+		filter      submission.Filter
+		options     submission.ListOptions
+		wantPostIDs []string
+	}{
+		{
+			tname:       "title word, regardless of case",
+			terms:       []submission.SearchTerm{{Text: "Aurora"}},
+			wantPostIDs: []string{"p2"},
+		},
+		{
+			tname:       "title prefix",
+			terms:       []submission.SearchTerm{{Text: "mount", Prefix: true}},
+			wantPostIDs: []string{"p1"},
+		},
+		{
+			tname:       "title phrase",
+			terms:       []submission.SearchTerm{{Text: "frozen lake"}},
+			wantPostIDs: []string{"p2"},
+		},
+		{
+			tname:       "tag",
+			terms:       []submission.SearchTerm{{Text: "winter"}},
+			wantPostIDs: []string{"p2"},
+		},
+		{
+			tname:       "subreddit name",
+			terms:       []submission.SearchTerm{{Text: "cityporn"}},
+			wantPostIDs: []string{"p5"},
+		},
+		{
+			tname:       "author",
+			terms:       []submission.SearchTerm{{Text: "alice"}},
+			wantPostIDs: []string{"p1", "p4"},
+		},
+		{
+			tname:       "all terms",
+			terms:       []submission.SearchTerm{{Text: "alice"}, {Text: "misty"}},
+			wantPostIDs: []string{"p1"},
+		},
+		{
+			tname:       "author and filter",
+			terms:       []submission.SearchTerm{{Text: "alice"}},
+			filter:      submission.Filter{NeverShown: true},
+			wantPostIDs: []string{"p4"},
+		},
+		{
+			tname:       "author, sorted by posting date",
+			terms:       []submission.SearchTerm{{Text: "alice"}},
+			options:     submission.ListOptions{Sort: submission.SortPosted},
+			wantPostIDs: []string{"p4", "p1"},
+		},
+		{
+			tname:       "author, sorted by posting date, ascending",
+			terms:       []submission.SearchTerm{{Text: "alice"}},
+			options:     submission.ListOptions{Sort: submission.SortPosted, Direction: submission.SortAscending},
+			wantPostIDs: []string{"p1", "p4"},
+		},
+		{
+			tname:   "no match",
+			terms:   []submission.SearchTerm{{Text: "desert"}},
+			options: submission.ListOptions{Sort: submission.SortPosted},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			results, err := r.SubmissionSearch(t.Context(), tc.terms, &tc.filter, &tc.options)
+			if !assertError(t, err, nil) {
+				return
+			}
+
+			submissions := make([]*submission.Submission, len(results))
+			for index, result := range results {
+				submissions[index] = result.Submission
+			}
+
+			// Without a SortKey, results are sorted by relevance, which
+			// depends on the implementation
+			if tc.options.Sort == submission.SortDefault {
+				slices.SortFunc(submissions, func(a, b *submission.Submission) int {
+					return strings.Compare(a.PostID, b.PostID)
+				})
+			}
+
+			assertPostIDs(t, submissions, tc.wantPostIDs)
+		})
+	}
+
+	t.Run("snippet", func(t *testing.T) {
+		results, err := r.SubmissionSearch(t.Context(), []submission.SearchTerm{{Text: "aurora"}}, &submission.Filter{}, &submission.ListOptions{})
+		if !assertError(t, err, nil) {
+			return
+		}
+
+		if len(results) != 1 {
+			t.Fatalf("want 1 result, got %d", len(results))
+		}
+
+		wantHighlight := submission.HighlightStart + "aurora" + submission.HighlightEnd
+		if !strings.Contains(results[0].Snippet, wantHighlight) {
+			t.Errorf("want snippet highlighting %q, got %q", wantHighlight, results[0].Snippet)
+		}
+
+		if s := results[0].Submission; s.Subreddit == nil || s.Subreddit.Name != "EarthPorn" {
+			t.Errorf("want subreddit \"EarthPorn\" loaded for submission %q", s.PostID)
+		}
+	})
+}
+
+// randomTestCase is a random selection test case.
+type randomTestCase struct {
+	tname         string
+	minResolution monitor.Resolution
+	filter        submission.Filter
+	repeat        submission.RepeatPolicy
+	wantPostIDs   []string
+}
+
+// randomTestCases are run against the History created by seedRandom, which
+// holds p1, p5 and p1 again, from the oldest to the most recent entry.
+var randomTestCases = []randomTestCase{
+	{
+		tname:         "always repeat",
+		minResolution: monitor.Resolution{HeightPx: 1080, WidthPx: 1920},
+		repeat:        submission.RepeatPolicy{Mode: submission.RepeatAfterSelections},
+		wantPostIDs:   []string{"p1", "p2", "p3", "p5"},
+	},
+	{
+		tname:         "never repeat",
+		minResolution: monitor.Resolution{HeightPx: 1080, WidthPx: 1920},
+		repeat:        submission.RepeatPolicy{Mode: submission.RepeatNever},
+		wantPostIDs:   []string{"p2", "p3"},
+	},
+	{
+		tname:         "never repeat, with filter",
+		minResolution: monitor.Resolution{HeightPx: 1080, WidthPx: 1920},
+		filter:        submission.Filter{NSFW: submission.NSFWExclude},
+		repeat:        submission.RepeatPolicy{Mode: submission.RepeatNever},
+		wantPostIDs:   []string{"p2"},
+	},
+	{
+		tname:         "repeat after days",
+		minResolution: monitor.Resolution{HeightPx: 1080, WidthPx: 1920},
+		repeat:        submission.RepeatPolicy{Mode: submission.RepeatAfterDays, Count: 7},
+		wantPostIDs:   []string{"p2", "p3", "p5"},
+	},
+	{
+		tname:         "repeat after selections",
+		minResolution: monitor.Resolution{HeightPx: 1080, WidthPx: 1920},
+		repeat:        submission.RepeatPolicy{Mode: submission.RepeatAfterSelections, Count: 2},
+		wantPostIDs:   []string{"p2", "p3"},
+	},
+	{
+		tname:         "repeat after one selection",
+		minResolution: monitor.Resolution{HeightPx: 1080, WidthPx: 1920},
+		repeat:        submission.RepeatPolicy{Mode: submission.RepeatAfterSelections, Count: 1},
+		wantPostIDs:   []string{"p2", "p3", "p5"},
+	},
+	{
+		tname:         "cycle",
+		minResolution: monitor.Resolution{HeightPx: 1080, WidthPx: 1920},
+		repeat:        submission.RepeatPolicy{Mode: submission.RepeatCycle},
+		wantPostIDs:   []string{"p2", "p3"},
+	},
+	{
+		tname:         "cycle, all shown",
+		minResolution: monitor.Resolution{HeightPx: 2160, WidthPx: 3840},
+		repeat:        submission.RepeatPolicy{Mode: submission.RepeatCycle},
+		wantPostIDs:   []string{"p5"},
+	},
+	{
+		tname:         "never repeat, all shown",
+		minResolution: monitor.Resolution{HeightPx: 2160, WidthPx: 3840},
+		repeat:        submission.RepeatPolicy{Mode: submission.RepeatNever},
+		wantPostIDs:   []string{},
+	},
+}
+
+// seedRandom creates the fixture Subreddits and Submissions, and the History
+// described by randomTestCases.
+func seedRandom(t *testing.T, r Repository) {
+	t.Helper()
+
+	f := seed(t, r)
+	now := time.Now().UTC()
+
+	createEntry(t, r, f.submissions["p1"], now.AddDate(0, 0, -30))
+	createEntry(t, r, f.submissions["p5"], now.AddDate(0, 0, -20))
+	createEntry(t, r, f.submissions["p1"], now.AddDate(0, 0, -1))
+}
+
+func testSubmissionGetRandom(t *testing.T, newRepository func(t *testing.T) Repository) {
+	r := newRepository(t)
+	seedRandom(t, r)
+
+	for _, tc := range randomTestCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			s, err := r.SubmissionGetRandom(t.Context(), &tc.minResolution, &tc.filter, tc.repeat)
+
+			if len(tc.wantPostIDs) == 0 {
+				assertError(t, err, submission.ErrSubmissionNotFound)
+				return
+			}
+
+			if !assertError(t, err, nil) {
+				return
+			}
+
+			if !slices.Contains(tc.wantPostIDs, s.PostID) {
+				t.Errorf("want any of post IDs %q, got %q", tc.wantPostIDs, s.PostID)
+			}
+
+			if s.Subreddit == nil || s.Subreddit.Name == "" {
+				t.Errorf("want subreddit loaded for submission %q", s.PostID)
+			}
+		})
+	}
+}
+
+func testSubmissionGetRandomCandidates(t *testing.T, newRepository func(t *testing.T) Repository) {
+	r := newRepository(t)
+	seedRandom(t, r)
+
+	for _, tc := range randomTestCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			candidates, err := r.SubmissionGetRandomCandidates(t.Context(), &tc.minResolution, &tc.filter, tc.repeat)
+			if !assertError(t, err, nil) {
+				return
+			}
+
+			assertPostIDs(t, candidates, tc.wantPostIDs)
+		})
+	}
+}
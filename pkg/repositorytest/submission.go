@@ -0,0 +1,771 @@
+package repositorytest
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/virtualtam/walric/pkg/history"
+	"github.com/virtualtam/walric/pkg/submission"
+)
+
+func testBan(t *testing.T, newRepository func(t *testing.T) Repository) {
+	r := newRepository(t)
+
+	ban := &submission.Ban{
+		Date:        time.Date(2023, 4, 1, 8, 0, 0, 0, time.UTC),
+		PostID:      "banned",
+		ImageSHA256: "d2a84f4b8b650937ec8f73cd8be2c74add5a911ba64df27458ed8229da804a26",
+	}
+
+	if err := r.BanCreate(t.Context(), ban); err != nil {
+		t.Fatalf("failed to create ban: %q", err)
+	}
+
+	testCases := []struct {
+		tname          string
+		postID         string
+		imageSHA256    string
+		wantRegistered bool
+	}{
+		{
+			tname:          "banned post ID",
+			postID:         "banned",
+			wantRegistered: true,
+		},
+		{
+			tname:          "banned image",
+			imageSHA256:    ban.ImageSHA256,
+			wantRegistered: true,
+		},
+		{
+			tname:       "unknown post ID and image",
+			postID:      "unknown",
+			imageSHA256: "f7f3d6c8a1c8b4e0a5c36b1a2e8a0c7d5c8f4b8e7d6a5c4b3a2f1e0d9c8b7a69",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			var registered bool
+			var err error
+
+			if tc.postID != "" {
+				registered, err = r.BanIsPostIDRegistered(t.Context(), tc.postID)
+			} else {
+				registered, err = r.BanIsImageSHA256Registered(t.Context(), tc.imageSHA256)
+			}
+
+			if !assertError(t, err, nil) {
+				return
+			}
+
+			if registered != tc.wantRegistered {
+				t.Errorf("want registered %t, got %t", tc.wantRegistered, registered)
+			}
+		})
+	}
+
+	t.Run("duplicate post ID", func(t *testing.T) {
+		err := r.BanCreate(t.Context(), &submission.Ban{Date: ban.Date, PostID: "banned"})
+		assertError(t, err, submission.ErrBanPostIDAlreadyRegistered)
+	})
+}
+
+func testSubreddit(t *testing.T, newRepository func(t *testing.T) Repository) {
+	r := newRepository(t)
+	f := seed(t, r)
+
+	t.Run("get all", func(t *testing.T) {
+		subreddits, err := r.SubredditGetAll(t.Context())
+		if !assertError(t, err, nil) {
+			return
+		}
+
+		names := make([]string, len(subreddits))
+		for index, subreddit := range subreddits {
+			names[index] = subreddit.Name
+		}
+
+		wantNames := []string{"CityPorn", "EarthPorn", "wallpapers"}
+		if !slices.Equal(names, wantNames) {
+			t.Errorf("want subreddits %q, got %q", wantNames, names)
+		}
+	})
+
+	t.Run("get by ID", func(t *testing.T) {
+		want := f.subreddits["wallpapers"]
+
+		subreddit, err := r.SubredditGetByID(t.Context(), want.ID)
+		if !assertError(t, err, nil) {
+			return
+		}
+
+		if subreddit.Name != want.Name {
+			t.Errorf("want subreddit %q, got %q", want.Name, subreddit.Name)
+		}
+	})
+
+	testCases := []struct {
+		tname          string
+		name           string
+		wantRegistered bool
+		wantErr        error
+	}{
+		// nominal cases
+		{
+			tname:          "registered name",
+			name:           "EarthPorn",
+			wantRegistered: true,
+		},
+
+		// error cases
+		{
+			tname:   "unknown name",
+			name:    "SkyPorn",
+			wantErr: submission.ErrSubredditNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			registered, err := r.SubredditIsNameRegistered(t.Context(), tc.name)
+			if !assertError(t, err, nil) {
+				return
+			}
+
+			if registered != tc.wantRegistered {
+				t.Errorf("want registered %t, got %t", tc.wantRegistered, registered)
+			}
+
+			subreddit, err := r.SubredditGetByName(t.Context(), tc.name)
+			if !assertError(t, err, tc.wantErr) {
+				return
+			}
+
+			if subreddit.ID != f.subreddits[tc.name].ID || subreddit.Name != tc.name {
+				t.Errorf("want subreddit %d %q, got %d %q", f.subreddits[tc.name].ID, tc.name, subreddit.ID, subreddit.Name)
+			}
+		})
+	}
+
+	t.Run("unknown ID", func(t *testing.T) {
+		_, err := r.SubredditGetByID(t.Context(), 9999)
+		assertError(t, err, submission.ErrSubredditNotFound)
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		err := r.SubredditCreate(t.Context(), &submission.Subreddit{Name: "EarthPorn"})
+		assertError(t, err, submission.ErrSubredditNameAlreadyRegistered)
+	})
+}
+
+func testSubredditGetStats(t *testing.T, newRepository func(t *testing.T) Repository) {
+	r := newRepository(t)
+	seed(t, r)
+	createSubreddit(t, r, "SkyPorn")
+
+	stats, err := r.SubredditGetStats(t.Context())
+	if !assertError(t, err, nil) {
+		return
+	}
+
+	wantStats := []submission.SubredditStats{
+		{Name: "CityPorn", Submissions: 1},
+		{Name: "EarthPorn", Submissions: 2},
+		{Name: "SkyPorn", Submissions: 0},
+		{Name: "wallpapers", Submissions: 2},
+	}
+
+	if !slices.Equal(stats, wantStats) {
+		t.Errorf("want subreddit stats %v, got %v", wantStats, stats)
+	}
+}
+
+func testSubmissionCreate(t *testing.T, newRepository func(t *testing.T) Repository) {
+	r := newRepository(t)
+	f := seed(t, r)
+
+	t.Run("get all", func(t *testing.T) {
+		submissions, err := r.SubmissionGetAll(t.Context())
+		if !assertError(t, err, nil) {
+			return
+		}
+
+		assertPostIDs(t, submissions, []string{"p1", "p2", "p3", "p4", "p5"})
+	})
+
+	t.Run("get by ID", func(t *testing.T) {
+		want := f.submissions["p3"]
+
+		s, err := r.SubmissionGetByID(t.Context(), want.ID)
+		if !assertError(t, err, nil) {
+			return
+		}
+
+		if s.PostID != want.PostID {
+			t.Errorf("want post ID %q, got %q", want.PostID, s.PostID)
+		}
+
+		if s.Title != want.Title || s.Author != want.Author || s.Score != want.Score {
+			t.Errorf("want submission %q by %q with score %d, got %q by %q with score %d", want.Title, want.Author, want.Score, s.Title, s.Author, s.Score)
+		}
+
+		if !s.PostedAt.Equal(want.PostedAt) {
+			t.Errorf("want posting date %q, got %q", want.PostedAt, s.PostedAt)
+		}
+
+		if !s.ImageNSFW || s.ImageUnavailable {
+			t.Errorf("want NSFW and available image, got NSFW %t and unavailable %t", s.ImageNSFW, s.ImageUnavailable)
+		}
+
+		if s.ImageHeightPx != want.ImageHeightPx || s.ImageWidthPx != want.ImageWidthPx {
+			t.Errorf("want image size %dx%d, got %dx%d", want.ImageWidthPx, want.ImageHeightPx, s.ImageWidthPx, s.ImageHeightPx)
+		}
+
+		if s.Rating != submission.RatingNone || len(s.Tags) != 0 {
+			t.Errorf("want no rating and no tags, got rating %d and tags %q", s.Rating, s.Tags)
+		}
+
+		assertSubredditLoaded(t, s, f.subreddits["wallpapers"])
+	})
+
+	t.Run("get by subreddit ID", func(t *testing.T) {
+		submissions, err := r.SubmissionGetBySubredditID(t.Context(), f.subreddits["wallpapers"].ID)
+		if !assertError(t, err, nil) {
+			return
+		}
+
+		assertPostIDs(t, submissions, []string{"p4", "p3"})
+
+		for _, s := range submissions {
+			assertSubredditLoaded(t, s, f.subreddits["wallpapers"])
+		}
+	})
+
+	t.Run("post ID registered", func(t *testing.T) {
+		registered, err := r.SubmissionIsPostIDRegistered(t.Context(), "p1")
+		if !assertError(t, err, nil) {
+			return
+		}
+
+		if !registered {
+			t.Error("want post ID registered")
+		}
+	})
+
+	t.Run("post ID not registered", func(t *testing.T) {
+		registered, err := r.SubmissionIsPostIDRegistered(t.Context(), "unknown")
+		if !assertError(t, err, nil) {
+			return
+		}
+
+		if registered {
+			t.Error("want post ID not registered")
+		}
+	})
+
+	t.Run("unknown ID", func(t *testing.T) {
+		_, err := r.SubmissionGetByID(t.Context(), 9999)
+		assertError(t, err, submission.ErrSubmissionNotFound)
+	})
+
+	t.Run("unknown post ID", func(t *testing.T) {
+		_, err := r.SubmissionGetByPostID(t.Context(), "unknown")
+		assertError(t, err, submission.ErrSubmissionNotFound)
+	})
+
+	testCases := []struct {
+		tname      string
+		submission *submission.Submission
+		wantErr    error
+	}{
+		{
+			tname: "duplicate post ID",
+			submission: &submission.Submission{
+				Subreddit: f.subreddits["EarthPorn"],
+				PostID:    "p1",
+				Title:     "Duplicate",
+			},
+			wantErr: submission.ErrSubmissionPostIDAlreadyRegistered,
+		},
+		{
+			tname: "unknown subreddit",
+			submission: &submission.Submission{
+				Subreddit: &submission.Subreddit{ID: 9999},
+				PostID:    "orphan",
+				Title:     "Orphan",
+			},
+			wantErr: submission.ErrSubredditNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			err := r.SubmissionCreate(t.Context(), tc.submission)
+			assertError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func testSubmissionUpdate(t *testing.T, newRepository func(t *testing.T) Repository) {
+	testCases := []struct {
+		tname   string
+		update  func(f *fixture, s *submission.Submission)
+		wantErr error
+	}{
+		// nominal cases
+		{
+			tname: "update metadata",
+			update: func(f *fixture, s *submission.Submission) {
+				s.Title = "Misty mountains at sunrise"
+				s.Score = 150
+				s.Removed = true
+				s.Subreddit = f.subreddits["CityPorn"]
+			},
+		},
+
+		// error cases
+		{
+			tname: "unknown ID",
+			update: func(f *fixture, s *submission.Submission) {
+				s.ID = 9999
+				s.PostID = "unknown"
+			},
+			wantErr: submission.ErrSubmissionNotFound,
+		},
+		{
+			tname: "duplicate post ID",
+			update: func(f *fixture, s *submission.Submission) {
+				s.PostID = "p2"
+			},
+			wantErr: submission.ErrSubmissionPostIDAlreadyRegistered,
+		},
+		{
+			tname: "unknown subreddit",
+			update: func(f *fixture, s *submission.Submission) {
+				s.Subreddit = &submission.Subreddit{ID: 9999}
+			},
+			wantErr: submission.ErrSubredditNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			r := newRepository(t)
+			f := seed(t, r)
+
+			id := f.submissions["p1"].ID
+
+			if err := r.SubmissionRate(t.Context(), id, 4); err != nil {
+				t.Fatalf("failed to rate submission: %q", err)
+			}
+
+			if err := r.SubmissionAddTags(t.Context(), id, []string{"mountain"}); err != nil {
+				t.Fatalf("failed to tag submission: %q", err)
+			}
+
+			s := *getSubmission(t, r, "p1")
+			s.Rating = submission.RatingFavorite
+			s.Tags = []string{"fog"}
+			tc.update(f, &s)
+
+			err := r.SubmissionUpdate(t.Context(), &s)
+			if !assertError(t, err, tc.wantErr) {
+				return
+			}
+
+			updated := getSubmission(t, r, "p1")
+
+			if updated.Title != s.Title || updated.Score != s.Score || !updated.Removed {
+				t.Errorf("want title %q, score %d and removed, got %q, %d and %t", s.Title, s.Score, updated.Title, updated.Score, updated.Removed)
+			}
+
+			assertSubredditLoaded(t, updated, f.subreddits["CityPorn"])
+
+			if updated.Rating != 4 {
+				t.Errorf("want rating 4 left unchanged, got %d", updated.Rating)
+			}
+
+			if !slices.Equal(updated.Tags, []string{"mountain"}) {
+				t.Errorf("want tags [\"mountain\"] left unchanged, got %q", updated.Tags)
+			}
+		})
+	}
+}
+
+func testSubmissionRate(t *testing.T, newRepository func(t *testing.T) Repository) {
+	testCases := []struct {
+		tname      string
+		ratings    []int
+		wantRating int
+		wantErr    error
+	}{
+		// nominal cases
+		{
+			tname:      "rate",
+			ratings:    []int{3},
+			wantRating: 3,
+		},
+		{
+			tname:      "rate again",
+			ratings:    []int{3, submission.RatingFavorite},
+			wantRating: submission.RatingFavorite,
+		},
+		{
+			tname:      "dislike",
+			ratings:    []int{submission.RatingDisliked},
+			wantRating: submission.RatingDisliked,
+		},
+		{
+			tname:      "clear rating",
+			ratings:    []int{3, submission.RatingNone},
+			wantRating: submission.RatingNone,
+		},
+
+		// error cases
+		{
+			tname:   "unknown ID",
+			ratings: []int{3},
+			wantErr: submission.ErrSubmissionNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			r := newRepository(t)
+			f := seed(t, r)
+
+			id := f.submissions["p2"].ID
+			if tc.wantErr != nil {
+				id = 9999
+			}
+
+			var err error
+			for _, rating := range tc.ratings {
+				if err = r.SubmissionRate(t.Context(), id, rating); err != nil {
+					break
+				}
+			}
+
+			if !assertError(t, err, tc.wantErr) {
+				return
+			}
+
+			s := getSubmission(t, r, "p2")
+			if s.Rating != tc.wantRating {
+				t.Errorf("want rating %d, got %d", tc.wantRating, s.Rating)
+			}
+		})
+	}
+}
+
+func testSubmissionTags(t *testing.T, newRepository func(t *testing.T) Repository) {
+	testCases := []struct {
+		tname         string
+		addTags       []string
+		removeTags    []string
+		wantTags      []string
+		wantTagStats  []submission.TagStats
+		unknownTarget bool
+		wantErr       error
+	}{
+		// nominal cases
+		{
+			tname:    "add tags",
+			addTags:  []string{"lake", "aurora"},
+			wantTags: []string{"aurora", "lake", "winter"},
+			wantTagStats: []submission.TagStats{
+				{Name: "aurora", Submissions: 1},
+				{Name: "lake", Submissions: 1},
+				{Name: "winter", Submissions: 2},
+			},
+		},
+		{
+			tname:    "add existing tags",
+			addTags:  []string{"winter"},
+			wantTags: []string{"winter"},
+			wantTagStats: []submission.TagStats{
+				{Name: "winter", Submissions: 2},
+			},
+		},
+		{
+			tname:      "remove tags",
+			removeTags: []string{"winter", "unknown"},
+			wantTags:   []string{},
+			wantTagStats: []submission.TagStats{
+				{Name: "winter", Submissions: 1},
+			},
+		},
+
+		// error cases
+		{
+			tname:         "add tags to unknown ID",
+			addTags:       []string{"lake"},
+			unknownTarget: true,
+			wantErr:       submission.ErrSubmissionNotFound,
+		},
+		{
+			tname:         "remove tags from unknown ID",
+			removeTags:    []string{"winter"},
+			unknownTarget: true,
+			wantErr:       submission.ErrSubmissionNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			r := newRepository(t)
+			f := seed(t, r)
+
+			for _, postID := range []string{"p1", "p2"} {
+				if err := r.SubmissionAddTags(t.Context(), f.submissions[postID].ID, []string{"winter"}); err != nil {
+					t.Fatalf("failed to tag submission %q: %q", postID, err)
+				}
+			}
+
+			id := f.submissions["p2"].ID
+			if tc.unknownTarget {
+				id = 9999
+			}
+
+			var err error
+			if len(tc.addTags) > 0 {
+				err = r.SubmissionAddTags(t.Context(), id, tc.addTags)
+			} else {
+				err = r.SubmissionRemoveTags(t.Context(), id, tc.removeTags)
+			}
+
+			if !assertError(t, err, tc.wantErr) {
+				return
+			}
+
+			s := getSubmission(t, r, "p2")
+			if !slices.Equal(s.Tags, tc.wantTags) {
+				t.Errorf("want tags %q, got %q", tc.wantTags, s.Tags)
+			}
+
+			tagStats, err := r.TagGetStats(t.Context())
+			if !assertError(t, err, nil) {
+				return
+			}
+
+			if !slices.Equal(tagStats, tc.wantTagStats) {
+				t.Errorf("want tag stats %v, got %v", tc.wantTagStats, tagStats)
+			}
+		})
+	}
+}
+
+func testSubmissionDelete(t *testing.T, newRepository func(t *testing.T) Repository) {
+	r := newRepository(t)
+	f := seed(t, r)
+
+	p1 := f.submissions["p1"]
+	p2 := f.submissions["p2"]
+
+	createEntry(t, r, p1, time.Date(2023, 4, 1, 8, 0, 0, 0, time.UTC))
+	createEntry(t, r, p2, time.Date(2023, 4, 2, 8, 0, 0, 0, time.UTC))
+	createEntry(t, r, p1, time.Date(2023, 4, 3, 8, 0, 0, 0, time.UTC))
+
+	if err := r.SubmissionRate(t.Context(), p1.ID, submission.RatingFavorite); err != nil {
+		t.Fatalf("failed to rate submission: %q", err)
+	}
+
+	if err := r.SubmissionAddTags(t.Context(), p1.ID, []string{"mountain"}); err != nil {
+		t.Fatalf("failed to tag submission: %q", err)
+	}
+
+	if err := r.SubmissionDelete(t.Context(), p1.ID); err != nil {
+		t.Fatalf("failed to delete submission: %q", err)
+	}
+
+	t.Run("submission deleted", func(t *testing.T) {
+		_, err := r.SubmissionGetByID(t.Context(), p1.ID)
+		assertError(t, err, submission.ErrSubmissionNotFound)
+	})
+
+	t.Run("history entries deleted", func(t *testing.T) {
+		entries, err := r.HistoryGetAll(t.Context(), &history.ListOptions{})
+		if !assertError(t, err, nil) {
+			return
+		}
+
+		assertEntryPostIDs(t, entries, []string{"p2"})
+	})
+
+	t.Run("no longer shown", func(t *testing.T) {
+		submissions, err := r.SubmissionGetByFilter(t.Context(), &submission.Filter{NeverShown: true}, &submission.ListOptions{})
+		if !assertError(t, err, nil) {
+			return
+		}
+
+		assertPostIDs(t, submissions, []string{"p5", "p4", "p3"})
+	})
+
+	t.Run("tags deleted", func(t *testing.T) {
+		tagStats, err := r.TagGetStats(t.Context())
+		if !assertError(t, err, nil) {
+			return
+		}
+
+		if len(tagStats) != 0 {
+			t.Errorf("want no tag stats, got %v", tagStats)
+		}
+	})
+
+	t.Run("unknown ID", func(t *testing.T) {
+		err := r.SubmissionDelete(t.Context(), p1.ID)
+		assertError(t, err, submission.ErrSubmissionNotFound)
+	})
+}
+
+func testSubmissionDeleteMany(t *testing.T, newRepository func(t *testing.T) Repository) {
+	testCases := []struct {
+		tname       string
+		postIDs     []string
+		unknownID   bool
+		wantPostIDs []string
+		wantErr     error
+	}{
+		// nominal cases
+		{
+			tname:       "delete submissions",
+			postIDs:     []string{"p1", "p3"},
+			wantPostIDs: []string{"p2", "p4", "p5"},
+		},
+
+		// error cases
+		{
+			tname:       "unknown ID",
+			postIDs:     []string{"p1", "p3"},
+			unknownID:   true,
+			wantPostIDs: []string{"p1", "p2", "p3", "p4", "p5"},
+			wantErr:     submission.ErrSubmissionNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			r := newRepository(t)
+			f := seed(t, r)
+
+			createEntry(t, r, f.submissions["p1"], time.Date(2023, 4, 1, 8, 0, 0, 0, time.UTC))
+
+			ids := []int{}
+			for _, postID := range tc.postIDs {
+				ids = append(ids, f.submissions[postID].ID)
+			}
+
+			if tc.unknownID {
+				ids = append(ids, 9999)
+			}
+
+			err := r.SubmissionDeleteMany(t.Context(), ids)
+			assertError(t, err, tc.wantErr)
+
+			submissions, err := r.SubmissionGetAll(t.Context())
+			if !assertError(t, err, nil) {
+				return
+			}
+
+			assertPostIDs(t, submissions, tc.wantPostIDs)
+
+			entries, err := r.HistoryGetAll(t.Context(), &history.ListOptions{})
+			if !assertError(t, err, nil) {
+				return
+			}
+
+			wantEntries := 0
+			if slices.Contains(tc.wantPostIDs, "p1") {
+				wantEntries = 1
+			}
+
+			if len(entries) != wantEntries {
+				t.Errorf("want %d history entries, got %d", wantEntries, len(entries))
+			}
+		})
+	}
+}
+
+func testWithTx(t *testing.T, newRepository func(t *testing.T) Repository) {
+	errRollback := errors.New("rollback")
+
+	testCases := []struct {
+		tname       string
+		fnErr       error
+		wantPostIDs []string
+		wantRating  int
+	}{
+		{
+			tname:       "commit",
+			wantPostIDs: []string{"p1", "p2", "p3", "p4", "p5", "p6"},
+			wantRating:  submission.RatingFavorite,
+		},
+		{
+			tname:       "rollback",
+			fnErr:       errRollback,
+			wantPostIDs: []string{"p1", "p2", "p3", "p4", "p5"},
+			wantRating:  submission.RatingNone,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			r := newRepository(t)
+			f := seed(t, r)
+
+			err := r.WithTx(t.Context(), func(tx submission.Repository) error {
+				if err := tx.SubredditCreate(t.Context(), &submission.Subreddit{Name: "SkyPorn"}); err != nil {
+					return err
+				}
+
+				subreddit, err := tx.SubredditGetByName(t.Context(), "SkyPorn")
+				if err != nil {
+					return err
+				}
+
+				err = tx.SubmissionCreate(t.Context(), &submission.Submission{
+					Subreddit: subreddit,
+					PostID:    "p6",
+					PostedAt:  time.Date(2023, 5, 1, 8, 0, 0, 0, time.UTC),
+					Title:     "Starry night",
+				})
+				if err != nil {
+					return err
+				}
+
+				if err := tx.SubmissionRate(t.Context(), f.submissions["p1"].ID, submission.RatingFavorite); err != nil {
+					return err
+				}
+
+				return tc.fnErr
+			})
+
+			if !errors.Is(err, tc.fnErr) {
+				t.Errorf("want error %v, got %v", tc.fnErr, err)
+			}
+
+			submissions, err := r.SubmissionGetAll(t.Context())
+			if !assertError(t, err, nil) {
+				return
+			}
+
+			assertPostIDs(t, submissions, tc.wantPostIDs)
+
+			registered, err := r.SubredditIsNameRegistered(t.Context(), "SkyPorn")
+			if !assertError(t, err, nil) {
+				return
+			}
+
+			if registered != (tc.fnErr == nil) {
+				t.Errorf("want subreddit registered %t, got %t", tc.fnErr == nil, registered)
+			}
+
+			if s := getSubmission(t, r, "p1"); s.Rating != tc.wantRating {
+				t.Errorf("want rating %d, got %d", tc.wantRating, s.Rating)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package submission
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Ban represents a removed Reddit post whose image must never be gathered
+// again.
+type Ban struct {
+	ID   int
+	Date time.Time
+
+	PostID      string
+	ImageSHA256 string
+}
+
+// NewBan initializes and returns a new Ban for a given Submission, and the
+// SHA-256 checksum of its image file.
+func NewBan(sub *Submission, imageSHA256 string) *Ban {
+	return &Ban{
+		Date:        time.Now().UTC(),
+		PostID:      sub.PostID,
+		ImageSHA256: imageSHA256,
+	}
+}
+
+// Normalize sanitizes and normalizes all fields.
+func (b *Ban) Normalize() {
+	b.PostID = strings.TrimSpace(b.PostID)
+	b.ImageSHA256 = strings.ToLower(strings.TrimSpace(b.ImageSHA256))
+}
+
+// ValidateForAddition ensures mandatory fields are properly set when adding an
+// new Ban.
+func (b *Ban) ValidateForAddition(ctx context.Context, r ValidationRepository) error {
+	fns := []func() error{
+		b.requireDefaultID,
+		b.requirePostID,
+		b.ensurePostIDIsNotBanned(ctx, r),
+	}
+
+	for _, fn := range fns {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *Ban) ensurePostIDIsNotBanned(ctx context.Context, r ValidationRepository) func() error {
+	return func() error {
+		banned, err := r.BanIsPostIDRegistered(ctx, b.PostID)
+		if err != nil {
+			return err
+		}
+
+		if banned {
+			return ErrBanPostIDAlreadyRegistered
+		}
+
+		return nil
+	}
+}
+
+func (b *Ban) requireDefaultID() error {
+	if b.ID != 0 {
+		return ErrBanIDInvalid
+	}
+
+	return nil
+}
+
+func (b *Ban) requirePostID() error {
+	if b.PostID == "" {
+		return ErrBanPostIDEmpty
+	}
+
+	return nil
+}
@@ -3,6 +3,27 @@ package submission
 import "errors"
 
 var (
+	ErrBanIDInvalid               error = errors.New("ban: invalid ID")
+	ErrBanPostIDAlreadyRegistered error = errors.New("ban: post ID already registered")
+	ErrBanPostIDEmpty             error = errors.New("ban: empty post ID")
+
+	ErrListLimitInvalid         error = errors.New("list: invalid limit")
+	ErrListOffsetInvalid        error = errors.New("list: invalid offset")
+	ErrListSortDirectionInvalid error = errors.New("list: invalid sort direction")
+	ErrListSortInvalid          error = errors.New("list: invalid sort key")
+
+	ErrNSFWPolicyInvalid error = errors.New("nsfw: invalid policy")
+
+	ErrQueryFieldUnknown    error = errors.New("query: unknown field")
+	ErrQueryOperatorInvalid error = errors.New("query: invalid operator")
+	ErrQueryValueInvalid    error = errors.New("query: invalid value")
+
+	ErrRatingInvalid error = errors.New("rating: invalid value")
+
+	ErrRepeatPolicyInvalid error = errors.New("repeat: invalid policy")
+
+	ErrSelectionStrategyInvalid error = errors.New("selection: invalid strategy")
+
 	ErrSubmissionIDInvalid               error = errors.New("submission: invalid ID")
 	ErrSubmissionNotFound                error = errors.New("submission: not found")
 	ErrSubmissionPostIDAlreadyRegistered error = errors.New("submission: post ID already registered")
@@ -14,4 +35,7 @@ var (
 	ErrSubredditNameAlreadyRegistered error = errors.New("subreddit: name already registered")
 	ErrSubredditNameEmpty             error = errors.New("subreddit: empty name")
 	ErrSubredditNotFound              error = errors.New("subreddit: not found")
+
+	ErrTagNameEmpty   error = errors.New("tag: empty name")
+	ErrTagNameInvalid error = errors.New("tag: invalid name")
 )
@@ -0,0 +1,187 @@
+package submission
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/virtualtam/walric/pkg/monitor"
+)
+
+// Filter holds the criteria used to select Submissions.
+//
+// Criteria are combined with a logical AND, and unset criteria are ignored.
+type Filter struct {
+	// PostedBefore selects Submissions posted before this date.
+	PostedBefore time.Time
+
+	// BelowResolution selects Submissions whose image is smaller than this
+	// resolution, in either dimension.
+	BelowResolution *monitor.Resolution
+
+	// AspectRatio selects Submissions whose image aspect ratio is within this
+	// range.
+	AspectRatio *monitor.AspectRatioRange
+
+	// SubredditNames selects Submissions from any of these Subreddits.
+	SubredditNames []string
+
+	// NSFW selects Submissions according to their NSFW flag.
+	//
+	// When unset, NSFWInclude applies, except for wallpaper selection Service
+	// methods, where DefaultNSFWPolicy applies.
+	NSFW NSFWPolicy
+
+	// NeverShown selects Submissions that are not present in the History.
+	NeverShown bool
+
+	// MinRating selects Submissions rated at least this value, from 1 to
+	// RatingFavorite.
+	MinRating int
+
+	// ExcludeDisliked excludes Submissions rated as disliked.
+	ExcludeDisliked bool
+
+	// DislikedOnly selects Submissions rated as disliked.
+	DislikedOnly bool
+
+	// Tags selects Submissions tagged with all of these tags.
+	Tags []string
+
+	// ExcludeTags excludes Submissions tagged with any of these tags.
+	ExcludeTags []string
+
+	// Query selects Submissions matching all of its Conditions.
+	Query *Query
+}
+
+// IsEmpty returns whether no criteria is set, i.e. whether this Filter would
+// select all Submissions.
+func (f *Filter) IsEmpty() bool {
+	return f.PostedBefore.IsZero() &&
+		f.BelowResolution == nil &&
+		f.AspectRatio == nil &&
+		len(f.SubredditNames) == 0 &&
+		(f.NSFW == "" || f.NSFW == NSFWInclude) &&
+		!f.NeverShown &&
+		f.MinRating == RatingNone &&
+		!f.ExcludeDisliked &&
+		!f.DislikedOnly &&
+		len(f.Tags) == 0 &&
+		len(f.ExcludeTags) == 0 &&
+		f.Query.IsEmpty()
+}
+
+// selectionFilter returns a copy of a Filter used to select wallpapers, where
+// DefaultNSFWPolicy applies if no NSFWPolicy is set.
+func selectionFilter(filter *Filter) *Filter {
+	selection := *filter
+
+	if selection.NSFW == "" {
+		selection.NSFW = DefaultNSFWPolicy
+	}
+
+	return &selection
+}
+
+// Validate ensures this Filter's criteria are valid.
+func (f *Filter) Validate() error {
+	if f.BelowResolution != nil {
+		if err := f.BelowResolution.Validate(); err != nil {
+			return err
+		}
+	}
+
+	switch f.NSFW {
+	case "", NSFWExclude, NSFWInclude, NSFWOnly:
+	default:
+		return fmt.Errorf("%w %q", ErrNSFWPolicyInvalid, f.NSFW)
+	}
+
+	if f.MinRating < RatingNone || f.MinRating > RatingFavorite {
+		return ErrRatingInvalid
+	}
+
+	if err := ValidateTags(NormalizeTags(f.Tags)); err != nil {
+		return err
+	}
+
+	if err := ValidateTags(NormalizeTags(f.ExcludeTags)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// match returns whether a Submission matches this Filter's criteria, where
+// shown reports whether the Submission is present in the History.
+//
+// The Submission's Subreddit MUST be fully loaded.
+func (f *Filter) match(s *Submission, shown bool) bool {
+	if !f.PostedBefore.IsZero() && !s.PostedAt.Before(f.PostedBefore) {
+		return false
+	}
+
+	if f.BelowResolution != nil &&
+		s.ImageHeightPx >= f.BelowResolution.HeightPx &&
+		s.ImageWidthPx >= f.BelowResolution.WidthPx {
+		return false
+	}
+
+	if f.AspectRatio != nil && !f.AspectRatio.Contains(s.AspectRatio()) {
+		return false
+	}
+
+	if len(f.SubredditNames) > 0 && !slices.ContainsFunc(f.SubredditNames, func(name string) bool {
+		return strings.EqualFold(name, s.Subreddit.Name)
+	}) {
+		return false
+	}
+
+	if f.NSFW == NSFWExclude && s.ImageNSFW {
+		return false
+	}
+
+	if f.NSFW == NSFWOnly && !s.ImageNSFW {
+		return false
+	}
+
+	if f.NeverShown && shown {
+		return false
+	}
+
+	if f.MinRating > RatingNone && s.Rating < f.MinRating {
+		return false
+	}
+
+	if f.ExcludeDisliked && s.Rating == RatingDisliked {
+		return false
+	}
+
+	if f.DislikedOnly && s.Rating != RatingDisliked {
+		return false
+	}
+
+	hasTag := func(tag string) bool {
+		return slices.ContainsFunc(s.Tags, func(name string) bool {
+			return strings.EqualFold(name, strings.TrimSpace(tag))
+		})
+	}
+
+	for _, tag := range f.Tags {
+		if !hasTag(tag) {
+			return false
+		}
+	}
+
+	if slices.ContainsFunc(f.ExcludeTags, hasTag) {
+		return false
+	}
+
+	if !f.Query.IsEmpty() && !f.Query.match(s, shown) {
+		return false
+	}
+
+	return true
+}
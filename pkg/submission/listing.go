@@ -0,0 +1,148 @@
+package submission
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// SortKey is a Submission attribute used to sort listings.
+type SortKey string
+
+const (
+	// SortDefault sorts listings in their default order: by decreasing
+	// relevance for searches, and by subreddit and posting date otherwise.
+	SortDefault    SortKey = ""
+	SortPosted     SortKey = "posted"
+	SortRating     SortKey = "rating"
+	SortResolution SortKey = "resolution"
+	SortScore      SortKey = "score"
+	SortSubreddit  SortKey = "subreddit"
+	SortTitle      SortKey = "title"
+)
+
+// SortKeys lists the SortKeys that can be set explicitly.
+var SortKeys = []SortKey{
+	SortPosted,
+	SortRating,
+	SortResolution,
+	SortScore,
+	SortSubreddit,
+	SortTitle,
+}
+
+// SortDirection is the direction in which a listing is sorted.
+type SortDirection string
+
+const (
+	// SortNatural sorts listings in the natural direction of their SortKey:
+	// descending for dates, ratings, resolutions and scores, ascending for
+	// names.
+	SortNatural    SortDirection = ""
+	SortAscending  SortDirection = "asc"
+	SortDescending SortDirection = "desc"
+)
+
+// Page selects a range of a listing.
+type Page struct {
+	// Limit is the maximum number of items to return, or 0 for no limit.
+	Limit int
+
+	// Offset is the number of items to skip.
+	Offset int
+}
+
+// Validate ensures this Page's limit and offset are valid.
+func (p Page) Validate() error {
+	if p.Limit < 0 {
+		return ErrListLimitInvalid
+	}
+
+	if p.Offset < 0 {
+		return ErrListOffsetInvalid
+	}
+
+	return nil
+}
+
+// PageItems returns the items within a Page of a listing.
+func PageItems[T any](items []T, page Page) []T {
+	if page.Offset >= len(items) {
+		return []T{}
+	}
+
+	items = items[page.Offset:]
+
+	if page.Limit > 0 && page.Limit < len(items) {
+		items = items[:page.Limit]
+	}
+
+	return items
+}
+
+// ListOptions controls the order and range of a Submission listing.
+type ListOptions struct {
+	Sort      SortKey
+	Direction SortDirection
+	Page
+}
+
+// Descending returns whether the listing is sorted in descending order.
+func (o *ListOptions) Descending() bool {
+	switch o.Direction {
+	case SortAscending:
+		return false
+	case SortDescending:
+		return true
+	}
+
+	switch o.Sort {
+	case SortPosted, SortRating, SortResolution, SortScore:
+		return true
+	}
+
+	return false
+}
+
+// Validate ensures these ListOptions are valid.
+func (o *ListOptions) Validate() error {
+	if o.Sort != SortDefault && !slices.Contains(SortKeys, o.Sort) {
+		return fmt.Errorf("%w %q", ErrListSortInvalid, o.Sort)
+	}
+
+	if o.Sort == SortDefault && o.Direction != SortNatural {
+		return fmt.Errorf("%w: a direction requires a sort key", ErrListSortInvalid)
+	}
+
+	if err := ValidateSortDirection(o.Direction); err != nil {
+		return err
+	}
+
+	return o.Page.Validate()
+}
+
+// ParseSort parses a sort specification, formatted as `KEY[:asc|desc]`.
+func ParseSort(value string) (SortKey, SortDirection, error) {
+	key, direction, _ := strings.Cut(strings.ToLower(strings.TrimSpace(value)), ":")
+
+	options := &ListOptions{
+		Sort:      SortKey(key),
+		Direction: SortDirection(direction),
+	}
+
+	if err := options.Validate(); err != nil {
+		return SortDefault, SortNatural, err
+	}
+
+	return options.Sort, options.Direction, nil
+}
+
+// ValidateSortDirection ensures a SortDirection is valid.
+func ValidateSortDirection(direction SortDirection) error {
+	switch direction {
+	case SortNatural, SortAscending, SortDescending:
+		return nil
+	}
+
+	return fmt.Errorf("%w %q", ErrListSortDirectionInvalid, direction)
+}
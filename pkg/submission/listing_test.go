@@ -0,0 +1,125 @@
+package submission
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseSort(t *testing.T) {
+	testCases := []struct {
+		tname         string
+		value         string
+		wantKey       SortKey
+		wantDirection SortDirection
+		wantErr       error
+	}{
+		// nominal cases
+		{
+			tname: "default",
+			value: "",
+		},
+		{
+			tname:   "key",
+			value:   "score",
+			wantKey: SortScore,
+		},
+		{
+			tname:         "key and direction",
+			value:         " Title:DESC ",
+			wantKey:       SortTitle,
+			wantDirection: SortDescending,
+		},
+
+		// error cases
+		{
+			tname:   "unknown key",
+			value:   "colour",
+			wantErr: ErrListSortInvalid,
+		},
+		{
+			tname:   "direction without key",
+			value:   ":asc",
+			wantErr: ErrListSortInvalid,
+		},
+		{
+			tname:   "unknown direction",
+			value:   "score:up",
+			wantErr: ErrListSortDirectionInvalid,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			key, direction, err := ParseSort(tc.value)
+
+			if tc.wantErr != nil {
+				if err == nil {
+					t.Error("expected an error but got none")
+				} else if !errors.Is(err, tc.wantErr) {
+					t.Errorf("want error %q, got %q", tc.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error, got %q", err)
+				return
+			}
+
+			if key != tc.wantKey {
+				t.Errorf("want key %q, got %q", tc.wantKey, key)
+			}
+
+			if direction != tc.wantDirection {
+				t.Errorf("want direction %q, got %q", tc.wantDirection, direction)
+			}
+		})
+	}
+}
+
+func TestPageItems(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	testCases := []struct {
+		tname string
+		page  Page
+		want  []int
+	}{
+		{
+			tname: "all items",
+			want:  []int{1, 2, 3, 4, 5},
+		},
+		{
+			tname: "first page",
+			page:  Page{Limit: 2},
+			want:  []int{1, 2},
+		},
+		{
+			tname: "last page",
+			page:  Page{Limit: 2, Offset: 4},
+			want:  []int{5},
+		},
+		{
+			tname: "offset without limit",
+			page:  Page{Offset: 3},
+			want:  []int{4, 5},
+		},
+		{
+			tname: "past the end",
+			page:  Page{Limit: 2, Offset: 6},
+			want:  []int{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			got := PageItems(items, tc.page)
+
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("want items %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package submission
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NSFWPolicy defines whether Submissions whose image is flagged as NSFW may be
+// selected.
+type NSFWPolicy string
+
+const (
+	// NSFWExclude excludes Submissions whose image is flagged as NSFW.
+	NSFWExclude NSFWPolicy = "exclude"
+
+	// NSFWInclude selects Submissions regardless of their NSFW flag.
+	NSFWInclude NSFWPolicy = "include"
+
+	// NSFWOnly selects Submissions whose image is flagged as NSFW.
+	NSFWOnly NSFWPolicy = "only"
+)
+
+// DefaultNSFWPolicy is the NSFWPolicy applied when selecting wallpapers, unless
+// another policy is set.
+const DefaultNSFWPolicy NSFWPolicy = NSFWExclude
+
+// ParseNSFWPolicy parses a NSFWPolicy, regardless of case.
+func ParseNSFWPolicy(value string) (NSFWPolicy, error) {
+	policy := NSFWPolicy(strings.ToLower(strings.TrimSpace(value)))
+
+	switch policy {
+	case NSFWExclude, NSFWInclude, NSFWOnly:
+		return policy, nil
+	}
+
+	return "", fmt.Errorf("%w %q", ErrNSFWPolicyInvalid, value)
+}
@@ -0,0 +1,245 @@
+package submission
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// StringField is a Submission field compared to a string value.
+type StringField string
+
+const (
+	FieldAuthor    StringField = "author"
+	FieldSubreddit StringField = "subreddit"
+	FieldTag       StringField = "tag"
+)
+
+// NumberField is a Submission field compared to an integer value.
+type NumberField string
+
+const (
+	FieldHeight NumberField = "height"
+	FieldRating NumberField = "rating"
+	FieldScore  NumberField = "score"
+	FieldWidth  NumberField = "width"
+)
+
+// BoolField is a Submission field compared to a boolean value.
+type BoolField string
+
+const (
+	FieldNSFW        BoolField = "nsfw"
+	FieldRemoved     BoolField = "removed"
+	FieldShown       BoolField = "shown"
+	FieldUnavailable BoolField = "unavailable"
+)
+
+// DateField is a Submission field compared to a date range.
+type DateField string
+
+const (
+	FieldPosted DateField = "posted"
+)
+
+// Operator is a comparison operator for NumberConditions.
+type Operator string
+
+const (
+	OperatorEqual          Operator = "="
+	OperatorNotEqual       Operator = "!="
+	OperatorLess           Operator = "<"
+	OperatorLessOrEqual    Operator = "<="
+	OperatorGreater        Operator = ">"
+	OperatorGreaterOrEqual Operator = ">="
+)
+
+// Query is the syntax tree of a Submission query, where all Conditions must
+// match.
+type Query struct {
+	Conditions []Condition
+}
+
+// Condition is a node of a Query's syntax tree.
+type Condition interface {
+	// match returns whether a Submission matches this Condition, where shown
+	// reports whether the Submission is present in the History.
+	//
+	// The Submission's Subreddit MUST be fully loaded.
+	match(s *Submission, shown bool) bool
+}
+
+// TextCondition matches Submissions whose title, author, subreddit name or
+// tags contain a SearchTerm.
+type TextCondition struct {
+	Term SearchTerm
+}
+
+// StringCondition matches Submissions whose field is equal to a value,
+// regardless of case.
+type StringCondition struct {
+	Field StringField
+	Value string
+}
+
+// NumberCondition matches Submissions whose field compares to a value.
+type NumberCondition struct {
+	Field    NumberField
+	Operator Operator
+	Value    int
+}
+
+// BoolCondition matches Submissions whose field is equal to a value.
+type BoolCondition struct {
+	Field BoolField
+	Value bool
+}
+
+// DateCondition matches Submissions whose field is within a date range.
+type DateCondition struct {
+	Field DateField
+
+	// From is the inclusive start of the range, or the zero time if the range
+	// has no start.
+	From time.Time
+
+	// To is the exclusive end of the range, or the zero time if the range has
+	// no end.
+	To time.Time
+}
+
+// NotCondition matches Submissions that do not match a Condition.
+type NotCondition struct {
+	Condition Condition
+}
+
+// IsEmpty returns whether this Query has no Conditions.
+func (q *Query) IsEmpty() bool {
+	return q == nil || len(q.Conditions) == 0
+}
+
+// match returns whether a Submission matches all of this Query's Conditions.
+func (q *Query) match(s *Submission, shown bool) bool {
+	for _, condition := range q.Conditions {
+		if !condition.match(s, shown) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// splitSearchTerms returns the SearchTerms of this Query's TextConditions, and
+// a Query with the remaining Conditions.
+func (q *Query) splitSearchTerms() ([]SearchTerm, *Query) {
+	terms := []SearchTerm{}
+	remaining := &Query{Conditions: []Condition{}}
+
+	for _, condition := range q.Conditions {
+		if textCondition, ok := condition.(*TextCondition); ok {
+			terms = append(terms, textCondition.Term)
+			continue
+		}
+
+		remaining.Conditions = append(remaining.Conditions, condition)
+	}
+
+	return terms, remaining
+}
+
+func (c *TextCondition) match(s *Submission, _ bool) bool {
+	fields := []string{
+		s.Title,
+		strings.Join(s.Tags, " "),
+		s.Subreddit.Name,
+		s.Author,
+	}
+
+	return slices.ContainsFunc(fields, func(field string) bool {
+		return len(newSearchField(field).matches(c.Term)) > 0
+	})
+}
+
+func (c *StringCondition) match(s *Submission, _ bool) bool {
+	switch c.Field {
+	case FieldAuthor:
+		return strings.EqualFold(s.Author, c.Value)
+	case FieldSubreddit:
+		return strings.EqualFold(s.Subreddit.Name, c.Value)
+	case FieldTag:
+		return slices.ContainsFunc(s.Tags, func(tag string) bool {
+			return strings.EqualFold(tag, c.Value)
+		})
+	}
+
+	return false
+}
+
+func (c *NumberCondition) match(s *Submission, _ bool) bool {
+	var value int
+
+	switch c.Field {
+	case FieldHeight:
+		value = s.ImageHeightPx
+	case FieldRating:
+		value = s.Rating
+	case FieldScore:
+		value = s.Score
+	case FieldWidth:
+		value = s.ImageWidthPx
+	default:
+		return false
+	}
+
+	switch c.Operator {
+	case OperatorEqual:
+		return value == c.Value
+	case OperatorNotEqual:
+		return value != c.Value
+	case OperatorLess:
+		return value < c.Value
+	case OperatorLessOrEqual:
+		return value <= c.Value
+	case OperatorGreater:
+		return value > c.Value
+	case OperatorGreaterOrEqual:
+		return value >= c.Value
+	}
+
+	return false
+}
+
+func (c *BoolCondition) match(s *Submission, shown bool) bool {
+	switch c.Field {
+	case FieldNSFW:
+		return s.ImageNSFW == c.Value
+	case FieldRemoved:
+		return s.Removed == c.Value
+	case FieldShown:
+		return shown == c.Value
+	case FieldUnavailable:
+		return s.ImageUnavailable == c.Value
+	}
+
+	return false
+}
+
+func (c *DateCondition) match(s *Submission, _ bool) bool {
+	if c.Field != FieldPosted {
+		return false
+	}
+
+	if !c.From.IsZero() && s.PostedAt.Before(c.From) {
+		return false
+	}
+
+	if !c.To.IsZero() && !s.PostedAt.Before(c.To) {
+		return false
+	}
+
+	return true
+}
+
+func (c *NotCondition) match(s *Submission, shown bool) bool {
+	return !c.Condition.match(s, shown)
+}
@@ -0,0 +1,254 @@
+package submission
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var queryFieldRegexp = regexp.MustCompile(`^([A-Za-z_]+)(>=|<=|!=|>|<|=|:)(.*)$`)
+
+// ParseQuery parses a query string into a Query.
+//
+// A query is a whitespace-separated list of conditions, which must all match:
+//
+//   - `field:value` or `field OPERATOR value` compares a Submission field,
+//     where OPERATOR is one of `=`, `!=`, `<`, `<=`, `>`, `>=`;
+//   - any other word or double-quoted phrase is parsed as a SearchTerm (see
+//     ParseSearchText), including words that look like an unknown field,
+//     e.g. `Re:Zero` or `https://example.com`;
+//   - a leading `-` negates a condition.
+//
+// Dates are expressed as `YYYY`, `YYYY-MM` or `YYYY-MM-DD`, and ranges as
+// `FROM..TO`, where either bound may be omitted, e.g.
+// `subreddit:EarthPorn score>=5000 nsfw:false posted:2023.. "mountain lake"`.
+func ParseQuery(text string) (*Query, error) {
+	query := &Query{Conditions: []Condition{}}
+
+	for _, token := range queryTokens(text) {
+		negated := false
+
+		if len(token) > 1 && token[0] == '-' {
+			negated = true
+			token = token[1:]
+		}
+
+		conditions, err := parseQueryToken(token)
+		if err != nil {
+			return &Query{}, err
+		}
+
+		for _, condition := range conditions {
+			if negated {
+				condition = &NotCondition{Condition: condition}
+			}
+
+			query.Conditions = append(query.Conditions, condition)
+		}
+	}
+
+	return query, nil
+}
+
+// queryTokens splits a query string on whitespace, except within double
+// quotes.
+func queryTokens(text string) []string {
+	tokens := []string{}
+
+	var builder strings.Builder
+
+	quoted := false
+
+	for _, r := range text {
+		if r == '"' {
+			quoted = !quoted
+		}
+
+		if unicode.IsSpace(r) && !quoted {
+			if builder.Len() > 0 {
+				tokens = append(tokens, builder.String())
+				builder.Reset()
+			}
+
+			continue
+		}
+
+		builder.WriteRune(r)
+	}
+
+	if builder.Len() > 0 {
+		tokens = append(tokens, builder.String())
+	}
+
+	return tokens
+}
+
+func parseQueryToken(token string) ([]Condition, error) {
+	submatches := queryFieldRegexp.FindStringSubmatch(token)
+	if submatches == nil || !isQueryField(strings.ToLower(submatches[1])) {
+		conditions := []Condition{}
+
+		for _, term := range ParseSearchText(token) {
+			conditions = append(conditions, &TextCondition{Term: term})
+		}
+
+		return conditions, nil
+	}
+
+	name := strings.ToLower(submatches[1])
+	operator := Operator(submatches[2])
+	value := strings.Trim(submatches[3], `"`)
+
+	condition, err := parseQueryCondition(name, operator, value)
+	if err != nil {
+		return []Condition{}, err
+	}
+
+	return []Condition{condition}, nil
+}
+
+// isQueryField returns whether name is a Submission field that can be used in
+// a query condition.
+func isQueryField(name string) bool {
+	switch name {
+	case string(FieldAuthor), string(FieldSubreddit), string(FieldTag),
+		string(FieldHeight), string(FieldRating), string(FieldScore), string(FieldWidth),
+		string(FieldNSFW), string(FieldRemoved), string(FieldShown), string(FieldUnavailable),
+		string(FieldPosted):
+		return true
+	}
+
+	return false
+}
+
+func parseQueryCondition(name string, operator Operator, value string) (Condition, error) {
+	switch field := StringField(name); field {
+	case FieldAuthor, FieldSubreddit, FieldTag:
+		if operator != ":" && operator != OperatorEqual {
+			return nil, fmt.Errorf("%w %q for field %q", ErrQueryOperatorInvalid, operator, name)
+		}
+
+		value = strings.TrimSpace(value)
+		if value == "" {
+			return nil, fmt.Errorf("%w for field %q: empty value", ErrQueryValueInvalid, name)
+		}
+
+		return &StringCondition{Field: field, Value: value}, nil
+	}
+
+	switch field := NumberField(name); field {
+	case FieldHeight, FieldRating, FieldScore, FieldWidth:
+		if operator == ":" {
+			operator = OperatorEqual
+		}
+
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w for field %q: %q is not an integer", ErrQueryValueInvalid, name, value)
+		}
+
+		return &NumberCondition{Field: field, Operator: operator, Value: number}, nil
+	}
+
+	switch field := BoolField(name); field {
+	case FieldNSFW, FieldRemoved, FieldShown, FieldUnavailable:
+		if operator != ":" && operator != OperatorEqual {
+			return nil, fmt.Errorf("%w %q for field %q", ErrQueryOperatorInvalid, operator, name)
+		}
+
+		boolean, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w for field %q: %q is not a boolean", ErrQueryValueInvalid, name, value)
+		}
+
+		return &BoolCondition{Field: field, Value: boolean}, nil
+	}
+
+	if field := DateField(name); field == FieldPosted {
+		return parseDateCondition(field, operator, value)
+	}
+
+	return nil, fmt.Errorf("%w %q", ErrQueryFieldUnknown, name)
+}
+
+func parseDateCondition(field DateField, operator Operator, value string) (Condition, error) {
+	condition := &DateCondition{Field: field}
+
+	if operator == ":" || operator == OperatorEqual {
+		fromValue, toValue, isRange := strings.Cut(value, "..")
+		if !isRange {
+			toValue = fromValue
+		}
+
+		if fromValue == "" && toValue == "" {
+			return nil, fmt.Errorf("%w for field %q: empty date range", ErrQueryValueInvalid, field)
+		}
+
+		if fromValue != "" {
+			start, _, err := parseDatePeriod(fromValue)
+			if err != nil {
+				return nil, fmt.Errorf("%w for field %q: %w", ErrQueryValueInvalid, field, err)
+			}
+
+			condition.From = start
+		}
+
+		if toValue != "" {
+			_, end, err := parseDatePeriod(toValue)
+			if err != nil {
+				return nil, fmt.Errorf("%w for field %q: %w", ErrQueryValueInvalid, field, err)
+			}
+
+			condition.To = end
+		}
+
+		return condition, nil
+	}
+
+	start, end, err := parseDatePeriod(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w for field %q: %w", ErrQueryValueInvalid, field, err)
+	}
+
+	switch operator {
+	case OperatorGreaterOrEqual:
+		condition.From = start
+	case OperatorGreater:
+		condition.From = end
+	case OperatorLess:
+		condition.To = start
+	case OperatorLessOrEqual:
+		condition.To = end
+	default:
+		return nil, fmt.Errorf("%w %q for field %q", ErrQueryOperatorInvalid, operator, field)
+	}
+
+	return condition, nil
+}
+
+// parseDatePeriod parses a year, month or day, and returns the start and the
+// (exclusive) end of this period, in UTC.
+func parseDatePeriod(value string) (time.Time, time.Time, error) {
+	periods := []struct {
+		layout              string
+		years, months, days int
+	}{
+		{layout: "2006", years: 1},
+		{layout: "2006-01", months: 1},
+		{layout: "2006-01-02", days: 1},
+	}
+
+	for _, period := range periods {
+		start, err := time.Parse(period.layout, value)
+		if err != nil {
+			continue
+		}
+
+		return start, start.AddDate(period.years, period.months, period.days), nil
+	}
+
+	return time.Time{}, time.Time{}, fmt.Errorf("%q is not a date", value)
+}
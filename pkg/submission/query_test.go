@@ -0,0 +1,163 @@
+package submission
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseQuery(t *testing.T) {
+	testCases := []struct {
+		tname   string
+		text    string
+		want    []Condition
+		wantErr error
+	}{
+		// nominal cases
+		{
+			tname: "empty",
+			text:  "   ",
+			want:  []Condition{},
+		},
+		{
+			tname: "search terms",
+			text:  `"mountain lake" sun* -winter`,
+			want: []Condition{
+				&TextCondition{Term: SearchTerm{Text: "mountain lake"}},
+				&TextCondition{Term: SearchTerm{Text: "sun", Prefix: true}},
+				&NotCondition{Condition: &TextCondition{Term: SearchTerm{Text: "winter"}}},
+			},
+		},
+		{
+			tname: "string fields",
+			text:  `subreddit:EarthPorn Author=foo tag:"winter" -tag:space`,
+			want: []Condition{
+				&StringCondition{Field: FieldSubreddit, Value: "EarthPorn"},
+				&StringCondition{Field: FieldAuthor, Value: "foo"},
+				&StringCondition{Field: FieldTag, Value: "winter"},
+				&NotCondition{Condition: &StringCondition{Field: FieldTag, Value: "space"}},
+			},
+		},
+		{
+			tname: "number fields",
+			text:  "score>=5000 width>3839 height<=2160 rating!=-1 score:42",
+			want: []Condition{
+				&NumberCondition{Field: FieldScore, Operator: OperatorGreaterOrEqual, Value: 5000},
+				&NumberCondition{Field: FieldWidth, Operator: OperatorGreater, Value: 3839},
+				&NumberCondition{Field: FieldHeight, Operator: OperatorLessOrEqual, Value: 2160},
+				&NumberCondition{Field: FieldRating, Operator: OperatorNotEqual, Value: -1},
+				&NumberCondition{Field: FieldScore, Operator: OperatorEqual, Value: 42},
+			},
+		},
+		{
+			tname: "bool fields",
+			text:  "nsfw:false removed=true -shown:true",
+			want: []Condition{
+				&BoolCondition{Field: FieldNSFW, Value: false},
+				&BoolCondition{Field: FieldRemoved, Value: true},
+				&NotCondition{Condition: &BoolCondition{Field: FieldShown, Value: true}},
+			},
+		},
+		{
+			tname: "date ranges",
+			text:  "posted:2023.. posted:..2022-06 posted:2021-02-03 posted:2020..2021 posted>2019 posted<2018-05",
+			want: []Condition{
+				&DateCondition{Field: FieldPosted, From: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+				&DateCondition{Field: FieldPosted, To: time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC)},
+				&DateCondition{
+					Field: FieldPosted,
+					From:  time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC),
+					To:    time.Date(2021, 2, 4, 0, 0, 0, 0, time.UTC),
+				},
+				&DateCondition{
+					Field: FieldPosted,
+					From:  time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+					To:    time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+				},
+				&DateCondition{Field: FieldPosted, From: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+				&DateCondition{Field: FieldPosted, To: time.Date(2018, 5, 1, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		{
+			tname: "unknown fields as search terms",
+			text:  `Re:Zero https://example.com/image.jpg -colour:red "Steins;Gate 0" author:alice`,
+			want: []Condition{
+				&TextCondition{Term: SearchTerm{Text: "Re:Zero"}},
+				&TextCondition{Term: SearchTerm{Text: "https://example.com/image.jpg"}},
+				&NotCondition{Condition: &TextCondition{Term: SearchTerm{Text: "colour:red"}}},
+				&TextCondition{Term: SearchTerm{Text: "Steins;Gate 0"}},
+				&StringCondition{Field: FieldAuthor, Value: "alice"},
+			},
+		},
+		{
+			tname: "unknown field with an operator",
+			text:  "colour>=red",
+			want: []Condition{
+				&TextCondition{Term: SearchTerm{Text: "colour>=red"}},
+			},
+		},
+
+		// error cases
+		{
+			tname:   "invalid string operator",
+			text:    "subreddit>=EarthPorn",
+			wantErr: ErrQueryOperatorInvalid,
+		},
+		{
+			tname:   "empty string value",
+			text:    `author:""`,
+			wantErr: ErrQueryValueInvalid,
+		},
+		{
+			tname:   "invalid number",
+			text:    "score>=lots",
+			wantErr: ErrQueryValueInvalid,
+		},
+		{
+			tname:   "invalid boolean",
+			text:    "nsfw:maybe",
+			wantErr: ErrQueryValueInvalid,
+		},
+		{
+			tname:   "invalid date",
+			text:    "posted:yesterday",
+			wantErr: ErrQueryValueInvalid,
+		},
+		{
+			tname:   "empty date range",
+			text:    "posted:..",
+			wantErr: ErrQueryValueInvalid,
+		},
+		{
+			tname:   "invalid date operator",
+			text:    "posted!=2023",
+			wantErr: ErrQueryOperatorInvalid,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			got, err := ParseQuery(tc.text)
+
+			if tc.wantErr != nil {
+				if err == nil {
+					t.Error("expected an error but got none")
+				} else if !errors.Is(err, tc.wantErr) {
+					t.Errorf("want error %q, got %q", tc.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error, got %q", err)
+				return
+			}
+
+			if !reflect.DeepEqual(got.Conditions, tc.want) {
+				t.Errorf("want conditions %#v, got %#v", tc.want, got.Conditions)
+			}
+		})
+	}
+}
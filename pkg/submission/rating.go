@@ -0,0 +1,22 @@
+package submission
+
+const (
+	// RatingDisliked marks a Submission that must not be selected again.
+	RatingDisliked int = -1
+
+	// RatingNone is the rating of Submissions that were not rated.
+	RatingNone int = 0
+
+	// RatingFavorite is the highest rating, and marks a favorite Submission.
+	RatingFavorite int = 5
+)
+
+// ValidateRating ensures a rating is within the accepted range, from
+// RatingDisliked to RatingFavorite.
+func ValidateRating(rating int) error {
+	if rating < RatingDisliked || rating > RatingFavorite {
+		return ErrRatingInvalid
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package submission
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RepeatMode defines how RepeatPolicy determines whether a Submission that was
+// already selected may be selected again.
+type RepeatMode string
+
+const (
+	// RepeatNever never selects a Submission twice.
+	RepeatNever RepeatMode = "never"
+
+	// RepeatAfterDays selects a Submission again once it has not been
+	// selected for a given number of days.
+	RepeatAfterDays RepeatMode = "days"
+
+	// RepeatAfterSelections selects a Submission again once a given number of
+	// other selections have been made.
+	RepeatAfterSelections RepeatMode = "selections"
+
+	// RepeatCycle selects a Submission again once all the candidates have been
+	// selected as many times.
+	RepeatCycle RepeatMode = "cycle"
+)
+
+// DefaultRepeatPolicy is the RepeatPolicy applied when selecting wallpapers,
+// unless another policy is set.
+var DefaultRepeatPolicy = RepeatPolicy{Mode: RepeatNever}
+
+// RepeatPolicy defines when a Submission that is already present in the
+// History may be selected again.
+type RepeatPolicy struct {
+	Mode RepeatMode
+
+	// Count is the number of days or selections after which a Submission may
+	// be selected again, for the RepeatAfterDays and RepeatAfterSelections
+	// modes.
+	Count int
+}
+
+// ParseRepeatPolicy parses a RepeatPolicy, formatted as "never", "cycle",
+// "days:N" or "selections:N".
+func ParseRepeatPolicy(value string) (RepeatPolicy, error) {
+	mode, count, hasCount := strings.Cut(strings.ToLower(strings.TrimSpace(value)), ":")
+
+	policy := RepeatPolicy{Mode: RepeatMode(mode)}
+
+	if hasCount {
+		var err error
+
+		policy.Count, err = strconv.Atoi(count)
+		if err != nil {
+			return RepeatPolicy{}, fmt.Errorf("%w %q", ErrRepeatPolicyInvalid, value)
+		}
+	}
+
+	if err := policy.Validate(); err != nil {
+		return RepeatPolicy{}, fmt.Errorf("%w %q", ErrRepeatPolicyInvalid, value)
+	}
+
+	return policy, nil
+}
+
+// String returns the textual representation of the RepeatPolicy, as accepted
+// by ParseRepeatPolicy.
+func (p RepeatPolicy) String() string {
+	switch p.Mode {
+	case RepeatAfterDays, RepeatAfterSelections:
+		return fmt.Sprintf("%s:%d", p.Mode, p.Count)
+	}
+
+	return string(p.Mode)
+}
+
+// Validate ensures the RepeatPolicy has a known mode, and a positive Count for
+// modes that require one.
+func (p RepeatPolicy) Validate() error {
+	switch p.Mode {
+	case RepeatNever, RepeatCycle:
+		if p.Count != 0 {
+			return ErrRepeatPolicyInvalid
+		}
+	case RepeatAfterDays, RepeatAfterSelections:
+		if p.Count < 1 {
+			return ErrRepeatPolicyInvalid
+		}
+	default:
+		return ErrRepeatPolicyInvalid
+	}
+
+	return nil
+}
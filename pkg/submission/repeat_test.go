@@ -0,0 +1,98 @@
+package submission
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseRepeatPolicy(t *testing.T) {
+	testCases := []struct {
+		tname   string
+		value   string
+		want    RepeatPolicy
+		wantErr error
+	}{
+		// nominal cases
+		{
+			tname: "never",
+			value: "never",
+			want:  RepeatPolicy{Mode: RepeatNever},
+		},
+		{
+			tname: "cycle, mixed case",
+			value: " Cycle ",
+			want:  RepeatPolicy{Mode: RepeatCycle},
+		},
+		{
+			tname: "after days",
+			value: "days:30",
+			want:  RepeatPolicy{Mode: RepeatAfterDays, Count: 30},
+		},
+		{
+			tname: "after selections",
+			value: "selections:100",
+			want:  RepeatPolicy{Mode: RepeatAfterSelections, Count: 100},
+		},
+
+		// error cases
+		{
+			tname:   "unknown mode",
+			value:   "always",
+			wantErr: ErrRepeatPolicyInvalid,
+		},
+		{
+			tname:   "empty value",
+			value:   "",
+			wantErr: ErrRepeatPolicyInvalid,
+		},
+		{
+			tname:   "missing count",
+			value:   "days",
+			wantErr: ErrRepeatPolicyInvalid,
+		},
+		{
+			tname:   "zero count",
+			value:   "selections:0",
+			wantErr: ErrRepeatPolicyInvalid,
+		},
+		{
+			tname:   "invalid count",
+			value:   "days:thirty",
+			wantErr: ErrRepeatPolicyInvalid,
+		},
+		{
+			tname:   "unexpected count",
+			value:   "never:3",
+			wantErr: ErrRepeatPolicyInvalid,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			got, err := ParseRepeatPolicy(tc.value)
+
+			if tc.wantErr != nil {
+				if err == nil {
+					t.Error("expected an error but got none")
+				} else if !errors.Is(err, tc.wantErr) {
+					t.Errorf("want error %q, got %q", tc.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error, got %q", err)
+				return
+			}
+
+			if got != tc.want {
+				t.Errorf("want policy %q, got %q", tc.want, got)
+			}
+
+			if got.String() != tc.want.String() {
+				t.Errorf("want string %q, got %q", tc.want.String(), got.String())
+			}
+		})
+	}
+}
@@ -1,55 +1,128 @@
 package submission
 
-import "github.com/virtualtam/walric/pkg/monitor"
+import (
+	"context"
+
+	"github.com/virtualtam/walric/pkg/monitor"
+)
 
 // ValidationRepository provides methods for Submission validation.
 type ValidationRepository interface {
+	// BanIsPostIDRegistered returns whether this Reddit post ID was banned.
+	BanIsPostIDRegistered(ctx context.Context, postID string) (bool, error)
+
 	// SubmissionIsPostIDRegistered returns whether this Submission was previously saved.
-	SubmissionIsPostIDRegistered(postID string) (bool, error)
+	SubmissionIsPostIDRegistered(ctx context.Context, postID string) (bool, error)
 
 	// SubredditIsNameRegistered returns whether this Subreddit was previously saved.
-	SubredditIsNameRegistered(name string) (bool, error)
+	SubredditIsNameRegistered(ctx context.Context, name string) (bool, error)
 }
 
 // Repository defines the basic operations available to access and persist
 // Reddit Submissions.
+//
+// The Submissions returned by a Repository MUST have their Subreddit fully
+// loaded.
 type Repository interface {
 	ValidationRepository
 
+	// WithTx runs fn within a transaction, with a Repository whose operations
+	// are committed if fn returns nil, and rolled back otherwise.
+	WithTx(ctx context.Context, fn func(r Repository) error) error
+
+	// BanIsImageSHA256Registered returns whether an image with this SHA-256
+	// checksum was banned.
+	BanIsImageSHA256Registered(ctx context.Context, imageSHA256 string) (bool, error)
+
+	// BanCreate creates and persists a Ban.
+	BanCreate(ctx context.Context, ban *Ban) error
+
+	// SubmissionGetAll returns all persisted Submissions.
+	SubmissionGetAll(ctx context.Context) ([]*Submission, error)
+
+	// SubmissionGetByFilter returns the Submissions matching the specified
+	// Filter, sorted and paginated according to the specified ListOptions.
+	SubmissionGetByFilter(ctx context.Context, filter *Filter, options *ListOptions) ([]*Submission, error)
+
 	// SubmissionGetByID returns the Submission for a given ID.
-	SubmissionGetByID(id int) (*Submission, error)
+	SubmissionGetByID(ctx context.Context, id int) (*Submission, error)
 
-	// SubmissionGetByMinResolution returns all Submissions whose attached image's resolution
-	// is greater or equal to the specified constraints.
-	SubmissionGetByMinResolution(minResolution *monitor.Resolution) ([]*Submission, error)
+	// SubmissionGetByMinResolution returns the Submissions whose attached image's resolution
+	// is greater or equal to the specified constraints, and matching the specified Filter,
+	// sorted and paginated according to the specified ListOptions.
+	// Submissions whose image is unavailable SHOULD NOT be returned.
+	SubmissionGetByMinResolution(ctx context.Context, minResolution *monitor.Resolution, filter *Filter, options *ListOptions) ([]*Submission, error)
 
 	// SubmissionGetByPostID returns the Submission for a given Reddit post ID.
-	SubmissionGetByPostID(postID string) (*Submission, error)
+	SubmissionGetByPostID(ctx context.Context, postID string) (*Submission, error)
+
+	// SubmissionGetBySubredditID returns all Submissions for a given Subreddit ID.
+	SubmissionGetBySubredditID(ctx context.Context, subredditID int) ([]*Submission, error)
 
-	// SubmissionSearch returns all submissions whose title contains the specified text.
+	// SubmissionSearch returns the submissions matching all the specified terms in
+	// their title, author, subreddit name or tags, and matching the specified Filter,
+	// paginated according to the specified ListOptions.
+	// Results MUST be sorted by the ListOptions' SortKey, then by decreasing relevance.
 	// The search SHOULD BE case-insensitive.
-	SubmissionSearch(text string) ([]*Submission, error)
+	SubmissionSearch(ctx context.Context, terms []SearchTerm, filter *Filter, options *ListOptions) ([]*SearchResult, error)
 
 	// SubmissionGetRandom returns a randomly selected Submission whose attached image's
-	// resolution is greater or equal to the specified constraints.
-	// The Submission SHOULD NOT already be present in the History.
-	SubmissionGetRandom(minResolution *monitor.Resolution) (*Submission, error)
+	// resolution is greater or equal to the specified constraints, and matching the
+	// specified Filter.
+	// The Submission SHOULD NOT have an unavailable image, and MAY only be
+	// present in the History if the specified RepeatPolicy allows it.
+	SubmissionGetRandom(ctx context.Context, minResolution *monitor.Resolution, filter *Filter, repeat RepeatPolicy) (*Submission, error)
+
+	// SubmissionGetRandomCandidates returns all the Submissions that
+	// SubmissionGetRandom may select for the specified constraints, Filter and
+	// RepeatPolicy.
+	SubmissionGetRandomCandidates(ctx context.Context, minResolution *monitor.Resolution, filter *Filter, repeat RepeatPolicy) ([]*Submission, error)
 
 	// SubmissionCreate creates and persists a Submission.
-	SubmissionCreate(submission *Submission) error
+	SubmissionCreate(ctx context.Context, submission *Submission) error
+
+	// SubmissionUpdate updates an existing Submission.
+	// The Submission's rating and tags are left unchanged, see SubmissionRate,
+	// SubmissionAddTags and SubmissionRemoveTags.
+	SubmissionUpdate(ctx context.Context, submission *Submission) error
+
+	// SubmissionRate sets the rating for the Submission with a given ID.
+	// Setting RatingNone clears the rating.
+	SubmissionRate(ctx context.Context, id int, rating int) error
+
+	// SubmissionAddTags adds tags to the Submission with a given ID.
+	// Tags already set on the Submission are ignored.
+	SubmissionAddTags(ctx context.Context, id int, tags []string) error
+
+	// SubmissionRemoveTags removes tags from the Submission with a given ID.
+	// Tags not set on the Submission are ignored.
+	SubmissionRemoveTags(ctx context.Context, id int, tags []string) error
+
+	// SubmissionDelete deletes the Submission for a given ID, and the
+	// corresponding History entries, rating, tags and collection items.
+	SubmissionDelete(ctx context.Context, id int) error
+
+	// SubmissionDeleteMany deletes the Submissions for the given IDs, and the
+	// corresponding History entries, ratings, tags and collection items.
+	// Either all or none of the Submissions MUST be deleted.
+	SubmissionDeleteMany(ctx context.Context, ids []int) error
 
 	// SubredditGetAll returns all persisted Subreddits.
-	SubredditGetAll() ([]*Subreddit, error)
+	SubredditGetAll(ctx context.Context) ([]*Subreddit, error)
 
 	// SubredditGetStats returns the aggregated usage statistics for all Subreddits.
-	SubredditGetStats() ([]SubredditStats, error)
+	SubredditGetStats(ctx context.Context) ([]SubredditStats, error)
 
 	// SubredditGetByID returns the Subreddit for a given ID.
-	SubredditGetByID(id int) (*Subreddit, error)
+	SubredditGetByID(ctx context.Context, id int) (*Subreddit, error)
 
 	// SubredditGetByName returns the Subreddit for a given Name.
-	SubredditGetByName(name string) (*Subreddit, error)
+	SubredditGetByName(ctx context.Context, name string) (*Subreddit, error)
 
 	// SubredditCreate creates and persists a Subreddit.
-	SubredditCreate(subreddit *Subreddit) error
+	SubredditCreate(ctx context.Context, subreddit *Subreddit) error
+
+	// TagGetStats returns the aggregated usage statistics for all tags set
+	// on at least one Submission.
+	TagGetStats(ctx context.Context) ([]TagStats, error)
 }
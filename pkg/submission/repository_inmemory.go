@@ -1,35 +1,306 @@
 package submission
 
 import (
-	"errors"
+	"cmp"
+	"context"
+	"maps"
 	"math/rand"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/virtualtam/walric/pkg/monitor"
 )
 
 var _ Repository = &RepositoryInMemory{}
 
-// repositoryInMemory provides an in-memory Repository for testing.
+// RepositoryInMemory provides an in-memory Repository for testing.
 type RepositoryInMemory struct {
+	banCurrentID int
+	bans         []*Ban
+
 	submissionCurrentID int
 	submissions         []*Submission
 
 	subredditCurrentID int
 	subreddits         []*Subreddit
+
+	selections []selection
+}
+
+// selection records when a Submission was selected, to evaluate RepeatPolicies.
+type selection struct {
+	submissionID int
+	date         time.Time
 }
 
 func NewRepositoryInMemory(submissions []*Submission, subreddits []*Subreddit) *RepositoryInMemory {
-	return &RepositoryInMemory{
+	r := &RepositoryInMemory{
+		banCurrentID: 1,
+
 		submissionCurrentID: len(submissions) + 1,
 		submissions:         submissions,
 
 		subredditCurrentID: len(subreddits) + 1,
 		subreddits:         subreddits,
 	}
+
+	// IDs may not be contiguous, e.g. once Submissions have been deleted
+	for _, submission := range submissions {
+		r.submissionCurrentID = max(r.submissionCurrentID, submission.ID+1)
+	}
+
+	for _, subreddit := range subreddits {
+		r.subredditCurrentID = max(r.subredditCurrentID, subreddit.ID+1)
+	}
+
+	for _, submission := range submissions {
+		r.loadSubreddit(submission)
+	}
+
+	return r
+}
+
+// loadSubreddit replaces a Submission's Subreddit with the persisted one, so
+// that Submissions are returned with their Subreddit fully loaded.
+func (r *RepositoryInMemory) loadSubreddit(submission *Submission) {
+	if submission.Subreddit == nil {
+		return
+	}
+
+	if subreddit, err := r.subredditByID(submission.Subreddit.ID); err == nil {
+		submission.Subreddit = subreddit
+	}
 }
 
-func (r *RepositoryInMemory) SubmissionGetByID(id int) (*Submission, error) {
+// RecordSelection records that a Submission was selected at a given date, as
+// the History would.
+func (r *RepositoryInMemory) RecordSelection(submissionID int, date time.Time) {
+	r.selections = append(r.selections, selection{submissionID: submissionID, date: date})
+}
+
+// WithTx runs fn with this Repository, and restores its previous state if fn
+// returns an error.
+func (r *RepositoryInMemory) WithTx(ctx context.Context, fn func(r Repository) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	snapshot := r.snapshot()
+
+	if err := fn(r); err != nil {
+		*r = *snapshot
+		return err
+	}
+
+	return nil
+}
+
+// snapshot returns a copy of this Repository's state.
+//
+// Submissions are copied as they may be updated in place, while Bans and
+// Subreddits are only ever appended.
+func (r *RepositoryInMemory) snapshot() *RepositoryInMemory {
+	snapshot := *r
+	snapshot.bans = slices.Clone(r.bans)
+	snapshot.subreddits = slices.Clone(r.subreddits)
+	snapshot.selections = slices.Clone(r.selections)
+
+	snapshot.submissions = make([]*Submission, len(r.submissions))
+	for index, submission := range r.submissions {
+		submissionCopy := *submission
+		submissionCopy.Tags = slices.Clone(submission.Tags)
+		snapshot.submissions[index] = &submissionCopy
+	}
+
+	return &snapshot
+}
+
+func (r *RepositoryInMemory) BanIsPostIDRegistered(ctx context.Context, postID string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	for _, ban := range r.bans {
+		if ban.PostID == postID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *RepositoryInMemory) BanIsImageSHA256Registered(ctx context.Context, imageSHA256 string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	for _, ban := range r.bans {
+		if ban.ImageSHA256 != "" && ban.ImageSHA256 == imageSHA256 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// BanGetAll returns all persisted Bans.
+func (r *RepositoryInMemory) BanGetAll(ctx context.Context) ([]*Ban, error) {
+	if err := ctx.Err(); err != nil {
+		return []*Ban{}, err
+	}
+
+	return r.bans, nil
+}
+
+func (r *RepositoryInMemory) BanCreate(ctx context.Context, ban *Ban) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for _, existing := range r.bans {
+		if existing.PostID == ban.PostID {
+			return ErrBanPostIDAlreadyRegistered
+		}
+	}
+
+	ban.ID = r.banCurrentID
+	r.banCurrentID++
+
+	r.bans = append(r.bans, ban)
+
+	return nil
+}
+
+func (r *RepositoryInMemory) SubmissionGetAll(ctx context.Context) ([]*Submission, error) {
+	if err := ctx.Err(); err != nil {
+		return []*Submission{}, err
+	}
+
+	return r.submissions, nil
+}
+
+func (r *RepositoryInMemory) SubmissionGetByFilter(ctx context.Context, filter *Filter, options *ListOptions) ([]*Submission, error) {
+	if err := ctx.Err(); err != nil {
+		return []*Submission{}, err
+	}
+
+	submissions, err := r.filterSubmissions(r.submissions, filter)
+	if err != nil {
+		return []*Submission{}, err
+	}
+
+	return r.listSubmissions(submissions, options), nil
+}
+
+// listSubmissions returns the Page of Submissions selected by ListOptions,
+// sorted by their SortKey, then by Subreddit name, posting date and ID.
+func (r *RepositoryInMemory) listSubmissions(submissions []*Submission, options *ListOptions) []*Submission {
+	compare := r.compareSubmissions(options)
+
+	submissions = slices.Clone(submissions)
+	slices.SortStableFunc(submissions, func(a, b *Submission) int {
+		if compare != nil {
+			if order := compare(a, b); order != 0 {
+				return order
+			}
+		}
+
+		return cmp.Or(
+			strings.Compare(r.subredditSortName(a), r.subredditSortName(b)),
+			a.PostedAt.Compare(b.PostedAt),
+			cmp.Compare(a.ID, b.ID),
+		)
+	})
+
+	return PageItems(submissions, options.Page)
+}
+
+// subredditSortName returns the name of a Submission's Subreddit, used to
+// sort Submissions regardless of case.
+func (r *RepositoryInMemory) subredditSortName(s *Submission) string {
+	subreddit, err := r.subredditByID(s.Subreddit.ID)
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToLower(subreddit.Name)
+}
+
+// compareSubmissions returns a function comparing Submissions by the
+// ListOptions' SortKey and direction, or nil to keep the default order.
+func (r *RepositoryInMemory) compareSubmissions(options *ListOptions) func(a, b *Submission) int {
+	var compare func(a, b *Submission) int
+
+	switch options.Sort {
+	case SortPosted:
+		compare = func(a, b *Submission) int {
+			return a.PostedAt.Compare(b.PostedAt)
+		}
+	case SortRating:
+		compare = func(a, b *Submission) int {
+			return cmp.Compare(a.Rating, b.Rating)
+		}
+	case SortResolution:
+		compare = func(a, b *Submission) int {
+			return cmp.Compare(a.ImageWidthPx*a.ImageHeightPx, b.ImageWidthPx*b.ImageHeightPx)
+		}
+	case SortScore:
+		compare = func(a, b *Submission) int {
+			return cmp.Compare(a.Score, b.Score)
+		}
+	case SortSubreddit:
+		compare = func(a, b *Submission) int {
+			return strings.Compare(r.subredditSortName(a), r.subredditSortName(b))
+		}
+	case SortTitle:
+		compare = func(a, b *Submission) int {
+			return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+		}
+	default:
+		return nil
+	}
+
+	if options.Descending() {
+		return func(a, b *Submission) int {
+			return compare(b, a)
+		}
+	}
+
+	return compare
+}
+
+// filterSubmissions returns the Submissions matching a Filter.
+func (r *RepositoryInMemory) filterSubmissions(submissions []*Submission, filter *Filter) ([]*Submission, error) {
+	shown := map[int]bool{}
+	for _, selection := range r.selections {
+		shown[selection.submissionID] = true
+	}
+
+	results := []*Submission{}
+
+	for _, submission := range submissions {
+		subreddit, err := r.subredditByID(submission.Subreddit.ID)
+		if err != nil {
+			return []*Submission{}, err
+		}
+
+		candidate := *submission
+		candidate.Subreddit = subreddit
+
+		if filter.match(&candidate, shown[submission.ID]) {
+			results = append(results, submission)
+		}
+	}
+
+	return results, nil
+}
+
+func (r *RepositoryInMemory) SubmissionGetByID(ctx context.Context, id int) (*Submission, error) {
+	if err := ctx.Err(); err != nil {
+		return &Submission{}, err
+	}
+
 	for _, submission := range r.submissions {
 		if submission.ID == id {
 			return submission, nil
@@ -39,7 +310,11 @@ func (r *RepositoryInMemory) SubmissionGetByID(id int) (*Submission, error) {
 	return &Submission{}, ErrSubmissionNotFound
 }
 
-func (r *RepositoryInMemory) SubmissionGetByPostID(postID string) (*Submission, error) {
+func (r *RepositoryInMemory) SubmissionGetByPostID(ctx context.Context, postID string) (*Submission, error) {
+	if err := ctx.Err(); err != nil {
+		return &Submission{}, err
+	}
+
 	for _, submission := range r.submissions {
 		if submission.PostID == postID {
 			return submission, nil
@@ -49,7 +324,31 @@ func (r *RepositoryInMemory) SubmissionGetByPostID(postID string) (*Submission,
 	return &Submission{}, ErrSubmissionNotFound
 }
 
-func (r *RepositoryInMemory) SubmissionIsPostIDRegistered(postID string) (bool, error) {
+func (r *RepositoryInMemory) SubmissionGetBySubredditID(ctx context.Context, subredditID int) ([]*Submission, error) {
+	if err := ctx.Err(); err != nil {
+		return []*Submission{}, err
+	}
+
+	results := []*Submission{}
+
+	for _, submission := range r.submissions {
+		if submission.Subreddit.ID == subredditID {
+			results = append(results, submission)
+		}
+	}
+
+	slices.SortStableFunc(results, func(a, b *Submission) int {
+		return a.PostedAt.Compare(b.PostedAt)
+	})
+
+	return results, nil
+}
+
+func (r *RepositoryInMemory) SubmissionIsPostIDRegistered(ctx context.Context, postID string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
 	for _, submission := range r.submissions {
 		if submission.PostID == postID {
 			return true, nil
@@ -59,37 +358,137 @@ func (r *RepositoryInMemory) SubmissionIsPostIDRegistered(postID string) (bool,
 	return false, nil
 }
 
-func (r *RepositoryInMemory) SubmissionSearch(text string) ([]*Submission, error) {
-	results := []*Submission{}
+func (r *RepositoryInMemory) SubmissionSearch(ctx context.Context, terms []SearchTerm, filter *Filter, options *ListOptions) ([]*SearchResult, error) {
+	if err := ctx.Err(); err != nil {
+		return []*SearchResult{}, err
+	}
 
-	for _, submission := range r.submissions {
-		if strings.Contains(strings.ToLower(submission.Title), strings.ToLower(text)) {
-			results = append(results, submission)
+	candidates, err := r.filterSubmissions(r.submissions, filter)
+	if err != nil {
+		return []*SearchResult{}, err
+	}
+
+	type scoredResult struct {
+		result *SearchResult
+		score  int
+	}
+
+	scoredResults := []scoredResult{}
+
+	for _, submission := range candidates {
+		subreddit, err := r.subredditByID(submission.Subreddit.ID)
+		if err != nil {
+			return []*SearchResult{}, err
 		}
+
+		title := newSearchField(submission.Title)
+
+		// Fields are weighted by relevance, from the title to the author
+		fields := []struct {
+			field  *searchField
+			weight int
+		}{
+			{title, 10},
+			{newSearchField(strings.Join(submission.Tags, " ")), 5},
+			{newSearchField(subreddit.Name), 2},
+			{newSearchField(submission.Author), 1},
+		}
+
+		score := 0
+		titleIndexes := []int{}
+
+		for _, term := range terms {
+			termScore := 0
+
+			for _, f := range fields {
+				indexes := f.field.matches(term)
+				termScore += len(indexes) * f.weight
+
+				if f.field == title {
+					titleIndexes = append(titleIndexes, indexes...)
+				}
+			}
+
+			if termScore == 0 {
+				score = 0
+				break
+			}
+
+			score += termScore
+		}
+
+		if score == 0 {
+			continue
+		}
+
+		scoredResults = append(scoredResults, scoredResult{
+			result: &SearchResult{
+				Submission: submission,
+				Snippet:    title.highlight(titleIndexes),
+			},
+			score: score,
+		})
 	}
 
-	return results, nil
+	compare := r.compareSubmissions(options)
+
+	slices.SortStableFunc(scoredResults, func(a, b scoredResult) int {
+		if compare != nil {
+			if order := compare(a.result.Submission, b.result.Submission); order != 0 {
+				return order
+			}
+		}
+
+		return b.score - a.score
+	})
+
+	results := make([]*SearchResult, len(scoredResults))
+	for index, scored := range scoredResults {
+		results[index] = scored.result
+	}
+
+	return PageItems(results, options.Page), nil
 }
 
-func (r *RepositoryInMemory) SubmissionGetByMinResolution(minResolution *monitor.Resolution) ([]*Submission, error) {
+func (r *RepositoryInMemory) SubmissionGetByMinResolution(ctx context.Context, minResolution *monitor.Resolution, filter *Filter, options *ListOptions) ([]*Submission, error) {
+	if err := ctx.Err(); err != nil {
+		return []*Submission{}, err
+	}
+
+	candidates, err := r.minResolutionSubmissions(minResolution, filter)
+	if err != nil {
+		return []*Submission{}, err
+	}
+
+	return r.listSubmissions(candidates, options), nil
+}
+
+// minResolutionSubmissions returns the Submissions whose available image's
+// resolution is greater or equal to the specified constraints, and matching a
+// Filter.
+func (r *RepositoryInMemory) minResolutionSubmissions(minResolution *monitor.Resolution, filter *Filter) ([]*Submission, error) {
 	candidates := []*Submission{}
 	for _, submission := range r.submissions {
+		if submission.ImageUnavailable {
+			continue
+		}
+
 		if submission.ImageHeightPx >= minResolution.HeightPx && submission.ImageWidthPx >= minResolution.WidthPx {
 			candidates = append(candidates, submission)
 		}
 	}
 
-	return candidates, nil
+	return r.filterSubmissions(candidates, filter)
 }
 
-func (r *RepositoryInMemory) SubmissionGetRandom(minResolution *monitor.Resolution) (*Submission, error) {
-	if len(r.submissions) == 0 {
-		return &Submission{}, ErrSubmissionNotFound
+func (r *RepositoryInMemory) SubmissionGetRandom(ctx context.Context, minResolution *monitor.Resolution, filter *Filter, repeat RepeatPolicy) (*Submission, error) {
+	if err := ctx.Err(); err != nil {
+		return &Submission{}, err
 	}
 
-	candidates, err := r.SubmissionGetByMinResolution(minResolution)
+	candidates, err := r.SubmissionGetRandomCandidates(ctx, minResolution, filter, repeat)
 	if err != nil {
-		return &Submission{}, nil
+		return &Submission{}, err
 	}
 
 	if len(candidates) == 0 {
@@ -101,16 +500,236 @@ func (r *RepositoryInMemory) SubmissionGetRandom(minResolution *monitor.Resoluti
 	return candidates[index], nil
 }
 
-func (r *RepositoryInMemory) SubmissionCreate(submission *Submission) error {
+func (r *RepositoryInMemory) SubmissionGetRandomCandidates(ctx context.Context, minResolution *monitor.Resolution, filter *Filter, repeat RepeatPolicy) ([]*Submission, error) {
+	if err := ctx.Err(); err != nil {
+		return []*Submission{}, err
+	}
+
+	candidates, err := r.minResolutionSubmissions(minResolution, filter)
+	if err != nil {
+		return []*Submission{}, err
+	}
+
+	return r.repeatableSubmissions(candidates, repeat, time.Now().UTC()), nil
+}
+
+// repeatableSubmissions returns the candidates that a RepeatPolicy allows to
+// select at a given date.
+func (r *RepositoryInMemory) repeatableSubmissions(candidates []*Submission, repeat RepeatPolicy, now time.Time) []*Submission {
+	selections := slices.Clone(r.selections)
+	slices.SortStableFunc(selections, func(a, b selection) int {
+		return b.date.Compare(a.date)
+	})
+
+	excluded := map[int]bool{}
+
+	switch repeat.Mode {
+	case RepeatNever:
+		for _, selection := range selections {
+			excluded[selection.submissionID] = true
+		}
+
+	case RepeatAfterDays:
+		since := now.AddDate(0, 0, -repeat.Count)
+
+		for _, selection := range selections {
+			if selection.date.After(since) {
+				excluded[selection.submissionID] = true
+			}
+		}
+
+	case RepeatAfterSelections:
+		for _, selection := range selections[:min(repeat.Count, len(selections))] {
+			excluded[selection.submissionID] = true
+		}
+
+	case RepeatCycle:
+		counts := map[int]int{}
+		for _, selection := range selections {
+			counts[selection.submissionID]++
+		}
+
+		minCount := -1
+		for _, candidate := range candidates {
+			if minCount < 0 || counts[candidate.ID] < minCount {
+				minCount = counts[candidate.ID]
+			}
+		}
+
+		for _, candidate := range candidates {
+			if counts[candidate.ID] > minCount {
+				excluded[candidate.ID] = true
+			}
+		}
+	}
+
+	return slices.DeleteFunc(slices.Clone(candidates), func(candidate *Submission) bool {
+		return excluded[candidate.ID]
+	})
+}
+
+func (r *RepositoryInMemory) SubmissionCreate(ctx context.Context, submission *Submission) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := r.checkSubmissionConstraints(submission); err != nil {
+		return err
+	}
+
 	submission.ID = r.submissionCurrentID
 	r.submissionCurrentID++
 
+	r.loadSubreddit(submission)
+
 	r.submissions = append(r.submissions, submission)
 
 	return nil
 }
 
-func (r *RepositoryInMemory) SubredditCreate(subreddit *Subreddit) error {
+func (r *RepositoryInMemory) SubmissionUpdate(ctx context.Context, submission *Submission) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := r.checkSubmissionConstraints(submission); err != nil {
+		return err
+	}
+
+	for index, existing := range r.submissions {
+		if existing.ID == submission.ID {
+			submission.Rating = existing.Rating
+			submission.Tags = existing.Tags
+			r.loadSubreddit(submission)
+			r.submissions[index] = submission
+			return nil
+		}
+	}
+
+	return ErrSubmissionNotFound
+}
+
+// checkSubmissionConstraints ensures that a Submission's post ID is not
+// registered by another Submission, and that its Subreddit exists.
+func (r *RepositoryInMemory) checkSubmissionConstraints(submission *Submission) error {
+	for _, existing := range r.submissions {
+		if existing.ID != submission.ID && existing.PostID == submission.PostID {
+			return ErrSubmissionPostIDAlreadyRegistered
+		}
+	}
+
+	if submission.Subreddit == nil {
+		return ErrSubredditNotFound
+	}
+
+	if _, err := r.subredditByID(submission.Subreddit.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *RepositoryInMemory) SubmissionRate(ctx context.Context, id int, rating int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	submission, err := r.SubmissionGetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	submission.Rating = rating
+
+	return nil
+}
+
+func (r *RepositoryInMemory) SubmissionAddTags(ctx context.Context, id int, tags []string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	submission, err := r.SubmissionGetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	submission.Tags = NormalizeTags(append(slices.Clone(submission.Tags), tags...))
+
+	return nil
+}
+
+func (r *RepositoryInMemory) SubmissionRemoveTags(ctx context.Context, id int, tags []string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	submission, err := r.SubmissionGetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	submission.Tags = slices.DeleteFunc(slices.Clone(submission.Tags), func(tag string) bool {
+		return slices.Contains(tags, tag)
+	})
+
+	return nil
+}
+
+func (r *RepositoryInMemory) SubmissionDelete(ctx context.Context, id int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for index, submission := range r.submissions {
+		if submission.ID == id {
+			r.submissions = append(r.submissions[:index], r.submissions[index+1:]...)
+			r.deleteSelections([]int{id})
+			return nil
+		}
+	}
+
+	return ErrSubmissionNotFound
+}
+
+func (r *RepositoryInMemory) SubmissionDeleteMany(ctx context.Context, ids []int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		if _, err := r.SubmissionGetByID(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	r.submissions = slices.DeleteFunc(r.submissions, func(submission *Submission) bool {
+		return slices.Contains(ids, submission.ID)
+	})
+	r.deleteSelections(ids)
+
+	return nil
+}
+
+// deleteSelections deletes the selections of the Submissions with the given
+// IDs, as their History entries are deleted with them.
+func (r *RepositoryInMemory) deleteSelections(submissionIDs []int) {
+	r.selections = slices.DeleteFunc(r.selections, func(selection selection) bool {
+		return slices.Contains(submissionIDs, selection.submissionID)
+	})
+}
+
+func (r *RepositoryInMemory) SubredditCreate(ctx context.Context, subreddit *Subreddit) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	for _, existing := range r.subreddits {
+		if existing.Name == subreddit.Name {
+			return ErrSubredditNameAlreadyRegistered
+		}
+	}
+
 	subreddit.ID = r.subredditCurrentID
 	r.subredditCurrentID++
 
@@ -119,15 +738,54 @@ func (r *RepositoryInMemory) SubredditCreate(subreddit *Subreddit) error {
 	return nil
 }
 
-func (r *RepositoryInMemory) SubredditGetAll() ([]*Subreddit, error) {
-	return r.subreddits, nil
+func (r *RepositoryInMemory) SubredditGetAll(ctx context.Context) ([]*Subreddit, error) {
+	if err := ctx.Err(); err != nil {
+		return []*Subreddit{}, err
+	}
+
+	subreddits := slices.Clone(r.subreddits)
+	slices.SortStableFunc(subreddits, func(a, b *Subreddit) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+
+	return subreddits, nil
+}
+
+func (r *RepositoryInMemory) SubredditGetStats(ctx context.Context) ([]SubredditStats, error) {
+	if err := ctx.Err(); err != nil {
+		return []SubredditStats{}, err
+	}
+
+	counts := map[int]int{}
+
+	for _, submission := range r.submissions {
+		counts[submission.Subreddit.ID]++
+	}
+
+	subreddits, err := r.SubredditGetAll(ctx)
+	if err != nil {
+		return []SubredditStats{}, err
+	}
+
+	subredditStats := make([]SubredditStats, len(subreddits))
+
+	for index, subreddit := range subreddits {
+		subredditStats[index] = SubredditStats{Name: subreddit.Name, Submissions: counts[subreddit.ID]}
+	}
+
+	return subredditStats, nil
 }
 
-func (r *RepositoryInMemory) SubredditGetStats() ([]SubredditStats, error) {
-	return []SubredditStats{}, errors.New("not implemented")
+func (r *RepositoryInMemory) SubredditGetByID(ctx context.Context, id int) (*Subreddit, error) {
+	if err := ctx.Err(); err != nil {
+		return &Subreddit{}, err
+	}
+
+	return r.subredditByID(id)
 }
 
-func (r *RepositoryInMemory) SubredditGetByID(id int) (*Subreddit, error) {
+// subredditByID returns the Subreddit for a given ID.
+func (r *RepositoryInMemory) subredditByID(id int) (*Subreddit, error) {
 	for _, subreddit := range r.subreddits {
 		if subreddit.ID == id {
 			return subreddit, nil
@@ -137,7 +795,11 @@ func (r *RepositoryInMemory) SubredditGetByID(id int) (*Subreddit, error) {
 	return &Subreddit{}, ErrSubredditNotFound
 }
 
-func (r *RepositoryInMemory) SubredditGetByName(name string) (*Subreddit, error) {
+func (r *RepositoryInMemory) SubredditGetByName(ctx context.Context, name string) (*Subreddit, error) {
+	if err := ctx.Err(); err != nil {
+		return &Subreddit{}, err
+	}
+
 	for _, subreddit := range r.subreddits {
 		if subreddit.Name == name {
 			return subreddit, nil
@@ -147,7 +809,11 @@ func (r *RepositoryInMemory) SubredditGetByName(name string) (*Subreddit, error)
 	return &Subreddit{}, ErrSubredditNotFound
 }
 
-func (r *RepositoryInMemory) SubredditIsNameRegistered(name string) (bool, error) {
+func (r *RepositoryInMemory) SubredditIsNameRegistered(ctx context.Context, name string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
 	for _, subreddit := range r.subreddits {
 		if subreddit.Name == name {
 			return true, nil
@@ -156,3 +822,25 @@ func (r *RepositoryInMemory) SubredditIsNameRegistered(name string) (bool, error
 
 	return false, nil
 }
+
+func (r *RepositoryInMemory) TagGetStats(ctx context.Context) ([]TagStats, error) {
+	if err := ctx.Err(); err != nil {
+		return []TagStats{}, err
+	}
+
+	counts := map[string]int{}
+
+	for _, submission := range r.submissions {
+		for _, tag := range submission.Tags {
+			counts[tag]++
+		}
+	}
+
+	tagStats := []TagStats{}
+
+	for _, name := range slices.Sorted(maps.Keys(counts)) {
+		tagStats = append(tagStats, TagStats{Name: name, Submissions: counts[name]})
+	}
+
+	return tagStats, nil
+}
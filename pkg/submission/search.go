@@ -0,0 +1,194 @@
+package submission
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const (
+	// HighlightStart marks the beginning of a matching term in a search
+	// result Snippet.
+	HighlightStart = "\x02"
+
+	// HighlightEnd marks the end of a matching term in a search result
+	// Snippet.
+	HighlightEnd = "\x03"
+)
+
+// SearchTerm represents a word or phrase to look for when searching
+// Submissions.
+type SearchTerm struct {
+	// Text is a single word, or a phrase whose words must appear in this
+	// order.
+	Text string
+
+	// Prefix is set when the last word of Text may be followed by more
+	// characters.
+	Prefix bool
+}
+
+// SearchResult represents a Submission matching a search.
+type SearchResult struct {
+	Submission *Submission
+
+	// Snippet is an excerpt of the matching metadata, where matching terms
+	// are enclosed between HighlightStart and HighlightEnd.
+	Snippet string
+}
+
+// ParseSearchText splits a search string into SearchTerms.
+//
+// Double-quoted text is parsed as a phrase, and a trailing asterisk marks a
+// prefix, e.g. `"mountain lake" sun*`.
+func ParseSearchText(text string) []SearchTerm {
+	terms := []SearchTerm{}
+	runes := []rune(text)
+
+	for index := 0; index < len(runes); {
+		if unicode.IsSpace(runes[index]) {
+			index++
+			continue
+		}
+
+		var termRunes []rune
+
+		if runes[index] == '"' {
+			end := slices.Index(runes[index+1:], '"')
+			if end < 0 {
+				end = len(runes) - index - 1
+			}
+
+			termRunes = runes[index+1 : index+1+end]
+			index += end + 2
+		} else {
+			end := slices.IndexFunc(runes[index:], func(r rune) bool {
+				return unicode.IsSpace(r) || r == '"'
+			})
+			if end < 0 {
+				end = len(runes) - index
+			}
+
+			termRunes = runes[index : index+end]
+			index += end
+		}
+
+		term := SearchTerm{Text: strings.TrimSpace(string(termRunes))}
+
+		if index < len(runes) && runes[index] == '*' {
+			term.Prefix = true
+			index++
+		}
+
+		if strings.HasSuffix(term.Text, "*") {
+			term.Prefix = true
+			term.Text = strings.TrimRight(term.Text, "*")
+		}
+
+		if len(searchWords(term.Text)) == 0 {
+			continue
+		}
+
+		terms = append(terms, term)
+	}
+
+	return terms
+}
+
+// searchWords splits text into lowercase words, ignoring punctuation.
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), isSearchSeparator)
+}
+
+func isSearchSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// searchField holds the words of a text field, and their position in the
+// original text, to evaluate SearchTerms without a full-text search engine.
+//
+// Unlike a full-text search engine, words are not stemmed.
+type searchField struct {
+	text  string
+	words []string
+	spans [][2]int
+}
+
+func newSearchField(text string) *searchField {
+	field := &searchField{text: text}
+	start := -1
+
+	for offset, r := range text + " " {
+		if isSearchSeparator(r) {
+			if start >= 0 {
+				field.words = append(field.words, strings.ToLower(text[start:offset]))
+				field.spans = append(field.spans, [2]int{start, offset})
+				start = -1
+			}
+
+			continue
+		}
+
+		if start < 0 {
+			start = offset
+		}
+	}
+
+	return field
+}
+
+// matches returns the indexes of the words of this field matching a
+// SearchTerm.
+func (f *searchField) matches(term SearchTerm) []int {
+	termWords := searchWords(term.Text)
+	indexes := []int{}
+
+	for start := 0; start+len(termWords) <= len(f.words); start++ {
+		matches := true
+
+		for offset, termWord := range termWords {
+			word := f.words[start+offset]
+			last := offset == len(termWords)-1
+
+			if word == termWord || (last && term.Prefix && strings.HasPrefix(word, termWord)) {
+				continue
+			}
+
+			matches = false
+			break
+		}
+
+		if matches {
+			for offset := range termWords {
+				indexes = append(indexes, start+offset)
+			}
+		}
+	}
+
+	return indexes
+}
+
+// highlight returns the text of this field, where the words at the given
+// indexes are enclosed between HighlightStart and HighlightEnd.
+func (f *searchField) highlight(indexes []int) string {
+	var builder strings.Builder
+
+	previousEnd := 0
+
+	for index, span := range f.spans {
+		if !slices.Contains(indexes, index) {
+			continue
+		}
+
+		builder.WriteString(f.text[previousEnd:span[0]])
+		builder.WriteString(HighlightStart)
+		builder.WriteString(f.text[span[0]:span[1]])
+		builder.WriteString(HighlightEnd)
+
+		previousEnd = span[1]
+	}
+
+	builder.WriteString(f.text[previousEnd:])
+
+	return builder.String()
+}
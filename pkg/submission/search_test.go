@@ -0,0 +1,67 @@
+package submission
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseSearchText(t *testing.T) {
+	testCases := []struct {
+		tname string
+		text  string
+		want  []SearchTerm
+	}{
+		{
+			tname: "empty",
+			text:  "",
+			want:  []SearchTerm{},
+		},
+		{
+			tname: "words",
+			text:  "  mountain   lake ",
+			want: []SearchTerm{
+				{Text: "mountain"},
+				{Text: "lake"},
+			},
+		},
+		{
+			tname: "phrase",
+			text:  `"mountain lake" sunset`,
+			want: []SearchTerm{
+				{Text: "mountain lake"},
+				{Text: "sunset"},
+			},
+		},
+		{
+			tname: "unterminated phrase",
+			text:  `sunset "mountain lake`,
+			want: []SearchTerm{
+				{Text: "sunset"},
+				{Text: "mountain lake"},
+			},
+		},
+		{
+			tname: "prefixes",
+			text:  `sun* "mountain la"*`,
+			want: []SearchTerm{
+				{Text: "sun", Prefix: true},
+				{Text: "mountain la", Prefix: true},
+			},
+		},
+		{
+			tname: "punctuation only",
+			text:  `"" * - ...`,
+			want:  []SearchTerm{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			got := ParseSearchText(tc.text)
+
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("want terms %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
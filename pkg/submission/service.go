@@ -1,6 +1,7 @@
 package submission
 
 import (
+	"context"
 	"errors"
 	"strings"
 
@@ -19,55 +20,116 @@ func NewService(repository Repository) *Service {
 	}
 }
 
-// ByPostID returns the Submission matching a given ID.
-func (s *Service) ByID(id int) (*Submission, error) {
-	submission := &Submission{ID: id}
+// WithTx runs fn with a Service whose operations are persisted atomically:
+// they are all committed if fn returns nil, and all rolled back otherwise.
+func (s *Service) WithTx(ctx context.Context, fn func(tx *Service) error) error {
+	return s.r.WithTx(ctx, func(r Repository) error {
+		return fn(NewService(r))
+	})
+}
 
-	if err := submission.requirePositiveID(); err != nil {
-		return &Submission{}, err
+// Ban adds a Reddit post to the ban list.
+func (s *Service) Ban(ctx context.Context, ban *Ban) error {
+	ban.Normalize()
+
+	if err := ban.ValidateForAddition(ctx, s.r); err != nil {
+		return err
+	}
+
+	return s.r.BanCreate(ctx, ban)
+}
+
+// IsBanned returns whether a Reddit post was banned.
+func (s *Service) IsBanned(ctx context.Context, postID string) (bool, error) {
+	return s.r.BanIsPostIDRegistered(ctx, strings.TrimSpace(postID))
+}
+
+// IsImageBanned returns whether an image with a given SHA-256 checksum was
+// banned.
+func (s *Service) IsImageBanned(ctx context.Context, imageSHA256 string) (bool, error) {
+	imageSHA256 = strings.ToLower(strings.TrimSpace(imageSHA256))
+	if imageSHA256 == "" {
+		return false, nil
 	}
 
-	submission, err := s.r.SubmissionGetByID(id)
+	return s.r.BanIsImageSHA256Registered(ctx, imageSHA256)
+}
+
+// All returns all Submissions.
+func (s *Service) All(ctx context.Context) ([]*Submission, error) {
+	submissions, err := s.r.SubmissionGetAll(ctx)
 	if err != nil {
-		return &Submission{}, err
+		return []*Submission{}, err
 	}
 
-	subreddit, err := s.subredditByID(submission.Subreddit.ID)
+	return submissions, nil
+}
+
+// ByFilter returns the Submissions matching the provided Filter, sorted and
+// paginated according to the provided ListOptions.
+func (s *Service) ByFilter(ctx context.Context, filter *Filter, options *ListOptions) ([]*Submission, error) {
+	if err := filter.Validate(); err != nil {
+		return []*Submission{}, err
+	}
+
+	if err := options.Validate(); err != nil {
+		return []*Submission{}, err
+	}
+
+	submissions, err := s.r.SubmissionGetByFilter(ctx, filter, options)
 	if err != nil {
+		return []*Submission{}, err
+	}
+
+	return submissions, nil
+}
+
+// ByID returns the Submission matching a given ID.
+func (s *Service) ByID(ctx context.Context, id int) (*Submission, error) {
+	submission := &Submission{ID: id}
+
+	if err := submission.requirePositiveID(); err != nil {
 		return &Submission{}, err
 	}
 
-	submission.Subreddit = subreddit
+	submission, err := s.r.SubmissionGetByID(ctx, id)
+	if err != nil {
+		return &Submission{}, err
+	}
 
 	return submission, nil
 }
 
-// ByMinResolution returns all Submissions whose size is greater or equal to
-// the provided minimum resolution.
-func (s *Service) ByMinResolution(minResolution *monitor.Resolution) ([]*Submission, error) {
+// ByMinResolution returns the Submissions whose size is greater or equal to
+// the provided minimum resolution, and matching the provided Filter, sorted
+// and paginated according to the provided ListOptions.
+//
+// DefaultNSFWPolicy applies if the Filter has no NSFWPolicy.
+func (s *Service) ByMinResolution(ctx context.Context, minResolution *monitor.Resolution, filter *Filter, options *ListOptions) ([]*Submission, error) {
 	if err := minResolution.Validate(); err != nil {
 		return []*Submission{}, err
 	}
 
-	submissions, err := s.r.SubmissionGetByMinResolution(minResolution)
-	if err != nil {
+	filter = selectionFilter(filter)
+
+	if err := filter.Validate(); err != nil {
 		return []*Submission{}, err
 	}
 
-	for _, submission := range submissions {
-		subreddit, err := s.subredditByID(submission.Subreddit.ID)
-		if err != nil {
-			return []*Submission{}, err
-		}
+	if err := options.Validate(); err != nil {
+		return []*Submission{}, err
+	}
 
-		submission.Subreddit = subreddit
+	submissions, err := s.r.SubmissionGetByMinResolution(ctx, minResolution, filter, options)
+	if err != nil {
+		return []*Submission{}, err
 	}
 
 	return submissions, nil
 }
 
 // ByPostID returns the Submission matching a given post ID.
-func (s *Service) ByPostID(postID string) (*Submission, error) {
+func (s *Service) ByPostID(ctx context.Context, postID string) (*Submission, error) {
 	submission := &Submission{PostID: postID}
 	submission.Normalize()
 
@@ -75,115 +137,248 @@ func (s *Service) ByPostID(postID string) (*Submission, error) {
 		return &Submission{}, err
 	}
 
-	submission, err := s.r.SubmissionGetByPostID(postID)
+	submission, err := s.r.SubmissionGetByPostID(ctx, postID)
 	if err != nil {
 		return &Submission{}, err
 	}
 
-	subreddit, err := s.subredditByID(submission.Subreddit.ID)
+	return submission, nil
+}
+
+// BySubredditName returns all Submissions for a given Subreddit name.
+func (s *Service) BySubredditName(ctx context.Context, name string) ([]*Submission, error) {
+	subreddit, err := s.SubredditByName(ctx, name)
 	if err != nil {
-		return &Submission{}, err
+		return []*Submission{}, err
 	}
 
-	submission.Subreddit = subreddit
+	submissions, err := s.r.SubmissionGetBySubredditID(ctx, subreddit.ID)
+	if err != nil {
+		return []*Submission{}, err
+	}
 
-	return submission, nil
+	for _, submission := range submissions {
+		submission.Subreddit = subreddit
+	}
+
+	return submissions, nil
 }
 
 // Creates creates a new Submission.
-func (s *Service) Create(submission *Submission) error {
+func (s *Service) Create(ctx context.Context, submission *Submission) error {
 	submission.Normalize()
 
-	if err := submission.ValidateForAddition(s.r); err != nil {
+	if err := submission.ValidateForAddition(ctx, s.r); err != nil {
 		return err
 	}
 
-	return s.r.SubmissionCreate(submission)
+	return s.r.SubmissionCreate(ctx, submission)
 }
 
-// Search returns all Submissions whose title match the search string.
-func (s *Service) Search(text string) ([]*Submission, error) {
-	text = strings.TrimSpace(text)
-	if text == "" {
-		return []*Submission{}, ErrSubmissionSearchTextEmpty
+// Update updates an existing Submission.
+func (s *Service) Update(ctx context.Context, submission *Submission) error {
+	submission.Normalize()
+
+	if err := submission.ValidateForUpdate(); err != nil {
+		return err
 	}
 
-	submissions, err := s.r.SubmissionSearch(text)
+	return s.r.SubmissionUpdate(ctx, submission)
+}
+
+// Rate sets the rating for the Submission with a given ID.
+func (s *Service) Rate(ctx context.Context, id int, rating int) error {
+	submission := &Submission{ID: id}
+
+	if err := submission.requirePositiveID(); err != nil {
+		return err
+	}
+
+	if err := ValidateRating(rating); err != nil {
+		return err
+	}
+
+	return s.r.SubmissionRate(ctx, id, rating)
+}
+
+// AddTags adds tags to the Submission with a given ID.
+func (s *Service) AddTags(ctx context.Context, id int, tags []string) error {
+	submission := &Submission{ID: id}
+
+	if err := submission.requirePositiveID(); err != nil {
+		return err
+	}
+
+	tags = NormalizeTags(tags)
+
+	if err := ValidateTags(tags); err != nil {
+		return err
+	}
+
+	return s.r.SubmissionAddTags(ctx, id, tags)
+}
+
+// RemoveTags removes tags from the Submission with a given ID.
+func (s *Service) RemoveTags(ctx context.Context, id int, tags []string) error {
+	submission := &Submission{ID: id}
+
+	if err := submission.requirePositiveID(); err != nil {
+		return err
+	}
+
+	tags = NormalizeTags(tags)
+
+	if err := ValidateTags(tags); err != nil {
+		return err
+	}
+
+	return s.r.SubmissionRemoveTags(ctx, id, tags)
+}
+
+// Delete deletes the Submission for a given ID.
+func (s *Service) Delete(ctx context.Context, id int) error {
+	submission := &Submission{ID: id}
+
+	if err := submission.requirePositiveID(); err != nil {
+		return err
+	}
+
+	return s.r.SubmissionDelete(ctx, id)
+}
+
+// DeleteMany deletes the Submissions for the given IDs, in a single operation.
+func (s *Service) DeleteMany(ctx context.Context, ids []int) error {
+	for _, id := range ids {
+		submission := &Submission{ID: id}
+
+		if err := submission.requirePositiveID(); err != nil {
+			return err
+		}
+	}
+
+	return s.r.SubmissionDeleteMany(ctx, ids)
+}
+
+// Search returns the Submissions matching the search string and the provided
+// Filter, sorted and paginated according to the provided ListOptions.
+//
+// The search string is parsed with ParseQuery. With the default SortKey,
+// results are ranked by their SearchTerms; when the query only has field
+// conditions, results are returned in the same order as ByFilter.
+//
+// DefaultNSFWPolicy applies if the Filter has no NSFWPolicy.
+func (s *Service) Search(ctx context.Context, text string, filter *Filter, options *ListOptions) ([]*SearchResult, error) {
+	query, err := ParseQuery(text)
 	if err != nil {
-		return []*Submission{}, err
+		return []*SearchResult{}, err
 	}
 
-	for _, submission := range submissions {
-		subreddit, err := s.subredditByID(submission.Subreddit.ID)
+	if query.IsEmpty() {
+		return []*SearchResult{}, ErrSubmissionSearchTextEmpty
+	}
+
+	filter = selectionFilter(filter)
+
+	if err := filter.Validate(); err != nil {
+		return []*SearchResult{}, err
+	}
+
+	if err := options.Validate(); err != nil {
+		return []*SearchResult{}, err
+	}
+
+	terms, remaining := query.splitSearchTerms()
+
+	searchFilter := *filter
+	searchFilter.Query = &Query{Conditions: remaining.Conditions}
+
+	if !filter.Query.IsEmpty() {
+		searchFilter.Query.Conditions = append(searchFilter.Query.Conditions, filter.Query.Conditions...)
+	}
+
+	var results []*SearchResult
+
+	if len(terms) == 0 {
+		submissions, err := s.r.SubmissionGetByFilter(ctx, &searchFilter, options)
 		if err != nil {
-			return []*Submission{}, err
+			return []*SearchResult{}, err
 		}
 
-		submission.Subreddit = subreddit
+		results = make([]*SearchResult, len(submissions))
+
+		for index, submission := range submissions {
+			results[index] = &SearchResult{
+				Submission: submission,
+				Snippet:    submission.Title,
+			}
+		}
+	} else {
+		results, err = s.r.SubmissionSearch(ctx, terms, &searchFilter, options)
+		if err != nil {
+			return []*SearchResult{}, err
+		}
 	}
 
-	return submissions, nil
+	return results, nil
 }
 
 // Random returns a randomly selected Submission with a size greater or equal
-// to the provided minimum resolution.
-func (s *Service) Random(minResolution *monitor.Resolution) (*Submission, error) {
+// to the provided minimum resolution, and matching the provided Filter.
+// The Submission is chosen by the provided SelectionStrategy, among the
+// Submissions that the provided RepeatPolicy allows to select.
+//
+// DefaultNSFWPolicy applies if the Filter has no NSFWPolicy.
+func (s *Service) Random(ctx context.Context, minResolution *monitor.Resolution, filter *Filter, strategy SelectionStrategy, repeat RepeatPolicy) (*Submission, error) {
 	if err := minResolution.Validate(); err != nil {
 		return &Submission{}, err
 	}
 
-	submission, err := s.r.SubmissionGetRandom(minResolution)
-	if err != nil {
+	if err := repeat.Validate(); err != nil {
 		return &Submission{}, err
 	}
 
-	subreddit, err := s.subredditByID(submission.Subreddit.ID)
-	if err != nil {
+	filter = selectionFilter(filter)
+
+	if err := filter.Validate(); err != nil {
 		return &Submission{}, err
 	}
 
-	submission.Subreddit = subreddit
-
-	return submission, err
+	return s.random(ctx, minResolution, filter, strategy, repeat)
 }
 
 // Stats returns statistics about how many Submissions were gathered per Subreddit.
-func (s *Service) Stats() ([]SubredditStats, error) {
-	return s.r.SubredditGetStats()
+func (s *Service) Stats(ctx context.Context) ([]SubredditStats, error) {
+	return s.r.SubredditGetStats(ctx)
 }
 
-func (s *Service) subredditByID(id int) (*Subreddit, error) {
-	sr := &Subreddit{ID: id}
-
-	if err := sr.requirePositiveID(); err != nil {
-		return &Subreddit{}, err
-	}
-
-	return s.r.SubredditGetByID(id)
+// TagStats returns statistics about how many Submissions are tagged with
+// each tag.
+func (s *Service) TagStats(ctx context.Context) ([]TagStats, error) {
+	return s.r.TagGetStats(ctx)
 }
 
 // SubredditCreate creates a new Subreddit.
-func (s *Service) SubredditCreate(sr *Subreddit) error {
+func (s *Service) SubredditCreate(ctx context.Context, sr *Subreddit) error {
 	sr.Normalize()
 
-	if err := sr.ValidateForAddition(s.r); err != nil {
+	if err := sr.ValidateForAddition(ctx, s.r); err != nil {
 		return err
 	}
 
-	return s.r.SubredditCreate(sr)
+	return s.r.SubredditCreate(ctx, sr)
 }
 
 // SubredditGetOrCreateByName returns an existing Subreddit or creates it otherwise.
-func (s *Service) SubredditGetOrCreateByName(name string) (*Subreddit, error) {
-	subreddit, err := s.SubredditByName(name)
+func (s *Service) SubredditGetOrCreateByName(ctx context.Context, name string) (*Subreddit, error) {
+	subreddit, err := s.SubredditByName(ctx, name)
 
 	if errors.Is(err, ErrSubredditNotFound) {
 		subreddit = &Subreddit{Name: name}
-		if err = s.SubredditCreate(subreddit); err != nil {
+		if err = s.SubredditCreate(ctx, subreddit); err != nil {
 			return &Subreddit{}, err
 		}
 
-		subreddit, err = s.SubredditByName(name)
+		subreddit, err = s.SubredditByName(ctx, name)
 		if err != nil {
 			return &Subreddit{}, err
 		}
@@ -194,8 +389,28 @@ func (s *Service) SubredditGetOrCreateByName(name string) (*Subreddit, error) {
 	return subreddit, nil
 }
 
+// random returns a Submission chosen by a SelectionStrategy.
+func (s *Service) random(ctx context.Context, minResolution *monitor.Resolution, filter *Filter, strategy SelectionStrategy, repeat RepeatPolicy) (*Submission, error) {
+	if _, ok := strategy.(*uniformStrategy); ok {
+		// the Repository can select a random Submission without loading all
+		// candidates
+		return s.r.SubmissionGetRandom(ctx, minResolution, filter, repeat)
+	}
+
+	candidates, err := s.r.SubmissionGetRandomCandidates(ctx, minResolution, filter, repeat)
+	if err != nil {
+		return &Submission{}, err
+	}
+
+	if len(candidates) == 0 {
+		return &Submission{}, ErrSubmissionNotFound
+	}
+
+	return strategy.Select(candidates), nil
+}
+
 // SubredditByName returns the SUbreddit for a given name.
-func (s *Service) SubredditByName(name string) (*Subreddit, error) {
+func (s *Service) SubredditByName(ctx context.Context, name string) (*Subreddit, error) {
 	sr := &Subreddit{Name: name}
 	sr.Normalize()
 
@@ -203,5 +418,5 @@ func (s *Service) SubredditByName(name string) (*Subreddit, error) {
 		return &Subreddit{}, err
 	}
 
-	return s.r.SubredditGetByName(sr.Name)
+	return s.r.SubredditGetByName(ctx, sr.Name)
 }
@@ -1,7 +1,10 @@
 package submission
 
 import (
+	"cmp"
+	"context"
 	"errors"
+	"slices"
 	"testing"
 	"time"
 
@@ -76,7 +79,7 @@ func TestServiceByID(t *testing.T) {
 			repository := NewRepositoryInMemory(tc.repositorySubmissions, tc.repositorySubreddits)
 			service := NewService(repository)
 
-			submission, err := service.ByID(tc.id)
+			submission, err := service.ByID(t.Context(), tc.id)
 
 			if tc.wantErr != nil {
 				if err == nil {
@@ -99,6 +102,25 @@ func TestServiceByID(t *testing.T) {
 	}
 }
 
+func TestServiceCanceledContext(t *testing.T) {
+	repository := NewRepositoryInMemory(
+		[]*Submission{{ID: 1, PostID: "first", Subreddit: &Subreddit{ID: 1}}},
+		[]*Subreddit{{ID: 1, Name: "EarthPorn"}},
+	)
+	service := NewService(repository)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	if _, err := service.ByID(ctx, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("want error %q, got %q", context.Canceled, err)
+	}
+
+	if err := service.Rate(ctx, 1, RatingFavorite); !errors.Is(err, context.Canceled) {
+		t.Errorf("want error %q, got %q", context.Canceled, err)
+	}
+}
+
 func TestServiceByMinResolution(t *testing.T) {
 	repositorySubreddits := []*Subreddit{
 		{
@@ -111,6 +133,7 @@ func TestServiceByMinResolution(t *testing.T) {
 		tname                 string
 		repositorySubmissions []*Submission
 		minResolution         *monitor.Resolution
+		options               ListOptions
 		want                  []*Submission
 		wantErr               error
 	}{
@@ -161,6 +184,33 @@ func TestServiceByMinResolution(t *testing.T) {
 				{Title: "Laguna Sunrise [1920x1200]", ImageHeightPx: 1200, ImageWidthPx: 1920},
 			},
 		},
+		{
+			tname: "sorted by resolution, second page",
+			repositorySubmissions: []*Submission{
+				{Title: "Moroccan Sunset [2560x1440]", ImageHeightPx: 1440, ImageWidthPx: 2560, Subreddit: &Subreddit{ID: 1}},
+				{Title: "Laguna Sunrise [1920x1200]", ImageHeightPx: 1200, ImageWidthPx: 1920, Subreddit: &Subreddit{ID: 1}},
+				{Title: "Alpine Meadow [3840x2160]", ImageHeightPx: 2160, ImageWidthPx: 3840, Subreddit: &Subreddit{ID: 1}},
+			},
+			minResolution: &monitor.Resolution{HeightPx: 1200, WidthPx: 1920},
+			options:       ListOptions{Sort: SortResolution, Page: Page{Limit: 2, Offset: 2}},
+			want: []*Submission{
+				{Title: "Laguna Sunrise [1920x1200]", ImageHeightPx: 1200, ImageWidthPx: 1920},
+			},
+		},
+		{
+			tname: "sorted by title, ascending",
+			repositorySubmissions: []*Submission{
+				{Title: "Moroccan Sunset [2560x1440]", ImageHeightPx: 1440, ImageWidthPx: 2560, Subreddit: &Subreddit{ID: 1}},
+				{Title: "laguna Sunrise [1920x1200]", ImageHeightPx: 1200, ImageWidthPx: 1920, Subreddit: &Subreddit{ID: 1}},
+				{Title: "Alpine Meadow [3840x2160]", ImageHeightPx: 2160, ImageWidthPx: 3840, Subreddit: &Subreddit{ID: 1}},
+			},
+			minResolution: &monitor.Resolution{HeightPx: 1200, WidthPx: 1920},
+			options:       ListOptions{Sort: SortTitle, Direction: SortAscending, Page: Page{Limit: 2}},
+			want: []*Submission{
+				{Title: "Alpine Meadow [3840x2160]", ImageHeightPx: 2160, ImageWidthPx: 3840},
+				{Title: "laguna Sunrise [1920x1200]", ImageHeightPx: 1200, ImageWidthPx: 1920},
+			},
+		},
 		{
 			tname: "no result for this resolution",
 			repositorySubmissions: []*Submission{
@@ -190,6 +240,18 @@ func TestServiceByMinResolution(t *testing.T) {
 			minResolution: &monitor.Resolution{HeightPx: -1200, WidthPx: -1920},
 			wantErr:       monitor.ErrResolutionInvalid,
 		},
+		{
+			tname:         "unknown sort key",
+			minResolution: &monitor.Resolution{HeightPx: 1200, WidthPx: 1920},
+			options:       ListOptions{Sort: "colour"},
+			wantErr:       ErrListSortInvalid,
+		},
+		{
+			tname:         "negative limit",
+			minResolution: &monitor.Resolution{HeightPx: 1200, WidthPx: 1920},
+			options:       ListOptions{Page: Page{Limit: -1}},
+			wantErr:       ErrListLimitInvalid,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -197,7 +259,7 @@ func TestServiceByMinResolution(t *testing.T) {
 			repository := NewRepositoryInMemory(tc.repositorySubmissions, repositorySubreddits)
 			service := NewService(repository)
 
-			submissions, err := service.ByMinResolution(tc.minResolution)
+			submissions, err := service.ByMinResolution(t.Context(), tc.minResolution, &Filter{}, &tc.options)
 
 			if tc.wantErr != nil {
 				if err == nil {
@@ -216,6 +278,13 @@ func TestServiceByMinResolution(t *testing.T) {
 
 			if len(submissions) != len(tc.want) {
 				t.Errorf("want %d submissions, got %d", len(tc.want), len(submissions))
+				return
+			}
+
+			for index, want := range tc.want {
+				if submissions[index].Title != want.Title {
+					t.Errorf("want submission %d titled %q, got %q", index, want.Title, submissions[index].Title)
+				}
 			}
 		})
 	}
@@ -281,7 +350,7 @@ func TestServiceByPostID(t *testing.T) {
 			repository := NewRepositoryInMemory(tc.repositorySubmissions, tc.repositorySubreddits)
 			service := NewService(repository)
 
-			submission, err := service.ByPostID(tc.postID)
+			submission, err := service.ByPostID(t.Context(), tc.postID)
 
 			if tc.wantErr != nil {
 				if err == nil {
@@ -418,7 +487,7 @@ func TestServiceCreate(t *testing.T) {
 			currentID := repository.submissionCurrentID
 			service := NewService(repository)
 
-			err := service.Create(tc.submission)
+			err := service.Create(t.Context(), tc.submission)
 
 			if tc.wantErr != nil {
 				if err == nil {
@@ -435,7 +504,7 @@ func TestServiceCreate(t *testing.T) {
 				return
 			}
 
-			submission, err := service.ByID(currentID)
+			submission, err := service.ByID(t.Context(), currentID)
 
 			if err != nil {
 				t.Errorf("failed to retrieve submission: %q", err)
@@ -452,7 +521,15 @@ func TestServiceCreate(t *testing.T) {
 	}
 }
 
-func TestServiceRandom(t *testing.T) {
+func TestServiceWithTx(t *testing.T) {
+	repositorySubmissions := []*Submission{
+		{
+			Subreddit: &Subreddit{ID: 1},
+			ID:        1,
+			PostID:    "dupdup",
+			Title:     "Existing Submission [800x600]",
+		},
+	}
 	repositorySubreddits := []*Subreddit{
 		{
 			ID:   1,
@@ -461,71 +538,44 @@ func TestServiceRandom(t *testing.T) {
 	}
 
 	testCases := []struct {
-		tname                 string
-		repositorySubmissions []*Submission
-		minResolution         *monitor.Resolution
-		want                  *Submission
-		wantErr               error
+		tname   string
+		postID  string
+		wantErr error
 	}{
 		// nominal cases
 		{
-			tname: "random result",
-			repositorySubmissions: []*Submission{
-				{
-					Title:         "Sunday Afternoon In The Park [640x480]",
-					ImageHeightPx: 480,
-					ImageWidthPx:  640,
-					Subreddit:     &Subreddit{ID: 1},
-				},
-				{
-					Title:         "Laguna Sunrise [1920x1200]",
-					ImageHeightPx: 1200,
-					ImageWidthPx:  1920,
-					Subreddit:     &Subreddit{ID: 1},
-				},
-			},
-			minResolution: &monitor.Resolution{HeightPx: 1200, WidthPx: 1920},
-			want:          &Submission{Title: "Laguna Sunrise [1920x1200]", ImageHeightPx: 1200, ImageWidthPx: 1920},
-		},
-		{
-			tname:         "not found (empty repository)",
-			minResolution: &monitor.Resolution{HeightPx: 1200, WidthPx: 1920},
-			wantErr:       ErrSubmissionNotFound,
-		},
-		{
-			tname: "not found (no result)",
-			repositorySubmissions: []*Submission{
-				{Title: "Sunday Afternoon In The Park [640x480]", ImageHeightPx: 480, ImageWidthPx: 640},
-				{Title: "Laguna Sunrise [1920x1200]", ImageHeightPx: 1200, ImageWidthPx: 1920},
-			},
-			minResolution: &monitor.Resolution{HeightPx: 1440, WidthPx: 2560},
-			wantErr:       ErrSubmissionNotFound,
+			tname:  "commit",
+			postID: "newnew",
 		},
 
 		// error cases
 		{
-			tname:         "negative resolution height",
-			minResolution: &monitor.Resolution{HeightPx: -1200, WidthPx: 1920},
-			wantErr:       monitor.ErrResolutionInvalid,
-		},
-		{
-			tname:         "negative resolution width",
-			minResolution: &monitor.Resolution{HeightPx: 1200, WidthPx: -1920},
-			wantErr:       monitor.ErrResolutionInvalid,
-		},
-		{
-			tname:         "negative resolution height and width",
-			minResolution: &monitor.Resolution{HeightPx: -1200, WidthPx: -1920},
-			wantErr:       monitor.ErrResolutionInvalid,
+			tname:   "rollback on duplicate PostID",
+			postID:  "dupdup",
+			wantErr: ErrSubmissionPostIDAlreadyRegistered,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.tname, func(t *testing.T) {
-			repository := NewRepositoryInMemory(tc.repositorySubmissions, repositorySubreddits)
+			repository := NewRepositoryInMemory(slices.Clone(repositorySubmissions), slices.Clone(repositorySubreddits))
 			service := NewService(repository)
 
-			submission, err := service.Random(tc.minResolution)
+			err := service.WithTx(t.Context(), func(tx *Service) error {
+				sr, err := tx.SubredditGetOrCreateByName(t.Context(), "Transactional")
+				if err != nil {
+					return err
+				}
+
+				return tx.Create(t.Context(), &Submission{
+					Subreddit: sr,
+					PostID:    tc.postID,
+					Title:     "New Submission [800x600]",
+				})
+			})
+
+			_, srErr := service.SubredditByName(t.Context(), "Transactional")
+			_, submissionErr := service.ByPostID(t.Context(), "newnew")
 
 			if tc.wantErr != nil {
 				if err == nil {
@@ -534,6 +584,10 @@ func TestServiceRandom(t *testing.T) {
 					t.Errorf("want error %q, got %q", tc.wantErr, err)
 				}
 
+				if !errors.Is(srErr, ErrSubredditNotFound) {
+					t.Errorf("want subreddit creation to be rolled back, got %v", srErr)
+				}
+
 				return
 			}
 
@@ -542,91 +596,85 @@ func TestServiceRandom(t *testing.T) {
 				return
 			}
 
-			if submission.Title != tc.want.Title {
-				t.Errorf("want name %q, got %q", tc.want.Title, submission.Title)
+			if srErr != nil {
+				t.Errorf("want subreddit to be committed, got %q", srErr)
+			}
+			if submissionErr != nil {
+				t.Errorf("want submission to be committed, got %q", submissionErr)
 			}
 		})
 	}
 }
 
-func TestServiceSearch(t *testing.T) {
+func TestServiceUpdate(t *testing.T) {
+	repositorySubreddits := []*Subreddit{
+		{ID: 1, Name: "astrophotography"},
+	}
+
 	testCases := []struct {
 		tname                 string
-		repositorySubreddits  []*Subreddit
 		repositorySubmissions []*Submission
-		text                  string
-		want                  []*Submission
+		submission            *Submission
 		wantErr               error
 	}{
 		// nominal cases
 		{
-			tname: "single result",
-			repositorySubreddits: []*Subreddit{
-				{ID: 1, Name: "astrophotography"},
-			},
+			tname: "update resolution",
 			repositorySubmissions: []*Submission{
-				{ID: 1, PostID: "m31aga", Subreddit: &Subreddit{ID: 1}, Title: "Messier 31 - The Andromeda Galaxy"},
-				{ID: 2, PostID: "owlsrf", Subreddit: &Subreddit{ID: 1}, Title: "The Owl Nebula and Surfboard Galaxy"},
+				{ID: 1, PostID: "m31aga", Subreddit: &Subreddit{ID: 1}, Title: "Messier 31 - The Andromeda Galaxy", ImageHeightPx: 600, ImageWidthPx: 800},
 			},
-			text: "nebula",
-			want: []*Submission{
-				{
-					ID:        2,
-					PostID:    "owlsrf",
-					Title:     "The Owl Nebula and Surfboard Galaxy",
-					Subreddit: &Subreddit{ID: 1, Name: "astrophotography"},
-				},
+			submission: &Submission{
+				ID:            1,
+				PostID:        "m31aga",
+				Subreddit:     &Subreddit{ID: 1},
+				Title:         "Messier 31 - The Andromeda Galaxy",
+				ImageHeightPx: 1200,
+				ImageWidthPx:  1600,
 			},
 		},
+
+		// error cases
 		{
-			tname: "multiple results",
-			repositorySubreddits: []*Subreddit{
-				{ID: 1, Name: "astrophotography"},
-			},
-			repositorySubmissions: []*Submission{
-				{ID: 1, PostID: "m31aga", Subreddit: &Subreddit{ID: 1}, Title: "Messier 31 - The Andromeda Galaxy"},
-				{ID: 2, PostID: "owlsrf", Subreddit: &Subreddit{ID: 1}, Title: "The Owl Nebula and Surfboard Galaxy"},
-			},
-			text: "galaxy",
-			want: []*Submission{
-				{
-					ID:        1,
-					PostID:    "m31aga",
-					Title:     "Messier 31 - The Andromeda Galaxy",
-					Subreddit: &Subreddit{ID: 1, Name: "astrophotography"},
-				},
-				{
-					ID:        2,
-					PostID:    "owlsrf",
-					Title:     "The Owl Nebula and Surfboard Galaxy",
-					Subreddit: &Subreddit{ID: 1, Name: "astrophotography"},
-				},
+			tname: "not found",
+			submission: &Submission{
+				ID:        649,
+				PostID:    "unkwn",
+				Subreddit: &Subreddit{ID: 1},
+				Title:     "Unknown",
 			},
+			wantErr: ErrSubmissionNotFound,
 		},
-
-		// error cases
 		{
-			tname:   "empty text",
-			text:    "",
-			wantErr: ErrSubmissionSearchTextEmpty,
+			tname: "ID equals zero",
+			submission: &Submission{
+				PostID:    "zeroid",
+				Subreddit: &Subreddit{ID: 1},
+				Title:     "Zero",
+			},
+			wantErr: ErrSubmissionIDInvalid,
 		},
 		{
-			tname:   "whitespace (empty) text",
-			text:    "       ",
-			wantErr: ErrSubmissionSearchTextEmpty,
+			tname: "empty title",
+			submission: &Submission{
+				ID:        1,
+				PostID:    "notitl",
+				Subreddit: &Subreddit{ID: 1},
+				Title:     "   ",
+			},
+			wantErr: ErrSubmissionTitleEmpty,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.tname, func(t *testing.T) {
-			repository := NewRepositoryInMemory(tc.repositorySubmissions, tc.repositorySubreddits)
+			repository := NewRepositoryInMemory(tc.repositorySubmissions, repositorySubreddits)
 			service := NewService(repository)
 
-			submissions, err := service.Search(tc.text)
+			err := service.Update(t.Context(), tc.submission)
 
 			if tc.wantErr != nil {
 				if err == nil {
-					t.Error("expected an errot but got none")
+					t.Error("expected an error but got none")
 				} else if !errors.Is(err, tc.wantErr) {
 					t.Errorf("want error %q, got %q", tc.wantErr, err)
 				}
@@ -635,67 +683,62 @@ func TestServiceSearch(t *testing.T) {
 			}
 
 			if err != nil {
-				t.Errorf("expected no error, got %q", err)
+				t.Errorf("expected no error but got %q", err)
 				return
 			}
 
-			if len(submissions) != len(tc.want) {
-				t.Errorf("want %d submissions, got %d", len(tc.want), len(submissions))
+			submission, err := service.ByID(t.Context(), tc.submission.ID)
+			if err != nil {
+				t.Errorf("failed to retrieve submission: %q", err)
 				return
 			}
 
-			for index, want := range tc.want {
-				assertSubmissionEquals(t, want, submissions[index])
-				assertSubmissionSubredditEquals(t, want, submissions[index])
+			if submission.ImageHeightPx != tc.submission.ImageHeightPx {
+				t.Errorf("want height %d, got %d", tc.submission.ImageHeightPx, submission.ImageHeightPx)
+			}
+			if submission.ImageWidthPx != tc.submission.ImageWidthPx {
+				t.Errorf("want width %d, got %d", tc.submission.ImageWidthPx, submission.ImageWidthPx)
 			}
 		})
 	}
 }
 
-func TestServiceSubredditByID(t *testing.T) {
+func TestServiceDelete(t *testing.T) {
 	testCases := []struct {
-		tname                string
-		repositorySubreddits []*Subreddit
-		id                   int
-		want                 *Subreddit
-		wantErr              error
+		tname                 string
+		repositorySubmissions []*Submission
+		id                    int
+		wantErr               error
 	}{
 		// nominal cases
 		{
-			tname: "return by ID",
-			repositorySubreddits: []*Subreddit{
-				{ID: 1, Name: "astrophotography"},
-				{ID: 2, Name: "FromSpaceWithLove"},
-				{ID: 3, Name: "Museum"},
+			tname: "existing ID",
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "m31aga", Subreddit: &Subreddit{ID: 1}, Title: "Messier 31 - The Andromeda Galaxy"},
+				{ID: 2, PostID: "owlsrf", Subreddit: &Subreddit{ID: 1}, Title: "The Owl Nebula and Surfboard Galaxy"},
 			},
-			id:   2,
-			want: &Subreddit{ID: 2, Name: "FromSpaceWithLove"},
-		},
-		{
-			tname:   "not found",
-			id:      9362,
-			wantErr: ErrSubredditNotFound,
+			id: 2,
 		},
 
 		// error cases
 		{
-			tname:   "ID is negative",
-			id:      -548,
-			wantErr: ErrSubredditIDInvalid,
+			tname:   "unknown ID",
+			id:      649,
+			wantErr: ErrSubmissionNotFound,
 		},
 		{
 			tname:   "ID equals zero",
 			id:      0,
-			wantErr: ErrSubredditIDInvalid,
+			wantErr: ErrSubmissionIDInvalid,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.tname, func(t *testing.T) {
-			repository := NewRepositoryInMemory(nil, tc.repositorySubreddits)
+			repository := NewRepositoryInMemory(tc.repositorySubmissions, nil)
 			service := NewService(repository)
 
-			subreddit, err := service.subredditByID(tc.id)
+			err := service.Delete(t.Context(), tc.id)
 
 			if tc.wantErr != nil {
 				if err == nil {
@@ -712,60 +755,1202 @@ func TestServiceSubredditByID(t *testing.T) {
 				return
 			}
 
-			if subreddit.ID != tc.want.ID {
-				t.Errorf("want ID %d, got %d", tc.want.ID, subreddit.ID)
-			}
-			if subreddit.Name != tc.want.Name {
-				t.Errorf("want name %q, got %q", tc.want.Name, subreddit.Name)
+			_, err = repository.SubmissionGetByID(t.Context(), tc.id)
+			if !errors.Is(err, ErrSubmissionNotFound) {
+				t.Errorf("want error %q, got %q", ErrSubmissionNotFound, err)
 			}
 		})
 	}
 }
 
-func TestServiceSubredditByName(t *testing.T) {
+func TestServiceRate(t *testing.T) {
 	testCases := []struct {
-		tname                string
-		repositorySubreddits []*Subreddit
-		name                 string
-		want                 *Subreddit
-		wantErr              error
+		tname                 string
+		repositorySubmissions []*Submission
+		id                    int
+		rating                int
+		wantErr               error
 	}{
 		// nominal cases
 		{
-			tname: "return by ID",
-			repositorySubreddits: []*Subreddit{
-				{ID: 1, Name: "astrophotography"},
-				{ID: 2, Name: "FromSpaceWithLove"},
-				{ID: 3, Name: "Museum"},
+			tname: "favorite",
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "m31aga", Subreddit: &Subreddit{ID: 1}, Title: "Messier 31 - The Andromeda Galaxy"},
 			},
-			name: "FromSpaceWithLove",
-			want: &Subreddit{ID: 2, Name: "FromSpaceWithLove"},
+			id:     1,
+			rating: RatingFavorite,
 		},
 		{
-			tname:   "not found",
-			name:    "Unknown",
-			wantErr: ErrSubredditNotFound,
+			tname: "disliked",
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "m31aga", Subreddit: &Subreddit{ID: 1}, Title: "Messier 31 - The Andromeda Galaxy"},
+			},
+			id:     1,
+			rating: RatingDisliked,
+		},
+		{
+			tname: "clear rating",
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "m31aga", Subreddit: &Subreddit{ID: 1}, Title: "Messier 31 - The Andromeda Galaxy", Rating: 3},
+			},
+			id:     1,
+			rating: RatingNone,
 		},
 
 		// error cases
 		{
-			tname:   "empty name",
-			name:    "",
-			wantErr: ErrSubredditNameEmpty,
+			tname:   "unknown ID",
+			id:      649,
+			rating:  3,
+			wantErr: ErrSubmissionNotFound,
 		},
 		{
-			tname:   "empty name (whitespace)",
-			name:    "     ",
-			wantErr: ErrSubredditNameEmpty,
+			tname:   "ID equals zero",
+			id:      0,
+			rating:  3,
+			wantErr: ErrSubmissionIDInvalid,
 		},
-	}
-
-	for _, tc := range testCases {
-		t.Run(tc.tname, func(t *testing.T) {
-			repository := NewRepositoryInMemory(nil, tc.repositorySubreddits)
-			validator := NewService(repository)
-
-			subreddit, err := validator.SubredditByName(tc.name)
+		{
+			tname: "rating too low",
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "m31aga", Subreddit: &Subreddit{ID: 1}, Title: "Messier 31 - The Andromeda Galaxy"},
+			},
+			id:      1,
+			rating:  -2,
+			wantErr: ErrRatingInvalid,
+		},
+		{
+			tname: "rating too high",
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "m31aga", Subreddit: &Subreddit{ID: 1}, Title: "Messier 31 - The Andromeda Galaxy"},
+			},
+			id:      1,
+			rating:  6,
+			wantErr: ErrRatingInvalid,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			repository := NewRepositoryInMemory(tc.repositorySubmissions, nil)
+			service := NewService(repository)
+
+			err := service.Rate(t.Context(), tc.id, tc.rating)
+
+			if tc.wantErr != nil {
+				if err == nil {
+					t.Error("expected an error but got none")
+				} else if !errors.Is(err, tc.wantErr) {
+					t.Errorf("want error %q, got %q", tc.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error but got %q", err)
+				return
+			}
+
+			submission, err := repository.SubmissionGetByID(t.Context(), tc.id)
+			if err != nil {
+				t.Errorf("expected no error but got %q", err)
+				return
+			}
+
+			if submission.Rating != tc.rating {
+				t.Errorf("want rating %d, got %d", tc.rating, submission.Rating)
+			}
+		})
+	}
+}
+
+func TestServiceTags(t *testing.T) {
+	testCases := []struct {
+		tname                 string
+		repositorySubmissions []*Submission
+		id                    int
+		addTags               []string
+		removeTags            []string
+		wantTags              []string
+		wantStats             []TagStats
+		wantErr               error
+	}{
+		// nominal cases
+		{
+			tname: "add tags",
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "m31aga", Subreddit: &Subreddit{ID: 1}, Title: "Messier 31 - The Andromeda Galaxy", Tags: []string{"space"}},
+				{ID: 2, PostID: "owlsrf", Subreddit: &Subreddit{ID: 1}, Title: "The Owl Nebula and Surfboard Galaxy", Tags: []string{"space"}},
+			},
+			id:       1,
+			addTags:  []string{" Galaxy ", "space", "galaxy"},
+			wantTags: []string{"galaxy", "space"},
+			wantStats: []TagStats{
+				{Name: "galaxy", Submissions: 1},
+				{Name: "space", Submissions: 2},
+			},
+		},
+		{
+			tname: "remove tags",
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "m31aga", Subreddit: &Subreddit{ID: 1}, Title: "Messier 31 - The Andromeda Galaxy", Tags: []string{"galaxy", "space"}},
+			},
+			id:         1,
+			removeTags: []string{"GALAXY", "winter"},
+			wantTags:   []string{"space"},
+			wantStats: []TagStats{
+				{Name: "space", Submissions: 1},
+			},
+		},
+
+		// error cases
+		{
+			tname:   "unknown ID",
+			id:      649,
+			addTags: []string{"space"},
+			wantErr: ErrSubmissionNotFound,
+		},
+		{
+			tname:   "ID equals zero",
+			id:      0,
+			addTags: []string{"space"},
+			wantErr: ErrSubmissionIDInvalid,
+		},
+		{
+			tname: "empty tag",
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "m31aga", Subreddit: &Subreddit{ID: 1}, Title: "Messier 31 - The Andromeda Galaxy"},
+			},
+			id:      1,
+			addTags: []string{"   "},
+			wantErr: ErrTagNameEmpty,
+		},
+		{
+			tname: "tag with a comma",
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "m31aga", Subreddit: &Subreddit{ID: 1}, Title: "Messier 31 - The Andromeda Galaxy"},
+			},
+			id:      1,
+			addTags: []string{"galaxy,space"},
+			wantErr: ErrTagNameInvalid,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			repository := NewRepositoryInMemory(tc.repositorySubmissions, nil)
+			service := NewService(repository)
+
+			var err error
+
+			if len(tc.addTags) > 0 {
+				err = service.AddTags(t.Context(), tc.id, tc.addTags)
+			} else {
+				err = service.RemoveTags(t.Context(), tc.id, tc.removeTags)
+			}
+
+			if tc.wantErr != nil {
+				if err == nil {
+					t.Error("expected an error but got none")
+				} else if !errors.Is(err, tc.wantErr) {
+					t.Errorf("want error %q, got %q", tc.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error but got %q", err)
+				return
+			}
+
+			submission, err := repository.SubmissionGetByID(t.Context(), tc.id)
+			if err != nil {
+				t.Errorf("expected no error but got %q", err)
+				return
+			}
+
+			if !slices.Equal(submission.Tags, tc.wantTags) {
+				t.Errorf("want tags %q, got %q", tc.wantTags, submission.Tags)
+			}
+
+			stats, err := service.TagStats(t.Context())
+			if err != nil {
+				t.Errorf("expected no error but got %q", err)
+				return
+			}
+
+			if !slices.Equal(stats, tc.wantStats) {
+				t.Errorf("want stats %v, got %v", tc.wantStats, stats)
+			}
+		})
+	}
+}
+
+func TestServiceRandom(t *testing.T) {
+	repositorySubreddits := []*Subreddit{
+		{
+			ID:   1,
+			Name: "Dummy",
+		},
+	}
+
+	testCases := []struct {
+		tname                 string
+		repositorySubmissions []*Submission
+		minResolution         *monitor.Resolution
+		filter                Filter
+		strategy              string
+		want                  *Submission
+		wantErr               error
+	}{
+		// nominal cases
+		{
+			tname: "random result",
+			repositorySubmissions: []*Submission{
+				{
+					Title:         "Sunday Afternoon In The Park [640x480]",
+					ImageHeightPx: 480,
+					ImageWidthPx:  640,
+					Subreddit:     &Subreddit{ID: 1},
+				},
+				{
+					Title:         "Laguna Sunrise [1920x1200]",
+					ImageHeightPx: 1200,
+					ImageWidthPx:  1920,
+					Subreddit:     &Subreddit{ID: 1},
+				},
+			},
+			minResolution: &monitor.Resolution{HeightPx: 1200, WidthPx: 1920},
+			want:          &Submission{Title: "Laguna Sunrise [1920x1200]", ImageHeightPx: 1200, ImageWidthPx: 1920},
+		},
+		{
+			tname: "favorites only",
+			repositorySubmissions: []*Submission{
+				{
+					Title:         "Moroccan Sunset [2560x1440]",
+					ImageHeightPx: 1440,
+					ImageWidthPx:  2560,
+					Subreddit:     &Subreddit{ID: 1},
+					Rating:        3,
+				},
+				{
+					Title:         "Laguna Sunrise [1920x1200]",
+					ImageHeightPx: 1200,
+					ImageWidthPx:  1920,
+					Subreddit:     &Subreddit{ID: 1},
+					Rating:        RatingFavorite,
+				},
+			},
+			minResolution: &monitor.Resolution{HeightPx: 1200, WidthPx: 1920},
+			filter:        Filter{MinRating: RatingFavorite},
+			want:          &Submission{Title: "Laguna Sunrise [1920x1200]", ImageHeightPx: 1200, ImageWidthPx: 1920},
+		},
+		{
+			tname: "exclude disliked",
+			repositorySubmissions: []*Submission{
+				{
+					Title:         "Moroccan Sunset [2560x1440]",
+					ImageHeightPx: 1440,
+					ImageWidthPx:  2560,
+					Subreddit:     &Subreddit{ID: 1},
+					Rating:        RatingDisliked,
+				},
+				{
+					Title:         "Laguna Sunrise [1920x1200]",
+					ImageHeightPx: 1200,
+					ImageWidthPx:  1920,
+					Subreddit:     &Subreddit{ID: 1},
+				},
+			},
+			minResolution: &monitor.Resolution{HeightPx: 1200, WidthPx: 1920},
+			filter:        Filter{ExcludeDisliked: true},
+			want:          &Submission{Title: "Laguna Sunrise [1920x1200]", ImageHeightPx: 1200, ImageWidthPx: 1920},
+		},
+		{
+			tname: "NSFW excluded by default",
+			repositorySubmissions: []*Submission{
+				{
+					Title:         "Moroccan Sunset [2560x1440]",
+					ImageHeightPx: 1440,
+					ImageWidthPx:  2560,
+					ImageNSFW:     true,
+					Subreddit:     &Subreddit{ID: 1},
+				},
+				{
+					Title:         "Laguna Sunrise [1920x1200]",
+					ImageHeightPx: 1200,
+					ImageWidthPx:  1920,
+					Subreddit:     &Subreddit{ID: 1},
+				},
+			},
+			minResolution: &monitor.Resolution{HeightPx: 1200, WidthPx: 1920},
+			want:          &Submission{Title: "Laguna Sunrise [1920x1200]", ImageHeightPx: 1200, ImageWidthPx: 1920},
+		},
+		{
+			tname: "NSFW only",
+			repositorySubmissions: []*Submission{
+				{
+					Title:         "Moroccan Sunset [2560x1440]",
+					ImageHeightPx: 1440,
+					ImageWidthPx:  2560,
+					ImageNSFW:     true,
+					Subreddit:     &Subreddit{ID: 1},
+				},
+				{
+					Title:         "Laguna Sunrise [1920x1200]",
+					ImageHeightPx: 1200,
+					ImageWidthPx:  1920,
+					Subreddit:     &Subreddit{ID: 1},
+				},
+			},
+			minResolution: &monitor.Resolution{HeightPx: 1200, WidthPx: 1920},
+			filter:        Filter{NSFW: NSFWOnly},
+			want:          &Submission{Title: "Moroccan Sunset [2560x1440]", ImageHeightPx: 1440, ImageWidthPx: 2560},
+		},
+		{
+			tname: "aspect ratio",
+			repositorySubmissions: []*Submission{
+				{
+					Title:         "Square Garden [6000x6000]",
+					ImageHeightPx: 6000,
+					ImageWidthPx:  6000,
+					Subreddit:     &Subreddit{ID: 1},
+				},
+				{
+					Title:         "Tall Tower [4000x8000]",
+					ImageHeightPx: 8000,
+					ImageWidthPx:  4000,
+					Subreddit:     &Subreddit{ID: 1},
+				},
+				{
+					Title:         "Wide Canyon [5120x2160]",
+					ImageHeightPx: 2160,
+					ImageWidthPx:  5120,
+					Subreddit:     &Subreddit{ID: 1},
+				},
+			},
+			minResolution: &monitor.Resolution{HeightPx: 1080, WidthPx: 2560},
+			filter:        Filter{AspectRatio: &monitor.AspectRatioRange{Min: 1.8, Max: 3.2}},
+			want:          &Submission{Title: "Wide Canyon [5120x2160]", ImageHeightPx: 2160, ImageWidthPx: 5120},
+		},
+		{
+			tname: "score strategy",
+			repositorySubmissions: []*Submission{
+				{
+					Title:         "Sunday Afternoon In The Park [640x480]",
+					ImageHeightPx: 480,
+					ImageWidthPx:  640,
+					Subreddit:     &Subreddit{ID: 1},
+					Score:         12000,
+				},
+				{
+					Title:         "Laguna Sunrise [1920x1200]",
+					ImageHeightPx: 1200,
+					ImageWidthPx:  1920,
+					Subreddit:     &Subreddit{ID: 1},
+					Score:         3,
+				},
+			},
+			minResolution: &monitor.Resolution{HeightPx: 1200, WidthPx: 1920},
+			strategy:      StrategyScore,
+			want:          &Submission{Title: "Laguna Sunrise [1920x1200]", ImageHeightPx: 1200, ImageWidthPx: 1920},
+		},
+		{
+			tname: "query",
+			repositorySubmissions: []*Submission{
+				{
+					Title:         "Moroccan Sunset [2560x1440]",
+					ImageHeightPx: 1440,
+					ImageWidthPx:  2560,
+					Subreddit:     &Subreddit{ID: 1},
+					Score:         850,
+				},
+				{
+					Title:         "Laguna Sunrise [1920x1200]",
+					ImageHeightPx: 1200,
+					ImageWidthPx:  1920,
+					Subreddit:     &Subreddit{ID: 1},
+					Score:         6400,
+				},
+			},
+			minResolution: &monitor.Resolution{HeightPx: 1200, WidthPx: 1920},
+			filter: Filter{Query: &Query{Conditions: []Condition{
+				&NumberCondition{Field: FieldScore, Operator: OperatorGreaterOrEqual, Value: 5000},
+			}}},
+			want: &Submission{Title: "Laguna Sunrise [1920x1200]", ImageHeightPx: 1200, ImageWidthPx: 1920},
+		},
+		{
+			tname:         "not found (empty repository)",
+			minResolution: &monitor.Resolution{HeightPx: 1200, WidthPx: 1920},
+			wantErr:       ErrSubmissionNotFound,
+		},
+		{
+			tname: "not found (no result)",
+			repositorySubmissions: []*Submission{
+				{Title: "Sunday Afternoon In The Park [640x480]", ImageHeightPx: 480, ImageWidthPx: 640},
+				{Title: "Laguna Sunrise [1920x1200]", ImageHeightPx: 1200, ImageWidthPx: 1920},
+			},
+			minResolution: &monitor.Resolution{HeightPx: 1440, WidthPx: 2560},
+			wantErr:       ErrSubmissionNotFound,
+		},
+		{
+			tname: "not found (no favorite)",
+			repositorySubmissions: []*Submission{
+				{
+					Title:         "Laguna Sunrise [1920x1200]",
+					ImageHeightPx: 1200,
+					ImageWidthPx:  1920,
+					Subreddit:     &Subreddit{ID: 1},
+					Rating:        4,
+				},
+			},
+			minResolution: &monitor.Resolution{HeightPx: 1200, WidthPx: 1920},
+			filter:        Filter{MinRating: RatingFavorite},
+			wantErr:       ErrSubmissionNotFound,
+		},
+
+		// error cases
+		{
+			tname: "not found (subreddit strategy)",
+			repositorySubmissions: []*Submission{
+				{Title: "Sunday Afternoon In The Park [640x480]", ImageHeightPx: 480, ImageWidthPx: 640, Subreddit: &Subreddit{ID: 1}},
+			},
+			minResolution: &monitor.Resolution{HeightPx: 1200, WidthPx: 1920},
+			strategy:      StrategySubreddit,
+			wantErr:       ErrSubmissionNotFound,
+		},
+		{
+			tname:         "negative resolution height",
+			minResolution: &monitor.Resolution{HeightPx: -1200, WidthPx: 1920},
+			wantErr:       monitor.ErrResolutionInvalid,
+		},
+		{
+			tname:         "negative resolution width",
+			minResolution: &monitor.Resolution{HeightPx: 1200, WidthPx: -1920},
+			wantErr:       monitor.ErrResolutionInvalid,
+		},
+		{
+			tname:         "negative resolution height and width",
+			minResolution: &monitor.Resolution{HeightPx: -1200, WidthPx: -1920},
+			wantErr:       monitor.ErrResolutionInvalid,
+		},
+		{
+			tname:         "invalid NSFW policy",
+			minResolution: &monitor.Resolution{HeightPx: 1200, WidthPx: 1920},
+			filter:        Filter{NSFW: "sometimes"},
+			wantErr:       ErrNSFWPolicyInvalid,
+		},
+		{
+			tname:         "invalid minimum rating",
+			minResolution: &monitor.Resolution{HeightPx: 1200, WidthPx: 1920},
+			filter:        Filter{MinRating: 6},
+			wantErr:       ErrRatingInvalid,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			repository := NewRepositoryInMemory(tc.repositorySubmissions, repositorySubreddits)
+			service := NewService(repository)
+
+			strategy, err := NewSelectionStrategy(cmp.Or(tc.strategy, DefaultSelectionStrategy))
+			if err != nil {
+				t.Fatalf("failed to initialize strategy: %q", err)
+			}
+
+			submission, err := service.Random(t.Context(), tc.minResolution, &tc.filter, strategy, DefaultRepeatPolicy)
+
+			if tc.wantErr != nil {
+				if err == nil {
+					t.Error("expected an error but got none")
+				} else if !errors.Is(err, tc.wantErr) {
+					t.Errorf("want error %q, got %q", tc.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error but got %q", err)
+				return
+			}
+
+			if submission.Title != tc.want.Title {
+				t.Errorf("want name %q, got %q", tc.want.Title, submission.Title)
+			}
+		})
+	}
+}
+
+func TestServiceRandomRepeat(t *testing.T) {
+	now := time.Now().UTC()
+
+	repositorySubreddits := []*Subreddit{
+		{ID: 1, Name: "EarthPorn"},
+	}
+	repositorySubmissions := []*Submission{
+		{ID: 1, Title: "first", ImageHeightPx: 1080, ImageWidthPx: 1920, Subreddit: &Subreddit{ID: 1}},
+		{ID: 2, Title: "second", ImageHeightPx: 1080, ImageWidthPx: 1920, Subreddit: &Subreddit{ID: 1}},
+		{ID: 3, Title: "third", ImageHeightPx: 1080, ImageWidthPx: 1920, Subreddit: &Subreddit{ID: 1}},
+	}
+	minResolution := &monitor.Resolution{HeightPx: 1080, WidthPx: 1920}
+
+	type shown struct {
+		submissionID int
+		date         time.Time
+	}
+
+	testCases := []struct {
+		tname     string
+		history   []shown
+		repeat    RepeatPolicy
+		strategy  string
+		wantTitle string
+		wantErr   error
+	}{
+		// nominal cases
+		{
+			tname: "never repeat",
+			history: []shown{
+				{1, now.AddDate(-1, 0, 0)},
+				{2, now.AddDate(0, 0, -1)},
+			},
+			repeat:    RepeatPolicy{Mode: RepeatNever},
+			wantTitle: "third",
+		},
+		{
+			tname: "repeat after days",
+			history: []shown{
+				{1, now.AddDate(0, 0, -40)},
+				{2, now.AddDate(0, 0, -10)},
+				{3, now.AddDate(0, 0, -5)},
+			},
+			repeat:    RepeatPolicy{Mode: RepeatAfterDays, Count: 30},
+			wantTitle: "first",
+		},
+		{
+			tname: "repeat after selections",
+			history: []shown{
+				{1, now.AddDate(0, 0, -3)},
+				{2, now.AddDate(0, 0, -2)},
+				{3, now.AddDate(0, 0, -1)},
+			},
+			repeat:    RepeatPolicy{Mode: RepeatAfterSelections, Count: 2},
+			wantTitle: "first",
+		},
+		{
+			tname: "cycle through the least selected",
+			history: []shown{
+				{1, now.AddDate(0, 0, -5)},
+				{2, now.AddDate(0, 0, -4)},
+				{3, now.AddDate(0, 0, -3)},
+				{1, now.AddDate(0, 0, -2)},
+				{2, now.AddDate(0, 0, -1)},
+			},
+			repeat:    RepeatPolicy{Mode: RepeatCycle},
+			wantTitle: "third",
+		},
+		{
+			tname: "cycle with the subreddit strategy",
+			history: []shown{
+				{1, now.AddDate(0, 0, -3)},
+				{2, now.AddDate(0, 0, -2)},
+			},
+			repeat:    RepeatPolicy{Mode: RepeatCycle},
+			strategy:  StrategySubreddit,
+			wantTitle: "third",
+		},
+
+		// error cases
+		{
+			tname: "all candidates shown, never repeat",
+			history: []shown{
+				{1, now.AddDate(-1, 0, 0)},
+				{2, now.AddDate(0, 0, -1)},
+				{3, now.AddDate(0, 0, -1)},
+			},
+			repeat:  RepeatPolicy{Mode: RepeatNever},
+			wantErr: ErrSubmissionNotFound,
+		},
+		{
+			tname: "all candidates shown recently",
+			history: []shown{
+				{1, now.AddDate(0, 0, -3)},
+				{2, now.AddDate(0, 0, -2)},
+				{3, now.AddDate(0, 0, -1)},
+			},
+			repeat:   RepeatPolicy{Mode: RepeatAfterDays, Count: 7},
+			strategy: StrategyScore,
+			wantErr:  ErrSubmissionNotFound,
+		},
+		{
+			tname:   "missing repeat count",
+			repeat:  RepeatPolicy{Mode: RepeatAfterDays},
+			wantErr: ErrRepeatPolicyInvalid,
+		},
+		{
+			tname:   "unknown repeat mode",
+			repeat:  RepeatPolicy{Mode: "always"},
+			wantErr: ErrRepeatPolicyInvalid,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			repository := NewRepositoryInMemory(repositorySubmissions, repositorySubreddits)
+			for _, entry := range tc.history {
+				repository.RecordSelection(entry.submissionID, entry.date)
+			}
+
+			service := NewService(repository)
+
+			strategy, err := NewSelectionStrategy(cmp.Or(tc.strategy, DefaultSelectionStrategy))
+			if err != nil {
+				t.Fatalf("failed to initialize strategy: %q", err)
+			}
+
+			submission, err := service.Random(t.Context(), minResolution, &Filter{}, strategy, tc.repeat)
+
+			if tc.wantErr != nil {
+				if err == nil {
+					t.Error("expected an error but got none")
+				} else if !errors.Is(err, tc.wantErr) {
+					t.Errorf("want error %q, got %q", tc.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error but got %q", err)
+				return
+			}
+
+			if submission.Title != tc.wantTitle {
+				t.Errorf("want title %q, got %q", tc.wantTitle, submission.Title)
+			}
+		})
+	}
+}
+
+func TestServiceSearch(t *testing.T) {
+	testCases := []struct {
+		tname                 string
+		repositorySubreddits  []*Subreddit
+		repositorySubmissions []*Submission
+		text                  string
+		filter                Filter
+		options               ListOptions
+		want                  []*Submission
+		wantSnippets          []string
+		wantErr               error
+	}{
+		// nominal cases
+		{
+			tname: "single result",
+			repositorySubreddits: []*Subreddit{
+				{ID: 1, Name: "astrophotography"},
+			},
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "m31aga", Subreddit: &Subreddit{ID: 1}, Title: "Messier 31 - The Andromeda Galaxy"},
+				{ID: 2, PostID: "owlsrf", Subreddit: &Subreddit{ID: 1}, Title: "The Owl Nebula and Surfboard Galaxy"},
+			},
+			text: "nebula",
+			want: []*Submission{
+				{
+					ID:        2,
+					PostID:    "owlsrf",
+					Title:     "The Owl Nebula and Surfboard Galaxy",
+					Subreddit: &Subreddit{ID: 1, Name: "astrophotography"},
+				},
+			},
+		},
+		{
+			tname: "multiple results",
+			repositorySubreddits: []*Subreddit{
+				{ID: 1, Name: "astrophotography"},
+			},
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "m31aga", Subreddit: &Subreddit{ID: 1}, Title: "Messier 31 - The Andromeda Galaxy"},
+				{ID: 2, PostID: "owlsrf", Subreddit: &Subreddit{ID: 1}, Title: "The Owl Nebula and Surfboard Galaxy"},
+			},
+			text: "galaxy",
+			want: []*Submission{
+				{
+					ID:        1,
+					PostID:    "m31aga",
+					Title:     "Messier 31 - The Andromeda Galaxy",
+					Subreddit: &Subreddit{ID: 1, Name: "astrophotography"},
+				},
+				{
+					ID:        2,
+					PostID:    "owlsrf",
+					Title:     "The Owl Nebula and Surfboard Galaxy",
+					Subreddit: &Subreddit{ID: 1, Name: "astrophotography"},
+				},
+			},
+		},
+		{
+			tname: "phrase",
+			repositorySubreddits: []*Subreddit{
+				{ID: 1, Name: "EarthPorn"},
+			},
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "lakmnt", Subreddit: &Subreddit{ID: 1}, Title: "Lake below the mountain"},
+				{ID: 2, PostID: "mntlak", Subreddit: &Subreddit{ID: 1}, Title: "Mountain lake at dawn"},
+			},
+			text: `"mountain lake"`,
+			want: []*Submission{
+				{
+					ID:        2,
+					PostID:    "mntlak",
+					Title:     "Mountain lake at dawn",
+					Subreddit: &Subreddit{ID: 1, Name: "EarthPorn"},
+				},
+			},
+			wantSnippets: []string{
+				HighlightStart + "Mountain" + HighlightEnd + " " + HighlightStart + "lake" + HighlightEnd + " at dawn",
+			},
+		},
+		{
+			tname: "prefix",
+			repositorySubreddits: []*Subreddit{
+				{ID: 1, Name: "EarthPorn"},
+			},
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "sunset", Subreddit: &Subreddit{ID: 1}, Title: "Sunset over the bay"},
+				{ID: 2, PostID: "sunday", Subreddit: &Subreddit{ID: 1}, Title: "A Sunday hike"},
+				{ID: 3, PostID: "moonrs", Subreddit: &Subreddit{ID: 1}, Title: "Moonrise"},
+			},
+			text: "sun*",
+			want: []*Submission{
+				{
+					ID:        1,
+					PostID:    "sunset",
+					Title:     "Sunset over the bay",
+					Subreddit: &Subreddit{ID: 1, Name: "EarthPorn"},
+				},
+				{
+					ID:        2,
+					PostID:    "sunday",
+					Title:     "A Sunday hike",
+					Subreddit: &Subreddit{ID: 1, Name: "EarthPorn"},
+				},
+			},
+		},
+		{
+			tname: "ranked across fields",
+			repositorySubreddits: []*Subreddit{
+				{ID: 1, Name: "EarthPorn"},
+				{ID: 2, Name: "WinterPorn"},
+			},
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "author", Subreddit: &Subreddit{ID: 1}, Title: "Frozen pond", Author: "winter_walker"},
+				{ID: 2, PostID: "subred", Subreddit: &Subreddit{ID: 2}, Title: "Frozen lake"},
+				{ID: 3, PostID: "tagged", Subreddit: &Subreddit{ID: 1}, Title: "Frozen river", Tags: []string{"winter"}},
+				{ID: 4, PostID: "titled", Subreddit: &Subreddit{ID: 1}, Title: "Frozen forest in winter"},
+			},
+			text: "frozen winter",
+			want: []*Submission{
+				{
+					ID:        4,
+					PostID:    "titled",
+					Title:     "Frozen forest in winter",
+					Subreddit: &Subreddit{ID: 1, Name: "EarthPorn"},
+				},
+				{
+					ID:        3,
+					PostID:    "tagged",
+					Title:     "Frozen river",
+					Subreddit: &Subreddit{ID: 1, Name: "EarthPorn"},
+				},
+				{
+					ID:        1,
+					PostID:    "author",
+					Title:     "Frozen pond",
+					Subreddit: &Subreddit{ID: 1, Name: "EarthPorn"},
+				},
+			},
+		},
+		{
+			tname: "minimum rating",
+			repositorySubreddits: []*Subreddit{
+				{ID: 1, Name: "astrophotography"},
+			},
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "m31aga", Subreddit: &Subreddit{ID: 1}, Title: "Messier 31 - The Andromeda Galaxy", Rating: 4},
+				{ID: 2, PostID: "owlsrf", Subreddit: &Subreddit{ID: 1}, Title: "The Owl Nebula and Surfboard Galaxy", Rating: 2},
+			},
+			text:   "galaxy",
+			filter: Filter{MinRating: 3},
+			want: []*Submission{
+				{
+					ID:        1,
+					PostID:    "m31aga",
+					Title:     "Messier 31 - The Andromeda Galaxy",
+					Subreddit: &Subreddit{ID: 1, Name: "astrophotography"},
+					Rating:    4,
+				},
+			},
+		},
+		{
+			tname: "disliked only",
+			repositorySubreddits: []*Subreddit{
+				{ID: 1, Name: "astrophotography"},
+			},
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "m31aga", Subreddit: &Subreddit{ID: 1}, Title: "Messier 31 - The Andromeda Galaxy"},
+				{ID: 2, PostID: "owlsrf", Subreddit: &Subreddit{ID: 1}, Title: "The Owl Nebula and Surfboard Galaxy", Rating: RatingDisliked},
+			},
+			text:   "galaxy",
+			filter: Filter{DislikedOnly: true},
+			want: []*Submission{
+				{
+					ID:        2,
+					PostID:    "owlsrf",
+					Title:     "The Owl Nebula and Surfboard Galaxy",
+					Subreddit: &Subreddit{ID: 1, Name: "astrophotography"},
+					Rating:    RatingDisliked,
+				},
+			},
+		},
+
+		{
+			tname: "tags",
+			repositorySubreddits: []*Subreddit{
+				{ID: 1, Name: "astrophotography"},
+			},
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "m31aga", Subreddit: &Subreddit{ID: 1}, Title: "Messier 31 - The Andromeda Galaxy", Tags: []string{"galaxy", "space"}},
+				{ID: 2, PostID: "owlsrf", Subreddit: &Subreddit{ID: 1}, Title: "The Owl Nebula and Surfboard Galaxy", Tags: []string{"nebula", "space"}},
+				{ID: 3, PostID: "sombga", Subreddit: &Subreddit{ID: 1}, Title: "The Sombrero Galaxy", Tags: []string{"space"}},
+			},
+			text:   "galaxy",
+			filter: Filter{Tags: []string{"Space"}, ExcludeTags: []string{"nebula"}},
+			want: []*Submission{
+				{
+					ID:        1,
+					PostID:    "m31aga",
+					Title:     "Messier 31 - The Andromeda Galaxy",
+					Subreddit: &Subreddit{ID: 1, Name: "astrophotography"},
+				},
+				{
+					ID:        3,
+					PostID:    "sombga",
+					Title:     "The Sombrero Galaxy",
+					Subreddit: &Subreddit{ID: 1, Name: "astrophotography"},
+				},
+			},
+		},
+
+		{
+			tname: "query",
+			repositorySubreddits: []*Subreddit{
+				{ID: 1, Name: "EarthPorn"},
+				{ID: 2, Name: "WinterPorn"},
+			},
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "lake1", Subreddit: &Subreddit{ID: 1}, Title: "Mountain lake at dawn", Score: 7200, PostedAt: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)},
+				{ID: 2, PostID: "lake2", Subreddit: &Subreddit{ID: 1}, Title: "Mountain lake at dusk", Score: 1200, PostedAt: time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)},
+				{ID: 3, PostID: "lake3", Subreddit: &Subreddit{ID: 1}, Title: "Mountain lake in spring", Score: 9100, PostedAt: time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)},
+				{ID: 4, PostID: "lake4", Subreddit: &Subreddit{ID: 2}, Title: "Frozen mountain lake", Score: 8800, PostedAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)},
+				{ID: 5, PostID: "lake5", Subreddit: &Subreddit{ID: 1}, Title: "Mountain lake at night", Score: 5400, PostedAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), ImageNSFW: true},
+			},
+			text: `subreddit:earthporn score>=5000 nsfw:false posted:2023.. "mountain lake"`,
+			want: []*Submission{
+				{
+					ID:        1,
+					PostID:    "lake1",
+					Title:     "Mountain lake at dawn",
+					Subreddit: &Subreddit{ID: 1, Name: "EarthPorn"},
+				},
+			},
+		},
+		{
+			tname: "query without search terms",
+			repositorySubreddits: []*Subreddit{
+				{ID: 1, Name: "EarthPorn"},
+			},
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "wide", Subreddit: &Subreddit{ID: 1}, Title: "Wide valley", ImageWidthPx: 3840},
+				{ID: 2, PostID: "narrow", Subreddit: &Subreddit{ID: 1}, Title: "Narrow canyon", ImageWidthPx: 1920},
+			},
+			text: "width>=3840",
+			want: []*Submission{
+				{
+					ID:        1,
+					PostID:    "wide",
+					Title:     "Wide valley",
+					Subreddit: &Subreddit{ID: 1, Name: "EarthPorn"},
+				},
+			},
+			wantSnippets: []string{"Wide valley"},
+		},
+		{
+			tname: "negated query conditions",
+			repositorySubreddits: []*Subreddit{
+				{ID: 1, Name: "astrophotography"},
+			},
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "m31aga", Subreddit: &Subreddit{ID: 1}, Title: "Messier 31 - The Andromeda Galaxy", Author: "stargazer"},
+				{ID: 2, PostID: "owlsrf", Subreddit: &Subreddit{ID: 1}, Title: "The Owl Nebula and Surfboard Galaxy"},
+				{ID: 3, PostID: "sombga", Subreddit: &Subreddit{ID: 1}, Title: "The Sombrero Galaxy"},
+			},
+			text: "galaxy -nebula -author:StarGazer",
+			want: []*Submission{
+				{
+					ID:        3,
+					PostID:    "sombga",
+					Title:     "The Sombrero Galaxy",
+					Subreddit: &Subreddit{ID: 1, Name: "astrophotography"},
+				},
+			},
+		},
+
+		{
+			tname: "sorted by score, first page",
+			repositorySubreddits: []*Subreddit{
+				{ID: 1, Name: "astrophotography"},
+			},
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "m31aga", Subreddit: &Subreddit{ID: 1}, Title: "Messier 31 - The Andromeda Galaxy", Score: 1200},
+				{ID: 2, PostID: "owlsrf", Subreddit: &Subreddit{ID: 1}, Title: "The Owl Nebula and Surfboard Galaxy", Score: 5300},
+				{ID: 3, PostID: "sombga", Subreddit: &Subreddit{ID: 1}, Title: "The Sombrero Galaxy", Score: 800},
+			},
+			text:    "galaxy",
+			options: ListOptions{Sort: SortScore, Page: Page{Limit: 2}},
+			want: []*Submission{
+				{
+					ID:        2,
+					PostID:    "owlsrf",
+					Title:     "The Owl Nebula and Surfboard Galaxy",
+					Subreddit: &Subreddit{ID: 1, Name: "astrophotography"},
+				},
+				{
+					ID:        1,
+					PostID:    "m31aga",
+					Title:     "Messier 31 - The Andromeda Galaxy",
+					Subreddit: &Subreddit{ID: 1, Name: "astrophotography"},
+				},
+			},
+		},
+		{
+			tname: "ranked, second page",
+			repositorySubreddits: []*Subreddit{
+				{ID: 1, Name: "astrophotography"},
+			},
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "m31aga", Subreddit: &Subreddit{ID: 1}, Title: "Messier 31 - The Andromeda Galaxy"},
+				{ID: 2, PostID: "owlsrf", Subreddit: &Subreddit{ID: 1}, Title: "The Owl Nebula and Surfboard Galaxy", Tags: []string{"galaxy"}},
+				{ID: 3, PostID: "sombga", Subreddit: &Subreddit{ID: 1}, Title: "The Sombrero Galaxy"},
+			},
+			text:    "galaxy",
+			options: ListOptions{Page: Page{Limit: 2, Offset: 2}},
+			want: []*Submission{
+				{
+					ID:        3,
+					PostID:    "sombga",
+					Title:     "The Sombrero Galaxy",
+					Subreddit: &Subreddit{ID: 1, Name: "astrophotography"},
+				},
+			},
+		},
+
+		{
+			tname: "unknown query field as search text",
+			repositorySubreddits: []*Subreddit{
+				{ID: 1, Name: "Animewallpaper"},
+			},
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "rezero", Subreddit: &Subreddit{ID: 1}, Title: "Re:Zero - Emilia and Puck"},
+				{ID: 2, PostID: "zeroem", Subreddit: &Subreddit{ID: 1}, Title: "Zero Two in the rain"},
+			},
+			text: "Re:Zero",
+			want: []*Submission{
+				{
+					ID:        1,
+					PostID:    "rezero",
+					Title:     "Re:Zero - Emilia and Puck",
+					Subreddit: &Subreddit{ID: 1, Name: "Animewallpaper"},
+				},
+			},
+		},
+
+		{
+			tname: "NSFW included",
+			repositorySubreddits: []*Subreddit{
+				{ID: 1, Name: "astrophotography"},
+			},
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "m31aga", Subreddit: &Subreddit{ID: 1}, Title: "Messier 31 - The Andromeda Galaxy", ImageNSFW: true},
+				{ID: 2, PostID: "sombga", Subreddit: &Subreddit{ID: 1}, Title: "The Sombrero Galaxy"},
+			},
+			text:   "galaxy",
+			filter: Filter{NSFW: NSFWInclude},
+			want: []*Submission{
+				{
+					ID:        1,
+					PostID:    "m31aga",
+					Title:     "Messier 31 - The Andromeda Galaxy",
+					Subreddit: &Subreddit{ID: 1, Name: "astrophotography"},
+				},
+				{
+					ID:        2,
+					PostID:    "sombga",
+					Title:     "The Sombrero Galaxy",
+					Subreddit: &Subreddit{ID: 1, Name: "astrophotography"},
+				},
+			},
+		},
+		{
+			tname: "NSFW excluded by default",
+			repositorySubreddits: []*Subreddit{
+				{ID: 1, Name: "astrophotography"},
+			},
+			repositorySubmissions: []*Submission{
+				{ID: 1, PostID: "m31aga", Subreddit: &Subreddit{ID: 1}, Title: "Messier 31 - The Andromeda Galaxy", ImageNSFW: true},
+				{ID: 2, PostID: "sombga", Subreddit: &Subreddit{ID: 1}, Title: "The Sombrero Galaxy"},
+			},
+			text: "galaxy",
+			want: []*Submission{
+				{
+					ID:        2,
+					PostID:    "sombga",
+					Title:     "The Sombrero Galaxy",
+					Subreddit: &Subreddit{ID: 1, Name: "astrophotography"},
+				},
+			},
+		},
+
+		// error cases
+		{
+			tname:   "empty text",
+			text:    "",
+			wantErr: ErrSubmissionSearchTextEmpty,
+		},
+		{
+			tname:   "whitespace (empty) text",
+			text:    "       ",
+			wantErr: ErrSubmissionSearchTextEmpty,
+		},
+		{
+			tname:   "punctuation (empty) text",
+			text:    `"" * -`,
+			wantErr: ErrSubmissionSearchTextEmpty,
+		},
+		{
+			tname:   "negative minimum rating",
+			text:    "galaxy",
+			filter:  Filter{MinRating: RatingDisliked},
+			wantErr: ErrRatingInvalid,
+		},
+		{
+			tname:   "invalid tag",
+			text:    "galaxy",
+			filter:  Filter{Tags: []string{"deep space"}},
+			wantErr: ErrTagNameInvalid,
+		},
+		{
+			tname:   "direction without sort key",
+			text:    "galaxy",
+			options: ListOptions{Direction: SortDescending},
+			wantErr: ErrListSortInvalid,
+		},
+		{
+			tname:   "negative offset",
+			text:    "galaxy",
+			options: ListOptions{Page: Page{Offset: -2}},
+			wantErr: ErrListOffsetInvalid,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			repository := NewRepositoryInMemory(tc.repositorySubmissions, tc.repositorySubreddits)
+			service := NewService(repository)
+
+			results, err := service.Search(t.Context(), tc.text, &tc.filter, &tc.options)
+
+			if tc.wantErr != nil {
+				if err == nil {
+					t.Error("expected an errot but got none")
+				} else if !errors.Is(err, tc.wantErr) {
+					t.Errorf("want error %q, got %q", tc.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error, got %q", err)
+				return
+			}
+
+			if len(results) != len(tc.want) {
+				t.Errorf("want %d submissions, got %d", len(tc.want), len(results))
+				return
+			}
+
+			for index, want := range tc.want {
+				assertSubmissionEquals(t, want, results[index].Submission)
+				assertSubmissionSubredditEquals(t, want, results[index].Submission)
+			}
+
+			for index, wantSnippet := range tc.wantSnippets {
+				if results[index].Snippet != wantSnippet {
+					t.Errorf("want snippet %q, got %q", wantSnippet, results[index].Snippet)
+				}
+			}
+		})
+	}
+}
+
+func TestServiceSubredditByName(t *testing.T) {
+	testCases := []struct {
+		tname                string
+		repositorySubreddits []*Subreddit
+		name                 string
+		want                 *Subreddit
+		wantErr              error
+	}{
+		// nominal cases
+		{
+			tname: "return by ID",
+			repositorySubreddits: []*Subreddit{
+				{ID: 1, Name: "astrophotography"},
+				{ID: 2, Name: "FromSpaceWithLove"},
+				{ID: 3, Name: "Museum"},
+			},
+			name: "FromSpaceWithLove",
+			want: &Subreddit{ID: 2, Name: "FromSpaceWithLove"},
+		},
+		{
+			tname:   "not found",
+			name:    "Unknown",
+			wantErr: ErrSubredditNotFound,
+		},
+
+		// error cases
+		{
+			tname:   "empty name",
+			name:    "",
+			wantErr: ErrSubredditNameEmpty,
+		},
+		{
+			tname:   "empty name (whitespace)",
+			name:    "     ",
+			wantErr: ErrSubredditNameEmpty,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			repository := NewRepositoryInMemory(nil, tc.repositorySubreddits)
+			validator := NewService(repository)
+
+			subreddit, err := validator.SubredditByName(t.Context(), tc.name)
 
 			if tc.wantErr != nil {
 				if err == nil {
@@ -837,7 +2022,7 @@ func TestServiceSubredditCreate(t *testing.T) {
 			currentID := repository.subredditCurrentID
 			service := NewService(repository)
 
-			err := service.SubredditCreate(tc.subreddit)
+			err := service.SubredditCreate(t.Context(), tc.subreddit)
 
 			if tc.wantErr != nil {
 				if err == nil {
@@ -853,7 +2038,7 @@ func TestServiceSubredditCreate(t *testing.T) {
 				t.Errorf("expected no error but got %q", err)
 			}
 
-			subreddit, err := service.subredditByID(currentID)
+			subreddit, err := repository.SubredditGetByID(t.Context(), currentID)
 			if err != nil {
 				t.Errorf("failed to retrieve subreddit: %q", err)
 			}
@@ -880,6 +2065,9 @@ func assertSubmissionEquals(t *testing.T, want, got *Submission) {
 	if got.Subreddit.ID != want.Subreddit.ID {
 		t.Errorf("want subreddit ID %d, got %d", want.Subreddit.ID, got.Subreddit.ID)
 	}
+	if got.Rating != want.Rating {
+		t.Errorf("want rating %d, got %d", want.Rating, got.Rating)
+	}
 }
 
 func assertSubmissionSubredditEquals(t *testing.T, want, got *Submission) {
@@ -897,3 +2085,97 @@ func assertSubmissionSubredditEquals(t *testing.T, want, got *Submission) {
 		t.Errorf("want subreddit name %q, got %q", want.Subreddit.Name, got.Subreddit.Name)
 	}
 }
+
+func TestServiceBan(t *testing.T) {
+	testCases := []struct {
+		tname       string
+		bans        []*Ban
+		ban         *Ban
+		wantErr     error
+		wantPostID  string
+		wantSHA256  string
+		checkSHA256 string
+	}{
+		// nominal cases
+		{
+			tname:       "new ban",
+			ban:         &Ban{PostID: " m31aga ", ImageSHA256: " 0A1B2C "},
+			wantPostID:  "m31aga",
+			wantSHA256:  "0a1b2c",
+			checkSHA256: "0A1B2C",
+		},
+		{
+			tname:      "new ban without image checksum",
+			ban:        &Ban{PostID: "owlsrf"},
+			wantPostID: "owlsrf",
+		},
+
+		// error cases
+		{
+			tname: "duplicate post ID",
+			bans: []*Ban{
+				{PostID: "dupdup"},
+			},
+			ban:     &Ban{PostID: "dupdup"},
+			wantErr: ErrBanPostIDAlreadyRegistered,
+		},
+		{
+			tname:   "empty post ID",
+			ban:     &Ban{PostID: "   "},
+			wantErr: ErrBanPostIDEmpty,
+		},
+		{
+			tname:   "non-default ID",
+			ban:     &Ban{ID: 12, PostID: "nondft"},
+			wantErr: ErrBanIDInvalid,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			repository := NewRepositoryInMemory(nil, nil)
+			service := NewService(repository)
+
+			for _, ban := range tc.bans {
+				if err := service.Ban(t.Context(), ban); err != nil {
+					t.Fatalf("failed to create ban: %q", err)
+				}
+			}
+
+			err := service.Ban(t.Context(), tc.ban)
+
+			if tc.wantErr != nil {
+				if err == nil {
+					t.Error("expected an error but got none")
+				} else if !errors.Is(err, tc.wantErr) {
+					t.Errorf("want error %q, got %q", tc.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error but got %q", err)
+				return
+			}
+
+			banned, err := service.IsBanned(t.Context(), tc.wantPostID)
+			if err != nil {
+				t.Errorf("failed to query ban list: %q", err)
+				return
+			}
+			if !banned {
+				t.Errorf("expected post %q to be banned", tc.wantPostID)
+			}
+
+			imageBanned, err := service.IsImageBanned(t.Context(), tc.checkSHA256)
+			if err != nil {
+				t.Errorf("failed to query ban list: %q", err)
+				return
+			}
+			if imageBanned != (tc.wantSHA256 != "") {
+				t.Errorf("want image banned %t, got %t", tc.wantSHA256 != "", imageBanned)
+			}
+		})
+	}
+}
@@ -5,3 +5,9 @@ type SubredditStats struct {
 	Name        string
 	Submissions int
 }
+
+// TagStats holds the aggregated usage statistics for a given tag.
+type TagStats struct {
+	Name        string
+	Submissions int
+}
@@ -0,0 +1,160 @@
+package submission
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	StrategyRecency   string = "recency"
+	StrategyScore     string = "score"
+	StrategySubreddit string = "subreddit"
+	StrategyUniform   string = "uniform"
+
+	// DefaultSelectionStrategy is the name of the SelectionStrategy used
+	// unless another strategy is set.
+	DefaultSelectionStrategy string = StrategyUniform
+
+	// RecencyHalfLife is the age difference after which a Submission is half
+	// as likely to be selected with the recency strategy.
+	RecencyHalfLife time.Duration = 180 * 24 * time.Hour
+)
+
+// SelectionStrategies lists the names of the available SelectionStrategies.
+var SelectionStrategies = []string{
+	StrategyRecency,
+	StrategyScore,
+	StrategySubreddit,
+	StrategyUniform,
+}
+
+// SelectionStrategy chooses a Submission among the candidates for random
+// selection.
+type SelectionStrategy interface {
+	// Select returns one of the candidates, which MUST NOT be empty.
+	Select(candidates []*Submission) *Submission
+}
+
+// NewSelectionStrategy returns the SelectionStrategy for a given name.
+//
+// The available strategies are:
+//
+//   - uniform: all candidates are equally likely;
+//   - score: candidates are weighted by their Reddit score;
+//   - subreddit: all subreddits are equally likely, regardless of how many
+//     candidates they have;
+//   - recency: candidates are weighted by their posting date, with a weight
+//     halved every RecencyHalfLife.
+func NewSelectionStrategy(name string) (SelectionStrategy, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case StrategyRecency:
+		return newWeightedStrategy(recencyWeights), nil
+	case StrategyScore:
+		return newWeightedStrategy(scoreWeights), nil
+	case StrategySubreddit:
+		return newWeightedStrategy(subredditWeights), nil
+	case StrategyUniform:
+		return &uniformStrategy{}, nil
+	}
+
+	return nil, fmt.Errorf("%w %q", ErrSelectionStrategyInvalid, name)
+}
+
+// uniformStrategy selects all candidates with the same probability.
+//
+// As it does not depend on the candidates' attributes, the Service delegates
+// the selection to the Repository.
+type uniformStrategy struct{}
+
+func (s *uniformStrategy) Select(candidates []*Submission) *Submission {
+	return candidates[rand.IntN(len(candidates))]
+}
+
+// weightedStrategy selects candidates with a probability proportional to
+// their weight.
+type weightedStrategy struct {
+	weights func(candidates []*Submission) []float64
+
+	// float64 returns a pseudo-random number in [0.0, 1.0)
+	float64 func() float64
+}
+
+func newWeightedStrategy(weights func(candidates []*Submission) []float64) *weightedStrategy {
+	return &weightedStrategy{
+		weights: weights,
+		float64: rand.Float64,
+	}
+}
+
+func (s *weightedStrategy) Select(candidates []*Submission) *Submission {
+	weights := s.weights(candidates)
+
+	total := 0.0
+	for _, weight := range weights {
+		total += weight
+	}
+
+	target := s.float64() * total
+	cumulative := 0.0
+
+	for index, weight := range weights {
+		cumulative += weight
+
+		if target < cumulative {
+			return candidates[index]
+		}
+	}
+
+	return candidates[len(candidates)-1]
+}
+
+// recencyWeights weights candidates by their age relative to the most recent
+// candidate.
+func recencyWeights(candidates []*Submission) []float64 {
+	newest := slices.MaxFunc(candidates, func(a, b *Submission) int {
+		return a.PostedAt.Compare(b.PostedAt)
+	}).PostedAt
+
+	weights := make([]float64, len(candidates))
+
+	for index, candidate := range candidates {
+		age := newest.Sub(candidate.PostedAt)
+		weights[index] = math.Exp2(-age.Hours() / RecencyHalfLife.Hours())
+	}
+
+	return weights
+}
+
+// scoreWeights weights candidates by their score, so that posts with a
+// negative or zero score can still be selected.
+func scoreWeights(candidates []*Submission) []float64 {
+	weights := make([]float64, len(candidates))
+
+	for index, candidate := range candidates {
+		weights[index] = float64(max(candidate.Score, 0) + 1)
+	}
+
+	return weights
+}
+
+// subredditWeights weights candidates by the inverse of the number of
+// candidates from the same Subreddit.
+func subredditWeights(candidates []*Submission) []float64 {
+	counts := map[int]int{}
+
+	for _, candidate := range candidates {
+		counts[candidate.Subreddit.ID]++
+	}
+
+	weights := make([]float64, len(candidates))
+
+	for index, candidate := range candidates {
+		weights[index] = 1 / float64(counts[candidate.Subreddit.ID])
+	}
+
+	return weights
+}
@@ -0,0 +1,142 @@
+package submission
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewSelectionStrategy(t *testing.T) {
+	testCases := []struct {
+		tname   string
+		name    string
+		wantErr error
+	}{
+		// nominal cases
+		{
+			tname: "uniform",
+			name:  "uniform",
+		},
+		{
+			tname: "score, mixed case",
+			name:  " Score ",
+		},
+
+		// error cases
+		{
+			tname:   "unknown strategy",
+			name:    "popularity",
+			wantErr: ErrSelectionStrategyInvalid,
+		},
+		{
+			tname:   "empty name",
+			name:    "",
+			wantErr: ErrSelectionStrategyInvalid,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			_, err := NewSelectionStrategy(tc.name)
+
+			if tc.wantErr != nil {
+				if err == nil {
+					t.Error("expected an error but got none")
+				} else if !errors.Is(err, tc.wantErr) {
+					t.Errorf("want error %q, got %q", tc.wantErr, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Errorf("expected no error, got %q", err)
+			}
+		})
+	}
+}
+
+func TestWeightedStrategySelect(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		tname       string
+		weights     func(candidates []*Submission) []float64
+		candidates  []*Submission
+		random      float64
+		wantPostID  string
+		wantWeights []float64
+	}{
+		{
+			tname:   "score, low draw",
+			weights: scoreWeights,
+			candidates: []*Submission{
+				{PostID: "low", Score: -20},
+				{PostID: "high", Score: 9},
+			},
+			random:      0.05,
+			wantPostID:  "low",
+			wantWeights: []float64{1, 10},
+		},
+		{
+			tname:   "score, high draw",
+			weights: scoreWeights,
+			candidates: []*Submission{
+				{PostID: "low", Score: -20},
+				{PostID: "high", Score: 9},
+			},
+			random:      0.5,
+			wantPostID:  "high",
+			wantWeights: []float64{1, 10},
+		},
+		{
+			tname:   "subreddit",
+			weights: subredditWeights,
+			candidates: []*Submission{
+				{PostID: "big1", Subreddit: &Subreddit{ID: 1}},
+				{PostID: "big2", Subreddit: &Subreddit{ID: 1}},
+				{PostID: "big3", Subreddit: &Subreddit{ID: 1}},
+				{PostID: "small", Subreddit: &Subreddit{ID: 2}},
+				{PostID: "big4", Subreddit: &Subreddit{ID: 1}},
+			},
+			random:      0.6,
+			wantPostID:  "small",
+			wantWeights: []float64{0.25, 0.25, 0.25, 1, 0.25},
+		},
+		{
+			tname:   "recency",
+			weights: recencyWeights,
+			candidates: []*Submission{
+				{PostID: "older", PostedAt: now.Add(-2 * RecencyHalfLife)},
+				{PostID: "old", PostedAt: now.Add(-RecencyHalfLife)},
+				{PostID: "new", PostedAt: now},
+			},
+			random:      0.3,
+			wantPostID:  "old",
+			wantWeights: []float64{0.25, 0.5, 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.tname, func(t *testing.T) {
+			weights := tc.weights(tc.candidates)
+
+			for index, want := range tc.wantWeights {
+				if weights[index] != want {
+					t.Errorf("want weight %f for candidate %d, got %f", want, index, weights[index])
+				}
+			}
+
+			strategy := &weightedStrategy{
+				weights: tc.weights,
+				float64: func() float64 { return tc.random },
+			}
+
+			got := strategy.Select(tc.candidates)
+
+			if got.PostID != tc.wantPostID {
+				t.Errorf("want post ID %q, got %q", tc.wantPostID, got.PostID)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package submission
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -21,15 +22,39 @@ type Submission struct {
 	Score     int
 	Title     string
 
+	// Reddit post status
+	Removed         bool
+	LastRefreshedAt time.Time
+
 	// Attached image metadata
 	ImageDomain string
 	ImageURL    string
 	ImageNSFW   bool
 
+	// ImageUnavailable is set when the attached image can no longer be
+	// retrieved from its source URL.
+	ImageUnavailable bool
+
 	// Local image metadata
 	ImageFilename string
 	ImageHeightPx int
 	ImageWidthPx  int
+
+	// Rating is set by the user, from RatingDisliked to RatingFavorite.
+	Rating int
+
+	// Tags are set by the user, and sorted by name.
+	Tags []string
+}
+
+// AspectRatio returns the aspect ratio of the attached image, expressed as
+// width / height, or 0 if the image's size is unknown.
+func (s *Submission) AspectRatio() float64 {
+	if s.ImageHeightPx < 1 {
+		return 0
+	}
+
+	return float64(s.ImageWidthPx) / float64(s.ImageHeightPx)
 }
 
 // Normalize sanitizes and normalizes all fields.
@@ -50,12 +75,31 @@ func (s *Submission) User() string {
 
 // ValidateForAddition ensures mandatory fields are properly set when adding an
 // new Submission.
-func (s *Submission) ValidateForAddition(r ValidationRepository) error {
+func (s *Submission) ValidateForAddition(ctx context.Context, r ValidationRepository) error {
 	fns := []func() error{
 		s.requirePositiveSubredditID,
 		s.requireDefaultID,
 		s.requirePostID,
-		s.ensurePostIDIsNotRegistered(r),
+		s.ensurePostIDIsNotRegistered(ctx, r),
+		s.requireTitle,
+	}
+
+	for _, fn := range fns {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateForUpdate ensures mandatory fields are properly set when updating an
+// existing Submission.
+func (s *Submission) ValidateForUpdate() error {
+	fns := []func() error{
+		s.requirePositiveSubredditID,
+		s.requirePositiveID,
+		s.requirePostID,
 		s.requireTitle,
 	}
 
@@ -100,9 +144,9 @@ func (s *Submission) requirePositiveID() error {
 	return nil
 }
 
-func (s *Submission) ensurePostIDIsNotRegistered(r ValidationRepository) func() error {
+func (s *Submission) ensurePostIDIsNotRegistered(ctx context.Context, r ValidationRepository) func() error {
 	return func() error {
-		registered, err := r.SubmissionIsPostIDRegistered(s.PostID)
+		registered, err := r.SubmissionIsPostIDRegistered(ctx, s.PostID)
 
 		if err != nil {
 			return err
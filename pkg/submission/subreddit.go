@@ -1,6 +1,7 @@
 package submission
 
 import (
+	"context"
 	"strings"
 )
 
@@ -17,11 +18,11 @@ func (sr *Subreddit) Normalize() {
 
 // ValidateForAddition ensures mandatory fields are properly set when adding an
 // new Subreddit.
-func (sr *Subreddit) ValidateForAddition(r ValidationRepository) error {
+func (sr *Subreddit) ValidateForAddition(ctx context.Context, r ValidationRepository) error {
 	fns := []func() error{
 		sr.requireDefaultID,
 		sr.requireName,
-		sr.ensureNameIsNotRegistered(r),
+		sr.ensureNameIsNotRegistered(ctx, r),
 	}
 
 	for _, fn := range fns {
@@ -33,9 +34,9 @@ func (sr *Subreddit) ValidateForAddition(r ValidationRepository) error {
 	return nil
 }
 
-func (sr *Subreddit) ensureNameIsNotRegistered(r ValidationRepository) func() error {
+func (sr *Subreddit) ensureNameIsNotRegistered(ctx context.Context, r ValidationRepository) func() error {
 	return func() error {
-		registered, err := r.SubredditIsNameRegistered(sr.Name)
+		registered, err := r.SubredditIsNameRegistered(ctx, sr.Name)
 		if err != nil {
 			return err
 		}
@@ -60,14 +61,6 @@ func (sr *Subreddit) requireDefaultID() error {
 	return nil
 }
 
-func (sr *Subreddit) requirePositiveID() error {
-	if sr.ID <= 0 {
-		return ErrSubredditIDInvalid
-	}
-
-	return nil
-}
-
 func (sr *Subreddit) requireName() error {
 	if sr.Name == "" {
 		return ErrSubredditNameEmpty
@@ -0,0 +1,40 @@
+package submission
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// NormalizeTags sanitizes, deduplicates and sorts a list of tag names.
+//
+// Tag names are case-insensitive, and stored in lower case.
+func NormalizeTags(names []string) []string {
+	tags := make([]string, 0, len(names))
+
+	for _, name := range names {
+		tags = append(tags, strings.ToLower(strings.TrimSpace(name)))
+	}
+
+	slices.Sort(tags)
+
+	return slices.Compact(tags)
+}
+
+// ValidateTags ensures tag names are not empty, and do not contain whitespace
+// or commas.
+func ValidateTags(names []string) error {
+	for _, name := range names {
+		if name == "" {
+			return ErrTagNameEmpty
+		}
+
+		if strings.ContainsFunc(name, func(r rune) bool {
+			return r == ',' || unicode.IsSpace(r)
+		}) {
+			return ErrTagNameInvalid
+		}
+	}
+
+	return nil
+}